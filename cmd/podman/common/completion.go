@@ -558,6 +558,22 @@ func AutocompleteContainersAndPods(cmd *cobra.Command, args []string, toComplete
 	return append(containers, pods...), cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteForQuadlet - Autocomplete container, pod, volume and network names.
+func AutocompleteForQuadlet(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	containers, _ := getContainers(cmd, toComplete, completeDefault)
+	pods, _ := getPods(cmd, toComplete, completeDefault)
+	volumes, _ := getVolumes(cmd, toComplete)
+	networks, _ := getNetworks(cmd, toComplete, completeDefault)
+	objs := containers
+	objs = append(objs, pods...)
+	objs = append(objs, volumes...)
+	objs = append(objs, networks...)
+	return objs, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteContainersAndImages - Autocomplete container names and pod names.
 func AutocompleteContainersAndImages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -1093,9 +1109,9 @@ func AutocompleteUserFlag(cmd *cobra.Command, args []string, toComplete string)
 }
 
 // AutocompleteMountFlag - Autocomplete mount flag options.
-// -> "type=bind,", "type=volume,", "type=tmpfs,"
+// -> "type=bind,", "type=glob,", "type=overlay,", "type=volume,", "type=tmpfs,"
 func AutocompleteMountFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	types := []string{"type=bind,", "type=volume,", "type=tmpfs,"}
+	types := []string{"type=bind,", "type=glob,", "type=overlay,", "type=volume,", "type=tmpfs,"}
 	// TODO: Add support for all different options
 	return types, cobra.ShellCompDirectiveNoSpace
 }
@@ -1356,11 +1372,12 @@ func getMethodNames(f reflect.Value, prefix string) []formatSuggestion {
 func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	event := func(_ string) ([]string, cobra.ShellCompDirective) {
 		return []string{events.Attach.String(), events.AutoUpdate.String(), events.Checkpoint.String(), events.Cleanup.String(),
-			events.Commit.String(), events.Create.String(), events.Exec.String(), events.ExecDied.String(),
-			events.Exited.String(), events.Export.String(), events.Import.String(), events.Init.String(), events.Kill.String(),
-			events.LoadFromArchive.String(), events.Mount.String(), events.NetworkConnect.String(),
-			events.NetworkDisconnect.String(), events.Pause.String(), events.Prune.String(), events.Pull.String(),
-			events.Push.String(), events.Refresh.String(), events.Remove.String(), events.Rename.String(),
+			events.Commit.String(), events.Create.String(), events.Exec.String(), events.ExecCreate.String(),
+			events.ExecDied.String(), events.Exited.String(), events.Export.String(), events.HealthStatus.String(),
+			events.Import.String(), events.Init.String(), events.Kill.String(), events.LoadFromArchive.String(),
+			events.Mount.String(), events.NetworkConnect.String(), events.NetworkDisconnect.String(), events.OOM.String(),
+			events.Pause.String(), events.PortAdd.String(), events.PortRemove.String(), events.Prune.String(),
+			events.Pull.String(), events.Push.String(), events.Refresh.String(), events.Remove.String(), events.Rename.String(),
 			events.Renumber.String(), events.Restart.String(), events.Restore.String(), events.Save.String(),
 			events.Start.String(), events.Stop.String(), events.Sync.String(), events.Tag.String(), events.Unmount.String(),
 			events.Unpause.String(), events.Untag.String(),
@@ -1467,9 +1484,9 @@ func AutocompleteImageSaveFormat(cmd *cobra.Command, args []string, toComplete s
 }
 
 // AutocompleteWaitCondition - Autocomplete wait condition options.
-// -> "unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing"
+// -> "unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing", "ready"
 func AutocompleteWaitCondition(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	states := []string{"unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing"}
+	states := []string{"unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing", define.ReadinessCheckReady}
 	return states, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -1501,9 +1518,9 @@ func AutocompleteLogLevel(cmd *cobra.Command, args []string, toComplete string)
 }
 
 // AutocompleteSDNotify - Autocomplete sdnotify options.
-// -> "container", "conmon", "ignore"
+// -> "container", "conmon", "ignore", "ready"
 func AutocompleteSDNotify(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	types := []string{define.SdNotifyModeContainer, define.SdNotifyModeContainer, define.SdNotifyModeIgnore}
+	types := []string{define.SdNotifyModeContainer, define.SdNotifyModeConmon, define.SdNotifyModeIgnore, define.SdNotifyModeReady}
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -1558,12 +1575,13 @@ func AutocompletePodPsFilters(cmd *cobra.Command, args []string, toComplete stri
 func AutocompleteImageFilters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	getImg := func(s string) ([]string, cobra.ShellCompDirective) { return getImages(cmd, s) }
 	kv := keyValueCompletion{
-		"before=":    getImg,
-		"since=":     getImg,
-		"label=":     nil,
-		"reference=": nil,
-		"dangling=":  getBoolCompletion,
-		"readonly=":  getBoolCompletion,
+		"before=":     getImg,
+		"since=":      getImg,
+		"label=":      nil,
+		"reference=":  nil,
+		"dangling=":   getBoolCompletion,
+		"readonly=":   getBoolCompletion,
+		"until-used=": nil,
 	}
 	return completeKeyValues(toComplete, kv)
 }
@@ -216,6 +216,22 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(healthOnFailureFlagName, AutocompleteHealthOnFailure)
 
+		hookPostStartFlagName := "hook-post-start"
+		createFlags.StringVar(
+			&cf.HookPostStart,
+			hookPostStartFlagName, "",
+			"Command to run in the container after it has been started",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(hookPostStartFlagName, completion.AutocompleteNone)
+
+		hookPreStopFlagName := "hook-pre-stop"
+		createFlags.StringVar(
+			&cf.HookPreStop,
+			hookPreStopFlagName, "",
+			"Command to run in the container before it is stopped",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(hookPreStopFlagName, completion.AutocompleteNone)
+
 		createFlags.BoolVar(
 			&cf.HTTPProxy,
 			"http-proxy", podmanConfig.ContainersConfDefaultsRO.Containers.HTTPProxy,
@@ -386,6 +402,46 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(requiresFlagName, AutocompleteContainers)
 
+		readinessCmdFlagName := "health-readiness-cmd"
+		createFlags.StringVar(
+			&cf.ReadinessCmd,
+			readinessCmdFlagName, "",
+			"Set a readiness check command for the container",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(readinessCmdFlagName, completion.AutocompleteNone)
+
+		readinessIntervalFlagName := "health-readiness-interval"
+		createFlags.StringVar(
+			&cf.ReadinessInterval,
+			readinessIntervalFlagName, define.DefaultHealthCheckInterval,
+			"Set an interval for the readiness check",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(readinessIntervalFlagName, completion.AutocompleteNone)
+
+		readinessRetriesFlagName := "health-readiness-retries"
+		createFlags.UintVar(
+			&cf.ReadinessRetries,
+			readinessRetriesFlagName, define.DefaultHealthCheckRetries,
+			"Set the number of consecutive failures before a ready container is marked as not ready",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(readinessRetriesFlagName, completion.AutocompleteNone)
+
+		readinessSuccessesFlagName := "health-readiness-success"
+		createFlags.UintVar(
+			&cf.ReadinessSuccesses,
+			readinessSuccessesFlagName, 0,
+			"Set the number of consecutive successes before the container is marked as ready (0 indicates any success marks it as ready)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(readinessSuccessesFlagName, completion.AutocompleteNone)
+
+		readinessTimeoutFlagName := "health-readiness-timeout"
+		createFlags.StringVar(
+			&cf.ReadinessTimeout,
+			readinessTimeoutFlagName, define.DefaultHealthCheckTimeout,
+			"Set the maximum amount of time that the readiness check may take before it is considered failed",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(readinessTimeoutFlagName, completion.AutocompleteNone)
+
 		restartFlagName := "restart"
 		createFlags.StringVar(
 			&cf.Restart,
@@ -409,7 +465,7 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		createFlags.StringVar(
 			&cf.SdNotifyMode,
 			sdnotifyFlagName, cf.SdNotifyMode,
-			`control sd-notify behavior ("container"|"conmon"|"ignore")`,
+			`control sd-notify behavior ("container"|"conmon"|"ignore"|"ready")`,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(sdnotifyFlagName, AutocompleteSDNotify)
 
@@ -898,6 +954,30 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"Tune container pids limit (set -1 for unlimited)",
 		)
 		_ = cmd.RegisterFlagCompletionFunc(pidsLimitFlagName, completion.AutocompleteNone)
+
+		networkRateLimitFlagName := "network-rate-limit"
+		createFlags.StringVar(
+			&cf.NetworkRateLimit,
+			networkRateLimitFlagName, "",
+			"Limit the network bandwidth of the container (e.g. ingress=10mbit,egress=5mbit)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(networkRateLimitFlagName, completion.AutocompleteNone)
+
+		networkDelayFlagName := "network-delay"
+		createFlags.StringVar(
+			&cf.NetworkDelay,
+			networkDelayFlagName, "",
+			"Delay packets sent by the container (e.g. 100ms)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(networkDelayFlagName, completion.AutocompleteNone)
+
+		networkLossFlagName := "network-loss"
+		createFlags.StringVar(
+			&cf.NetworkLoss,
+			networkLossFlagName, "",
+			"Drop a percentage of the packets sent by the container (e.g. 1%)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(networkLossFlagName, completion.AutocompleteNone)
 	}
 	// anyone can use these
 	cpusFlagName := "cpus"
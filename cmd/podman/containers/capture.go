@@ -0,0 +1,86 @@
+package containers
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/parse"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	captureDescription = `Capture the network traffic of a running container in pcap format.
+
+  The packets are captured on one interface inside the network namespace of the container, so no tools are needed in the container image.`
+
+	captureCommand = &cobra.Command{
+		Use:               "capture [options] CONTAINER",
+		Short:             "Capture the network traffic of a container",
+		Long:              captureDescription,
+		RunE:              captureRun,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman container capture -w ctr.pcap ctrID
+  podman container capture -i eth0 --filter "tcp port 80" ctrID | tcpdump -r -
+  podman container capture -c 10 --filter "udp and port 53" -w dns.pcap ctrID`,
+	}
+)
+
+var (
+	captureOpts       entities.ContainerCaptureOptions
+	captureOutputFile string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: captureCommand,
+		Parent:  containerCmd,
+	})
+	flags := captureCommand.Flags()
+
+	interfaceFlagName := "interface"
+	flags.StringVarP(&captureOpts.Interface, interfaceFlagName, "i", "", "Network interface in the container to capture on (default: first interface which is up and not a loopback interface)")
+	_ = captureCommand.RegisterFlagCompletionFunc(interfaceFlagName, completion.AutocompleteNone)
+
+	writeFlagName := "write"
+	flags.StringVarP(&captureOutputFile, writeFlagName, "w", "", "Write the packets to a file (default: stdout, which must be redirected)")
+	_ = captureCommand.RegisterFlagCompletionFunc(writeFlagName, completion.AutocompleteDefault)
+
+	filterFlagName := "filter"
+	flags.StringVar(&captureOpts.Filter, filterFlagName, "", "Only capture packets matching the filter expression")
+	_ = captureCommand.RegisterFlagCompletionFunc(filterFlagName, completion.AutocompleteNone)
+
+	countFlagName := "count"
+	flags.IntVarP(&captureOpts.Count, countFlagName, "c", 0, "Stop after capturing the given number of packets")
+	_ = captureCommand.RegisterFlagCompletionFunc(countFlagName, completion.AutocompleteNone)
+}
+
+func captureRun(cmd *cobra.Command, args []string) error {
+	if captureOpts.Count < 0 {
+		return errors.New("--count must not be negative")
+	}
+	if captureOutputFile == "" || captureOutputFile == "-" {
+		file := os.Stdout
+		if term.IsTerminal(int(file.Fd())) {
+			return errors.New("refusing to write packets to terminal. Use -w flag or redirect")
+		}
+		captureOpts.Output = file
+	} else {
+		if err := parse.ValidateFileName(captureOutputFile); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(captureOutputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		captureOpts.Output = file
+	}
+	return registry.ContainerEngine().ContainerCapture(registry.GetContext(), strings.TrimPrefix(args[0], "/"), captureOpts)
+}
@@ -26,6 +26,9 @@ var (
 			return validate.CheckAllLatestAndIDFile(cmd, args, true, "")
 		},
 		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Annotations: map[string]string{
+			registry.RunnableParent: "",
+		},
 		Example: `podman port --all
   podman port ctrID 80/tcp
   podman port --latest 80`,
@@ -40,6 +43,7 @@ var (
 			return validate.CheckAllLatestAndIDFile(cmd, args, true, "")
 		},
 		ValidArgsFunction: portCommand.ValidArgsFunction,
+		Annotations:       portCommand.Annotations,
 		Example: `podman container port --all
   podman container port --latest 80`,
 	}
@@ -154,3 +158,13 @@ func port(_ *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// portSubcommandArgs requires the CONTAINER and PORT arguments of a port
+// subcommand. A container named like the subcommand is never looked up, the
+// error points to the leading slash that selects it instead.
+func portSubcommandArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.MinimumNArgs(2)(cmd, args); err != nil {
+		return fmt.Errorf("%w, use \"%s /%s\" to list the ports of a container named %q", err, cmd.Parent().CommandPath(), cmd.Name(), cmd.Name())
+	}
+	return nil
+}
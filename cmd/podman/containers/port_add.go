@@ -0,0 +1,75 @@
+package containers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portAddDescription = `Publish additional ports of a container.
+
+  If the container is running, its port forwarding is updated right away. Ports are given in the --publish format.
+`
+	portAddCommand = &cobra.Command{
+		Use:               "add CONTAINER PORT [PORT...]",
+		Short:             "Publish additional ports of a container",
+		Long:              portAddDescription,
+		RunE:              portAdd,
+		Args:              portSubcommandArgs,
+		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Example: `podman port add ctrID 8080:80
+  podman port add ctrID 127.0.0.1:5353:53/udp 9000-9010`,
+	}
+
+	containerPortAddCommand = &cobra.Command{
+		Use:               portAddCommand.Use,
+		Short:             portAddCommand.Short,
+		Long:              portAddCommand.Long,
+		RunE:              portAddCommand.RunE,
+		Args:              portAddCommand.Args,
+		ValidArgsFunction: portAddCommand.ValidArgsFunction,
+		Example:           `podman container port add ctrID 8080:80`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: portAddCommand,
+		Parent:  portCommand,
+	})
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerPortAddCommand,
+		Parent:  containerPortCommand,
+	})
+}
+
+func portAdd(_ *cobra.Command, args []string) error {
+	container := strings.TrimPrefix(args[0], "/")
+	report, err := registry.ContainerEngine().ContainerPortAdd(registry.GetContext(), container, entities.ContainerPortAddOptions{Ports: args[1:]})
+	if err != nil {
+		return err
+	}
+	for _, v := range report.Ports {
+		hostIP := v.HostIP
+		// Set host IP to 0.0.0.0 if blank
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		portRange := v.Range
+		if portRange == 0 {
+			portRange = 1
+		}
+		for _, protocol := range strings.Split(v.Protocol, ",") {
+			for i := uint16(0); i < portRange; i++ {
+				fmt.Printf("%d/%s -> %s:%d\n", v.ContainerPort+i, protocol, hostIP, v.HostPort+i)
+			}
+		}
+	}
+	return nil
+}
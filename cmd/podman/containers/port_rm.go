@@ -0,0 +1,56 @@
+package containers
+
+import (
+	"strings"
+
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portRmDescription = `Unpublish ports of a container.
+
+  If the container is running, its port forwarding is updated right away. A port without host port is unpublished from all host ports it is published on.
+`
+	portRmCommand = &cobra.Command{
+		Use:               "rm CONTAINER PORT [PORT...]",
+		Aliases:           []string{"remove"},
+		Short:             "Unpublish ports of a container",
+		Long:              portRmDescription,
+		RunE:              portRm,
+		Args:              portSubcommandArgs,
+		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Example: `podman port rm ctrID 80
+  podman port rm ctrID 8080:80 53/udp`,
+	}
+
+	containerPortRmCommand = &cobra.Command{
+		Use:               portRmCommand.Use,
+		Aliases:           portRmCommand.Aliases,
+		Short:             portRmCommand.Short,
+		Long:              portRmCommand.Long,
+		RunE:              portRmCommand.RunE,
+		Args:              portRmCommand.Args,
+		ValidArgsFunction: portRmCommand.ValidArgsFunction,
+		Example:           `podman container port rm ctrID 80`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: portRmCommand,
+		Parent:  portCommand,
+	})
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerPortRmCommand,
+		Parent:  containerPortCommand,
+	})
+}
+
+func portRm(_ *cobra.Command, args []string) error {
+	container := strings.TrimPrefix(args[0], "/")
+	return registry.ContainerEngine().ContainerPortRm(registry.GetContext(), container, entities.ContainerPortRmOptions{Ports: args[1:]})
+}
@@ -31,6 +31,7 @@ var (
 		Args:              validate.NoArgs,
 	}
 	force  bool
+	dryRun bool
 	filter = []string{}
 )
 
@@ -41,6 +42,7 @@ func init() {
 	})
 	flags := pruneCommand.Flags()
 	flags.BoolVarP(&force, "force", "f", false, "Do not prompt for confirmation.  The default is false")
+	flags.BoolVar(&dryRun, "dry-run", false, "Only report the containers that would be removed")
 	filterFlagName := "filter"
 	flags.StringArrayVar(&filter, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
 	_ = pruneCommand.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePruneFilters)
@@ -48,10 +50,10 @@ func init() {
 
 func prune(cmd *cobra.Command, _ []string) error {
 	var (
-		pruneOptions = entities.ContainerPruneOptions{}
+		pruneOptions = entities.ContainerPruneOptions{DryRun: dryRun}
 		err          error
 	)
-	if !force {
+	if !force && !dryRun {
 		reader := bufio.NewReader(os.Stdin)
 		fmt.Println("WARNING! This will remove all non running containers.")
 		fmt.Print("Are you sure you want to continue? [y/N] ")
@@ -77,8 +77,11 @@ func update(cmd *cobra.Command, args []string) error {
 	}
 
 	opts := &entities.ContainerUpdateOptions{
-		NameOrID: strings.TrimPrefix(args[0], "/"),
-		Specgen:  s,
+		NameOrID:         strings.TrimPrefix(args[0], "/"),
+		Specgen:          s,
+		NetworkRateLimit: updateOpts.NetworkRateLimit,
+		NetworkDelay:     updateOpts.NetworkDelay,
+		NetworkLoss:      updateOpts.NetworkLoss,
 	}
 	rep, err := registry.ContainerEngine().ContainerUpdate(context.Background(), opts)
 	if err != nil {
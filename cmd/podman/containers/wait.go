@@ -96,11 +96,12 @@ func wait(cmd *cobra.Command, args []string) error {
 	}
 
 	for _, condition := range waitConditions {
-		cond, err := define.StringToContainerStatus(condition)
-		if err != nil {
-			return err
+		if condition != define.ReadinessCheckReady {
+			if _, err := define.StringToContainerStatus(condition); err != nil {
+				return err
+			}
 		}
-		waitOptions.Condition = append(waitOptions.Condition, cond)
+		waitOptions.Condition = append(waitOptions.Condition, condition)
 	}
 
 	responses, err := registry.ContainerEngine().ContainerWait(context.Background(), args, waitOptions)
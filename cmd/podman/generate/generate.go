@@ -11,8 +11,8 @@ var (
 	// Command: podman _generate_
 	GenerateCmd = &cobra.Command{
 		Use:   "generate",
-		Short: "Generate structured data based on containers, pods or volumes",
-		Long:  "Generate structured data (e.g., Kubernetes YAML or systemd units) based on containers, pods or volumes.",
+		Short: "Generate structured data based on containers, pods, volumes or networks",
+		Long:  "Generate structured data (e.g., Kubernetes YAML, systemd or quadlet units) based on containers, pods, volumes or networks.",
 		RunE:  validate.SubCommandExists,
 	}
 	containerConfig = util.DefaultContainerConfig()
@@ -0,0 +1,94 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	quadletFiles       bool
+	quadletFormat      string
+	quadletOptions     = entities.GenerateQuadletOptions{}
+	quadletDescription = `Generate quadlet unit files for a container, pod, volume or network.
+  The generated files can be placed in a quadlet unit search path such as
+  /etc/containers/systemd or ~/.config/containers/systemd.`
+
+	quadletCmd = &cobra.Command{
+		Use:               "quadlet [options] {CONTAINER|POD|VOLUME|NETWORK}",
+		Short:             "Generate quadlet unit files.",
+		Long:              quadletDescription,
+		RunE:              quadlet,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteForQuadlet,
+		Example: `podman generate quadlet CTR
+  podman generate quadlet --files POD
+  podman generate quadlet VOLUME`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: quadletCmd,
+		Parent:  GenerateCmd,
+	})
+	flags := quadletCmd.Flags()
+	flags.BoolVarP(&quadletFiles, "files", "f", false, "Write the files into the current directory instead of printing to stdout")
+	flags.BoolVarP(&quadletOptions.NoHeader, "no-header", "", false, "Skip header generation")
+
+	formatFlagName := "format"
+	flags.StringVar(&quadletFormat, formatFlagName, "", "Print the created files in specified format (json)")
+	_ = quadletCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
+}
+
+func quadlet(cmd *cobra.Command, args []string) error {
+	reports, err := registry.ContainerEngine().GenerateQuadlet(registry.GetContext(), args[0], quadletOptions)
+	if err != nil {
+		return err
+	}
+
+	if quadletFiles {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("getting current working directory: %w", err)
+		}
+		for name, content := range reports.Units {
+			path := filepath.Join(cwd, name)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return err
+			}
+			// add newline if default format is given
+			if quadletFormat == "" {
+				path += "\n"
+			}
+			// modify in place so we can print the
+			// paths when --files is set
+			reports.Units[name] = path
+		}
+	}
+
+	switch {
+	case report.IsJSON(quadletFormat):
+		return printJSON(reports.Units)
+	case quadletFormat == "":
+		// Print in a stable order.
+		names := make([]string, 0, len(reports.Units))
+		for name := range reports.Units {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Print(reports.Units[name])
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown --format argument: %s", quadletFormat)
+	}
+}
@@ -21,6 +21,8 @@ var (
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: common.AutocompleteContainersRunning,
 	}
+
+	runOptions = entities.HealthCheckOptions{}
 )
 
 func init() {
@@ -28,14 +30,18 @@ func init() {
 		Command: runCmd,
 		Parent:  healthCmd,
 	})
+	if !registry.IsRemote() {
+		flags := runCmd.Flags()
+		flags.BoolVar(&runOptions.Readiness, "readiness", false, "Run the readiness check instead of the healthcheck")
+	}
 }
 
 func run(cmd *cobra.Command, args []string) error {
-	response, err := registry.ContainerEngine().HealthCheckRun(context.Background(), args[0], entities.HealthCheckOptions{})
+	response, err := registry.ContainerEngine().HealthCheckRun(context.Background(), args[0], runOptions)
 	if err != nil {
 		return err
 	}
-	if response.Status == define.HealthCheckUnhealthy || response.Status == define.HealthCheckStarting {
+	if response.Status == define.HealthCheckUnhealthy || response.Status == define.HealthCheckStarting || response.Status == define.ReadinessCheckNotReady {
 		registry.SetExitCode(1)
 		fmt.Println(response.Status)
 	}
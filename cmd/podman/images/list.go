@@ -376,6 +376,13 @@ func (i imageReporter) CreatedTime() string {
 	return i.CreatedAt()
 }
 
+func (i imageReporter) LastUsed() string {
+	if i.ImageSummary.LastUsed == 0 {
+		return "Never"
+	}
+	return units.HumanDuration(time.Since(time.Unix(i.ImageSummary.LastUsed, 0))) + " ago"
+}
+
 func (i imageReporter) size() int64 {
 	return i.ImageSummary.Size
 }
@@ -2,6 +2,7 @@ package images
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/containers/podman/v4/cmd/podman/utils"
 	"github.com/containers/podman/v4/cmd/podman/validate"
 	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 )
 
@@ -28,9 +30,10 @@ var (
 		Example:           `podman image prune`,
 	}
 
-	pruneOpts = entities.ImagePruneOptions{}
-	force     bool
-	filter    = []string{}
+	pruneOpts   = entities.ImagePruneOptions{}
+	force       bool
+	filter      = []string{}
+	keepStorage string
 )
 
 func init() {
@@ -48,14 +51,33 @@ func init() {
 	flags.BoolVarP(&pruneOpts.All, "all", "a", false, "Remove all images not in use by containers, not just dangling ones")
 	flags.BoolVarP(&pruneOpts.External, "external", "", false, "Remove images even when they are used by external containers (e.g., by build containers)")
 	flags.BoolVarP(&force, "force", "f", false, "Do not prompt for confirmation")
+	flags.BoolVar(&pruneOpts.DryRun, "dry-run", false, "Only report the images that would be removed")
 
 	filterFlagName := "filter"
 	flags.StringArrayVar(&filter, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
 	_ = pruneCmd.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePruneFilters)
+
+	keepStorageFlagName := "keep-storage"
+	flags.StringVar(&keepStorage, keepStorageFlagName, "", "Remove least recently used unused images until the images use at most this much storage (e.g. 20G)")
+	_ = pruneCmd.RegisterFlagCompletionFunc(keepStorageFlagName, completion.AutocompleteNone)
+
+	untilUsedFlagName := "until-used"
+	flags.StringVar(&pruneOpts.UntilUsed, untilUsedFlagName, "", "Remove only unused images not used to create a container since this timestamp or duration (e.g. 30d)")
+	_ = pruneCmd.RegisterFlagCompletionFunc(untilUsedFlagName, completion.AutocompleteNone)
 }
 
 func prune(cmd *cobra.Command, args []string) error {
-	if !force {
+	if keepStorage != "" {
+		size, err := units.RAMInBytes(keepStorage)
+		if err != nil {
+			return fmt.Errorf("invalid --keep-storage %q: %w", keepStorage, err)
+		}
+		if size <= 0 {
+			return errors.New("--keep-storage must be greater than 0")
+		}
+		pruneOpts.KeepStorage = size
+	}
+	if !force && !pruneOpts.DryRun {
 		reader := bufio.NewReader(os.Stdin)
 		fmt.Printf("%s", createPruneWarningMessage(pruneOpts))
 		answer, err := reader.ReadString('\n')
@@ -76,15 +98,18 @@ func prune(cmd *cobra.Command, args []string) error {
 		}
 	}
 	results, err := registry.ImageEngine().Prune(registry.GetContext(), pruneOpts)
-	if err != nil {
-		return err
+	// Images removed before an error occurred are still reported.
+	if printErr := utils.PrintImagePruneResults(results, false); printErr != nil {
+		return printErr
 	}
-
-	return utils.PrintImagePruneResults(results, false)
+	return err
 }
 
 func createPruneWarningMessage(pruneOpts entities.ImagePruneOptions) string {
 	question := "Are you sure you want to continue? [y/N] "
+	if pruneOpts.KeepStorage > 0 || pruneOpts.UntilUsed != "" {
+		return "WARNING! This command removes the least recently used images without at least one container associated with them.\n" + question
+	}
 	if pruneOpts.All {
 		return "WARNING! This command removes all images without at least one container associated with them.\n" + question
 	}
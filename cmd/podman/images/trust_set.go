@@ -6,17 +6,16 @@ import (
 	"regexp"
 
 	"github.com/containers/common/pkg/completion"
-	"github.com/containers/common/pkg/util"
 	"github.com/containers/podman/v4/cmd/podman/common"
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/trust"
 	"github.com/spf13/cobra"
 )
 
 var (
 	setTrustDescription = "Set default trust policy or add a new trust policy for a registry"
 	setTrustCommand     = &cobra.Command{
-		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
 		Use:               "set [options] REGISTRY",
 		Short:             "Set default trust policy or a new trust policy for a registry",
 		Long:              setTrustDescription,
@@ -53,14 +52,12 @@ File(s) must exist before using this command`)
 }
 
 func setTrust(cmd *cobra.Command, args []string) error {
-	validTrustTypes := []string{"accept", "insecureAcceptAnything", "reject", "signedBy", "sigstoreSigned"}
-
 	valid, err := isValidImageURI(args[0])
 	if err != nil || !valid {
 		return err
 	}
 
-	if !util.StringInSlice(setOptions.Type, validTrustTypes) {
+	if !trust.IsValidType(setOptions.Type) {
 		return fmt.Errorf("invalid choice: %s (choose from 'accept', 'reject', 'signedBy', 'sigstoreSigned')", setOptions.Type)
 	}
 	return registry.ImageEngine().SetTrust(registry.Context(), args, setOptions)
@@ -9,6 +9,9 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/containers/common/pkg/auth"
 	"github.com/containers/common/pkg/completion"
@@ -18,9 +21,13 @@ import (
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	"github.com/containers/podman/v4/cmd/podman/utils"
 	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/shutdown"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/errorhandling"
+	kustomizepkg "github.com/containers/podman/v4/pkg/kustomize"
 	"github.com/containers/podman/v4/pkg/util"
+	"github.com/coreos/go-systemd/v22/daemon"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -33,6 +40,8 @@ type playKubeOptionsWrapper struct {
 	CredentialsCLI string
 	StartCLI       bool
 	BuildCLI       bool
+	Wait           bool
+	Kustomize      bool
 	annotations    []string
 	macs           []string
 }
@@ -46,7 +55,7 @@ var (
   Creates pods or volumes based on the Kubernetes kind described in the YAML. Supported kinds are Pods, Deployments and PersistentVolumeClaims.`
 
 	playCmd = &cobra.Command{
-		Use:               "play [options] KUBEFILE|-",
+		Use:               "play [options] KUBEFILE|-|KUSTOMIZATION_DIR",
 		Short:             "Play a pod or volume based on Kubernetes YAML.",
 		Long:              playDescription,
 		RunE:              play,
@@ -55,7 +64,8 @@ var (
 		Example: `podman kube play nginx.yml
   cat nginx.yml | podman kube play -
   podman kube play --creds user:password --seccomp-profile-root /custom/path apache.yml
-  podman kube play https://example.com/nginx.yml`,
+  podman kube play https://example.com/nginx.yml
+  podman kube play -k overlays/production`,
 	}
 )
 
@@ -138,7 +148,9 @@ func playFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&playOptions.Quiet, "quiet", "q", false, "Suppress output information when pulling images")
 	flags.BoolVar(&playOptions.TLSVerifyCLI, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
 	flags.BoolVar(&playOptions.StartCLI, "start", true, "Start the pod after creating it")
-	flags.BoolVar(&playOptions.Force, "force", false, "Remove volumes as part of --down")
+	flags.BoolVar(&playOptions.Force, "force", false, "Remove volumes as part of --down or --wait")
+	flags.BoolVarP(&playOptions.Wait, "wait", "w", false, "Follow the logs of the pods and tear them down once they exit or a SIGINT or SIGTERM is received")
+	flags.BoolVarP(&playOptions.Kustomize, "kustomize", "k", false, "Render the kustomization in the given directory and play the result")
 
 	authfileFlagName := "authfile"
 	flags.StringVar(&playOptions.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
@@ -247,8 +259,19 @@ func play(cmd *cobra.Command, args []string) error {
 		playOptions.StaticMACs = append(playOptions.StaticMACs, m)
 	}
 
-	if playOptions.Force && !playOptions.Down {
-		return errors.New("--force may be specified only with --down")
+	if playOptions.Force && !playOptions.Down && !playOptions.Wait {
+		return errors.New("--force may be specified only with --down or --wait")
+	}
+
+	if playOptions.Wait {
+		switch {
+		case playOptions.Down:
+			return errors.New("--wait and --down cannot be used together")
+		case !playOptions.StartCLI:
+			return errors.New("--wait does not work with --start=false")
+		case playOptions.ServiceContainer:
+			return errors.New("--wait and --service-container cannot be used together")
+		}
 	}
 
 	// When running under Systemd use passthrough as the default log-driver.
@@ -260,7 +283,7 @@ func play(cmd *cobra.Command, args []string) error {
 		playOptions.LogDriver = define.PassthroughLogging
 	}
 
-	reader, err := readerFromArg(args[0])
+	reader, err := kubeReader(args[0], playOptions.Kustomize)
 	if err != nil {
 		return err
 	}
@@ -278,7 +301,11 @@ func play(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if err := kubeplay(reader); err != nil {
+	if playOptions.Wait {
+		return kubeplayWait(reader)
+	}
+
+	if _, err := kubeplay(reader); err != nil {
 		// FIXME: The cleanup logic below must be fixed to only remove
 		// resources that were created before a failure.  Otherwise,
 		// rerunning the same YAML file will cause an error and remove
@@ -302,6 +329,23 @@ func playKube(cmd *cobra.Command, args []string) error {
 	return play(cmd, args)
 }
 
+// kubeReader returns a reader for the Kubernetes YAML of the argument of kube
+// play or kube down. With kustomize set, the argument is a kustomization
+// directory which is rendered first.
+func kubeReader(arg string, kustomize bool) (*bytes.Reader, error) {
+	if !kustomize {
+		if info, err := os.Stat(arg); err == nil && info.IsDir() {
+			return nil, fmt.Errorf("%s is a directory, use --kustomize to render the kustomization in it", arg)
+		}
+		return readerFromArg(arg)
+	}
+	data, err := kustomizepkg.Build(arg)
+	if err != nil {
+		return nil, fmt.Errorf("rendering kustomization %s: %w", arg, err)
+	}
+	return bytes.NewReader(data), nil
+}
+
 func readerFromArg(fileName string) (*bytes.Reader, error) {
 	errURL := parse.ValidURL(fileName)
 	if fileName == "-" { // Read from stdin
@@ -403,10 +447,10 @@ func teardown(body io.Reader, options entities.PlayKubeDownOptions, quiet bool)
 	return volRmErrors.PrintErrors()
 }
 
-func kubeplay(body io.Reader) error {
+func kubeplay(body io.Reader) (*entities.PlayKubeReport, error) {
 	report, err := registry.ContainerEngine().PlayKube(registry.GetContext(), body, playOptions.PlayKubeOptions)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Print volumes report
 	for i, volume := range report.Volumes {
@@ -453,8 +497,112 @@ func kubeplay(body io.Reader) error {
 	}
 
 	if ctrsFailed > 0 {
-		return fmt.Errorf("failed to start %d containers", ctrsFailed)
+		return report, fmt.Errorf("failed to start %d containers", ctrsFailed)
+	}
+
+	return report, nil
+}
+
+// kubeplayWait plays the YAML and stays attached to the pods: the logs of
+// all containers are followed and the pods are torn down the same way as
+// with `kube down` once all containers exited or when a SIGINT or SIGTERM is
+// received.  The exit code is the highest exit code of the containers.
+func kubeplayWait(body *bytes.Reader) error {
+	var (
+		teardownOnce sync.Once
+		teardownErr  error
+	)
+	down := func() error {
+		teardownOnce.Do(func() {
+			if _, err := body.Seek(0, 0); err != nil {
+				teardownErr = err
+				return
+			}
+			teardownErr = teardown(body, entities.PlayKubeDownOptions{Force: playOptions.Force}, playOptions.Quiet)
+		})
+		return teardownErr
+	}
+
+	// Register the handler before playing the YAML, so that pods which
+	// are created before a signal arrives are torn down as well.
+	// Handlers run in reverse order of registration, the teardown hence
+	// runs before libpod exits.
+	if err := shutdown.Start(); err != nil {
+		return fmt.Errorf("starting shutdown signal handler: %w", err)
+	}
+	if err := shutdown.Register("kube-play-wait", func(sig os.Signal) error {
+		if err := down(); err != nil {
+			logrus.Errorf("Tearing down pods: %v", err)
+		}
+		// For `systemctl stop` support, exit code should be 0
+		if sig == syscall.SIGTERM {
+			os.Exit(0)
+		}
+		os.Exit(1)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("registering shutdown handler: %w", err)
 	}
 
+	report, err := kubeplay(body)
+	if err != nil {
+		if report != nil {
+			if err := down(); err != nil {
+				logrus.Errorf("Tearing down pods: %v", err)
+			}
+		}
+		return err
+	}
+
+	// We are the main process of a systemd service, tell systemd that
+	// the pods are running.
+	if _, err := daemon.SdNotify(false, fmt.Sprintf("MAINPID=%d\n%s", os.Getpid(), daemon.SdNotifyReady)); err != nil {
+		logrus.Errorf("Sending READY message: %v", err)
+	}
+
+	var ctrs []string
+	for _, pod := range report.Pods {
+		ctrs = append(ctrs, pod.Containers...)
+	}
+	if len(ctrs) > 0 {
+		logOptions := entities.ContainerLogsOptions{
+			Follow:       true,
+			Names:        true,
+			StdoutWriter: os.Stdout,
+			StderrWriter: os.Stderr,
+		}
+		go func() {
+			if err := registry.ContainerEngine().ContainerLogs(registry.GetContext(), ctrs, logOptions); err != nil {
+				logrus.Errorf("Following logs: %v", err)
+			}
+		}()
+	}
+
+	waitOptions := entities.WaitOptions{
+		Condition: []string{define.ContainerStateStopped.String(), define.ContainerStateExited.String()},
+		Interval:  250 * time.Millisecond,
+	}
+	reports, err := registry.ContainerEngine().ContainerWait(registry.GetContext(), ctrs, waitOptions)
+	if err != nil {
+		if downErr := down(); downErr != nil {
+			logrus.Errorf("Tearing down pods: %v", downErr)
+		}
+		return err
+	}
+	exitCode := 0
+	for _, r := range reports {
+		if r.Error != nil {
+			logrus.Errorf("Waiting for container: %v", r.Error)
+			continue
+		}
+		if int(r.ExitCode) > exitCode {
+			exitCode = int(r.ExitCode)
+		}
+	}
+
+	if err := down(); err != nil {
+		return err
+	}
+	registry.SetExitCode(exitCode)
 	return nil
 }
@@ -37,6 +37,7 @@ var (
 
 func networkPruneFlags(cmd *cobra.Command, flags *pflag.FlagSet) {
 	flags.BoolVarP(&force, "force", "f", false, "do not prompt for confirmation")
+	flags.BoolVar(&networkPruneOptions.DryRun, "dry-run", false, "only report the networks that would be removed")
 	filterFlagName := "filter"
 	flags.StringArrayVar(&filter, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
 	_ = cmd.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePruneFilters)
@@ -53,7 +54,7 @@ func init() {
 
 func networkPrune(cmd *cobra.Command, _ []string) error {
 	var err error
-	if !force {
+	if !force && !networkPruneOptions.DryRun {
 		reader := bufio.NewReader(os.Stdin)
 		fmt.Println("WARNING! This will remove all networks not used by at least one container.")
 		fmt.Print("Are you sure you want to continue? [y/N] ")
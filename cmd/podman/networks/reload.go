@@ -15,11 +15,10 @@ import (
 var (
 	networkReloadDescription = `reload container networks, recreating firewall rules`
 	networkReloadCommand     = &cobra.Command{
-		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
-		Use:         "reload [options] [CONTAINER...]",
-		Short:       "Reload firewall rules for one or more containers",
-		Long:        networkReloadDescription,
-		RunE:        networkReload,
+		Use:   "reload [options] [CONTAINER...]",
+		Short: "Reload firewall rules for one or more containers",
+		Long:  networkReloadDescription,
+		RunE:  networkReload,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validate.CheckAllLatestAndIDFile(cmd, args, false, "")
 		},
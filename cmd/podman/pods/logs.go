@@ -9,7 +9,6 @@ import (
 	"github.com/containers/podman/v4/cmd/podman/common"
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	"github.com/containers/podman/v4/cmd/podman/validate"
-	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/util"
 	"github.com/spf13/cobra"
@@ -114,11 +113,6 @@ func logs(_ *cobra.Command, args []string) error {
 		logsPodOptions.Until = until
 	}
 
-	// Remote can only process one container at a time
-	if registry.IsRemote() && logsPodOptions.ContainerName == "" {
-		return fmt.Errorf("-c or --container cannot be empty: %w", define.ErrInvalidArg)
-	}
-
 	logsPodOptions.StdoutWriter = os.Stdout
 	logsPodOptions.StderrWriter = os.Stderr
 
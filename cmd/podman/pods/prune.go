@@ -40,10 +40,11 @@ func init() {
 	})
 	flags := pruneCommand.Flags()
 	flags.BoolVarP(&pruneOptions.Force, "force", "f", false, "Do not prompt for confirmation.  The default is false")
+	flags.BoolVar(&pruneOptions.DryRun, "dry-run", false, "Only report the pods that would be removed")
 }
 
 func prune(cmd *cobra.Command, args []string) error {
-	if !pruneOptions.Force {
+	if !pruneOptions.Force && !pruneOptions.DryRun {
 		reader := bufio.NewReader(os.Stdin)
 		fmt.Println("WARNING! This will remove all stopped/exited pods..")
 		fmt.Print("Are you sure you want to continue? [y/N] ")
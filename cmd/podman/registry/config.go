@@ -25,6 +25,9 @@ const (
 
 	// EngineMode used as cobra.Annotation when command supports a limited number of Engines
 	EngineMode = "EngineMode"
+
+	// RunnableParent used as cobra.Annotation when a command with subcommands also runs on its own and needs the engine
+	RunnableParent = "RunnableParent"
 )
 
 var (
@@ -139,7 +139,8 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 
 	// Help, completion and commands with subcommands are special cases, no need for more setup
 	// Completion cmd is used to generate the shell scripts
-	if cmd.Name() == "help" || cmd.Name() == "completion" || cmd.HasSubCommands() {
+	_, runnableParent := cmd.Annotations[registry.RunnableParent]
+	if cmd.Name() == "help" || cmd.Name() == "completion" || (cmd.HasSubCommands() && !runnableParent) {
 		requireCleanup = false
 		return nil
 	}
@@ -49,6 +49,7 @@ func init() {
 	flags.BoolVarP(&pruneOptions.All, "all", "a", false, "Remove all unused data")
 	flags.BoolVar(&pruneOptions.External, "external", false, "Remove container data in storage not controlled by podman")
 	flags.BoolVar(&pruneOptions.Volume, "volumes", false, "Prune volumes")
+	flags.BoolVar(&pruneOptions.DryRun, "dry-run", false, "Only report the data that would be removed")
 	filterFlagName := "filter"
 	flags.StringArrayVar(&filters, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
 	_ = pruneCommand.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePruneFilters)
@@ -56,8 +57,8 @@ func init() {
 
 func prune(cmd *cobra.Command, args []string) error {
 	var err error
-	// Prompt for confirmation if --force is not set, unless --external
-	if !force && !pruneOptions.External {
+	// Prompt for confirmation if --force is not set, unless --external or --dry-run
+	if !force && !pruneOptions.External && !pruneOptions.DryRun {
 		reader := bufio.NewReader(os.Stdin)
 		volumeString := ""
 		if pruneOptions.Volume {
@@ -86,40 +87,58 @@ func prune(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	// In a dry run the results are printed below our own headings
+	heading := !pruneOptions.DryRun
 	// Print container prune results
-	err = utils.PrintContainerPruneResults(response.ContainerPruneReports, true)
+	printDryRunHeading("Containers", len(response.ContainerPruneReports))
+	err = utils.PrintContainerPruneResults(response.ContainerPruneReports, heading)
 	if err != nil {
 		return err
 	}
 	// Print pod prune results
-	err = utils.PrintPodPruneResults(response.PodPruneReport, true)
+	printDryRunHeading("Pods", len(response.PodPruneReport))
+	err = utils.PrintPodPruneResults(response.PodPruneReport, heading)
 	if err != nil {
 		return err
 	}
 	// Print Volume prune results
 	if pruneOptions.Volume {
-		err = utils.PrintVolumePruneResults(response.VolumePruneReports, true)
+		printDryRunHeading("Volumes", len(response.VolumePruneReports))
+		err = utils.PrintVolumePruneResults(response.VolumePruneReports, heading)
 		if err != nil {
 			return err
 		}
 	}
 	// Print Images prune results
-	err = utils.PrintImagePruneResults(response.ImagePruneReports, true)
+	printDryRunHeading("Images", len(response.ImagePruneReports))
+	err = utils.PrintImagePruneResults(response.ImagePruneReports, heading)
 	if err != nil {
 		return err
 	}
 	// Print Network prune results
-	err = utils.PrintNetworkPruneResults(response.NetworkPruneReports, true)
+	printDryRunHeading("Networks", len(response.NetworkPruneReports))
+	err = utils.PrintNetworkPruneResults(response.NetworkPruneReports, heading)
 	if err != nil {
 		return err
 	}
 
-	if !pruneOptions.External {
+	switch {
+	case pruneOptions.External:
+	case pruneOptions.DryRun:
+		fmt.Printf("Total reclaimable space: %s\n", units.HumanSize((float64)(response.ReclaimedSpace)))
+	default:
 		fmt.Printf("Total reclaimed space: %s\n", units.HumanSize((float64)(response.ReclaimedSpace)))
 	}
 	return nil
 }
 
+// printDryRunHeading prints the heading of the results of a dry run
+func printDryRunHeading(kind string, count int) {
+	if pruneOptions.DryRun && count > 0 {
+		fmt.Printf("Would Delete %s\n", kind)
+	}
+}
+
 func createPruneWarningMessage(pruneOpts entities.SystemPruneOptions) string {
 	if pruneOpts.All {
 		return `WARNING! This command removes:
@@ -0,0 +1,143 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/utils"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rootlessNetNSDescription = `Manage the network namespace shared by rootless containers using bridge networks.
+
+  The namespace is created when the first rootless container with a bridge network is started. Its slirp4netns process connects it to the network of the host.`
+
+	rootlessNetNSCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "rootless-netns",
+		Short:       "Manage the rootless network namespace",
+		Long:        rootlessNetNSDescription,
+		RunE:        validate.SubCommandExists,
+	}
+
+	rootlessNetNSInspectCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "inspect [options]",
+		Args:              validate.NoArgs,
+		Short:             "Display the rootless network namespace",
+		Long:              "Display the interfaces, routes, slirp4netns process and attached containers of the rootless network namespace.",
+		RunE:              rootlessNetNSInspect,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system rootless-netns inspect
+  podman system rootless-netns inspect --format "{{.Helper.PID}}"`,
+	}
+
+	rootlessNetNSExecCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "exec COMMAND [ARG...]",
+		Short:             "Run a command in the rootless network namespace",
+		Long:              "Run a command in the rootless network namespace, with the mounts the network backends see. $SHELL is run if no command is given.",
+		RunE:              rootlessNetNSExec,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman system rootless-netns exec ip addr
+  podman system rootless-netns exec iptables -nvL`,
+	}
+
+	rootlessNetNSCleanupCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "cleanup",
+		Args:              validate.NoArgs,
+		Short:             "Remove the rootless network namespace if it is unused",
+		Long:              "Remove the rootless network namespace and stop its slirp4netns process. This fails if containers are still attached to it.",
+		RunE:              rootlessNetNSCleanup,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           `podman system rootless-netns cleanup`,
+	}
+)
+
+var rootlessNetNSInspectFormat string
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rootlessNetNSCmd,
+		Parent:  systemCmd,
+	})
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rootlessNetNSInspectCmd,
+		Parent:  rootlessNetNSCmd,
+	})
+	flags := rootlessNetNSInspectCmd.Flags()
+	formatFlagName := "format"
+	flags.StringVarP(&rootlessNetNSInspectFormat, formatFlagName, "f", "json", "Format the output to a Go template or json")
+	_ = rootlessNetNSInspectCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&define.RootlessNetNSInfo{}))
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rootlessNetNSExecCmd,
+		Parent:  rootlessNetNSCmd,
+	})
+	rootlessNetNSExecCmd.Flags().SetInterspersed(false)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rootlessNetNSCleanupCmd,
+		Parent:  rootlessNetNSCmd,
+	})
+}
+
+func rootlessNetNSInspect(cmd *cobra.Command, args []string) error {
+	if !rootless.IsRootless() {
+		return errors.New("the rootless network namespace is only used by rootless podman")
+	}
+	info, err := registry.ContainerEngine().SystemRootlessNetNSInspect(registry.GetContext())
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(rootlessNetNSInspectFormat) {
+		b, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	rpt, err = rpt.Parse(report.OriginUnknown, rootlessNetNSInspectFormat)
+	if err != nil {
+		return err
+	}
+	return rpt.Execute(info)
+}
+
+func rootlessNetNSExec(cmd *cobra.Command, args []string) error {
+	if !rootless.IsRootless() {
+		return errors.New("the rootless network namespace is only used by rootless podman")
+	}
+	if len(args) < 1 {
+		shell, shellSet := os.LookupEnv("SHELL")
+		if !shellSet {
+			return errors.New("no command specified and no $SHELL specified")
+		}
+		args = []string{shell}
+	}
+	err := registry.ContainerEngine().SystemRootlessNetNSExec(registry.GetContext(), args)
+	return utils.HandleOSExecError(err)
+}
+
+func rootlessNetNSCleanup(cmd *cobra.Command, args []string) error {
+	if !rootless.IsRootless() {
+		return errors.New("the rootless network namespace is only used by rootless podman")
+	}
+	return registry.ContainerEngine().SystemRootlessNetNSCleanup(registry.GetContext())
+}
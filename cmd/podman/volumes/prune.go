@@ -44,6 +44,7 @@ func init() {
 	flags.StringArrayVar(&filter, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
 	_ = pruneCommand.RegisterFlagCompletionFunc(filterFlagName, common.AutocompleteVolumeFilters)
 	flags.BoolP("force", "f", false, "Do not prompt for confirmation")
+	flags.Bool("dry-run", false, "Only report the volumes that would be removed")
 }
 
 func prune(cmd *cobra.Command, args []string) error {
@@ -57,11 +58,15 @@ func prune(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	pruneOptions.DryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
 	pruneOptions.Filters, err = parse.FilterArgumentsIntoFilters(filter)
 	if err != nil {
 		return err
 	}
-	if !force {
+	if !force && !pruneOptions.DryRun {
 		reader := bufio.NewReader(os.Stdin)
 		fmt.Println("WARNING! This will remove all volumes not used by at least one container. The following volumes will be removed:")
 		if err != nil {
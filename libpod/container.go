@@ -117,6 +117,11 @@ type Container struct {
 	rootlessPortSyncR *os.File
 	rootlessPortSyncW *os.File
 
+	// volumeSubPaths holds the subpaths of volumes opened to generate the
+	// spec of the container, they are closed once the container was
+	// created in the OCI runtime.
+	volumeSubPaths []*os.File
+
 	// perNetworkOpts should be set when you want to use special network
 	// options when calling network setup/teardown. This should be used for
 	// container restore or network reload for example. Leave this nil if
@@ -215,6 +220,15 @@ type ContainerState struct {
 	// healthcheck. The container will be restarted if this exceed a set
 	// number in the startup HC config.
 	StartupHCFailureCount int `json:"startupHCFailureCount,omitempty"`
+	// Ready indicates that the readiness check of the container has
+	// passed and the container is ready to serve requests.
+	Ready bool `json:"ready,omitempty"`
+	// ReadinessCheckSuccessCount is the number of consecutive successes of
+	// the readiness check.
+	ReadinessCheckSuccessCount int `json:"readinessCheckSuccessCount,omitempty"`
+	// ReadinessCheckFailureCount is the number of consecutive failures of
+	// the readiness check.
+	ReadinessCheckFailureCount int `json:"readinessCheckFailureCount,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -543,6 +557,16 @@ func (c *Container) PortMappings() ([]types.PortMapping, error) {
 	return c.config.PortMappings, nil
 }
 
+// NetworkShaping returns the traffic control applied to the network interfaces
+// of the container, nil if none is configured.
+func (c *Container) NetworkShaping() *define.NetworkShaping {
+	if c.config.NetworkShaping == nil {
+		return nil
+	}
+	shaping := *c.config.NetworkShaping
+	return &shaping
+}
+
 // DNSServers returns DNS servers that will be used in the container's
 // resolv.conf
 // If empty, DNS server from the host's resolv.conf will be used instead
@@ -959,6 +983,37 @@ func (c *Container) StartupHCPassed() (bool, error) {
 	return c.state.StartupHCPassed, nil
 }
 
+// Ready returns whether the container is ready to serve requests. Running
+// containers are ready once their startup healthcheck and readiness check
+// passed, if they have them.
+func (c *Container) Ready() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	return c.isReady(), nil
+}
+
+// isReady returns whether the container is ready to serve requests.
+// Must be called with the container locked.
+func (c *Container) isReady() bool {
+	if c.state.State != define.ContainerStateRunning {
+		return false
+	}
+	if c.config.HealthCheckConfig != nil && c.config.StartupHealthCheckConfig != nil && !c.state.StartupHCPassed {
+		return false
+	}
+	if c.config.ReadinessCheckConfig != nil {
+		return c.state.Ready
+	}
+	return true
+}
+
 // Misc Accessors
 // Most will require locking
 
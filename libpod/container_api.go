@@ -668,7 +668,20 @@ type waitResult struct {
 	err  error
 }
 
+// WaitForConditionWithInterval blocks until the container is in one of the
+// given states.
 func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeout time.Duration, conditions ...define.ContainerStatus) (int32, error) {
+	names := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		names = append(names, condition.String())
+	}
+	return c.WaitForConditionNamesWithInterval(ctx, waitTimeout, names...)
+}
+
+// WaitForConditionNamesWithInterval blocks until the container meets one of
+// the given conditions. A condition is either the name of a container state
+// or define.ReadinessCheckReady to wait until the container is ready.
+func (c *Container) WaitForConditionNamesWithInterval(ctx context.Context, waitTimeout time.Duration, conditions ...string) (int32, error) {
 	if !c.valid {
 		return -1, define.ErrCtrRemoved
 	}
@@ -682,14 +695,23 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 
 	resultChan := make(chan waitResult)
 	waitForExit := false
+	waitForReady := false
 	wantedStates := make(map[define.ContainerStatus]bool, len(conditions))
 
 	for _, condition := range conditions {
-		switch condition {
+		if condition == define.ReadinessCheckReady {
+			waitForReady = true
+			continue
+		}
+		state, err := define.StringToContainerStatus(condition)
+		if err != nil {
+			return -1, err
+		}
+		switch state {
 		case define.ContainerStateExited, define.ContainerStateStopped:
 			waitForExit = true
 		default:
-			wantedStates[condition] = true
+			wantedStates[state] = true
 		}
 	}
 
@@ -712,20 +734,33 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 		}()
 	}
 
-	if len(wantedStates) > 0 {
+	if len(wantedStates) > 0 || waitForReady {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
 			for {
-				state, err := c.State()
-				if err != nil {
-					trySend(-1, err)
-					return
+				if len(wantedStates) > 0 {
+					state, err := c.State()
+					if err != nil {
+						trySend(-1, err)
+						return
+					}
+					if _, found := wantedStates[state]; found {
+						trySend(-1, nil)
+						return
+					}
 				}
-				if _, found := wantedStates[state]; found {
-					trySend(-1, nil)
-					return
+				if waitForReady {
+					ready, err := c.Ready()
+					if err != nil {
+						trySend(-1, err)
+						return
+					}
+					if ready {
+						trySend(-1, nil)
+						return
+					}
 				}
 				select {
 				case <-ctx.Done():
@@ -969,6 +1004,21 @@ type ContainerCheckpointOptions struct {
 	FileLocks bool
 }
 
+// ContainerCaptureOptions is a struct used to pass the parameters for
+// capturing the network traffic of a container.
+type ContainerCaptureOptions struct {
+	// Interface is the name of the network interface in the container
+	// to capture on. If empty, the first interface which is up and not a
+	// loopback interface is used.
+	Interface string
+	// Filter selects the packets to capture, see capture.ParseFilter for
+	// the supported syntax. All packets are captured if it is empty.
+	Filter string
+	// Count stops the capture after the given number of packets. If it
+	// is 0, the capture runs until it is cancelled.
+	Count int
+}
+
 // Checkpoint checkpoints a container
 // The return values *define.CRIUCheckpointRestoreStatistics and int64 (time
 // the runtime needs to checkpoint the container) are only set if
@@ -305,6 +305,10 @@ type ContainerNetworkConfig struct {
 	NetMode namespaces.NetworkMode `json:"networkMode,omitempty"`
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// NetworkShaping is the traffic control applied to the network
+	// interfaces of the container.
+	// This cannot be set unless CreateNetNS is set.
+	NetworkShaping *define.NetworkShaping `json:"networkShaping,omitempty"`
 }
 
 // ContainerImageConfig is an embedded sub-config providing image configuration
@@ -401,6 +405,17 @@ type ContainerMiscConfig struct {
 	// healthcheck for the container. This will run before the regular HC
 	// runs, and when it passes the regular HC will be activated.
 	StartupHealthCheckConfig *define.StartupHealthCheck `json:"startupHealthCheck,omitempty"`
+	// ReadinessCheckConfig is the configuration of the readiness check of
+	// the container. It is run independently of the healthcheck and
+	// decides whether the container is ready to serve requests.
+	ReadinessCheckConfig *define.ReadinessCheck `json:"readinessCheck,omitempty"`
+	// PostStartHook is a command executed in the container after it has
+	// been started. If the command fails, the container is stopped again.
+	PostStartHook []string `json:"postStartHook,omitempty"`
+	// PreStopHook is a command executed in the container before it is
+	// sent the stop signal. Failures are logged but do not prevent the
+	// container from being stopped.
+	PreStopHook []string `json:"preStopHook,omitempty"`
 	// PreserveFDs is a number of additional file descriptors (in addition
 	// to 0, 1, 2) that will be passed to the executed process. The total FDs
 	// passed will be 3 + PreserveFDs.
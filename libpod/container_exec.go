@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/containers/common/pkg/resize"
@@ -155,6 +157,12 @@ type legacyExecSession struct {
 // ExecCreate creates a new exec session for the container.
 // The session is not started. The ID of the new exec session will be returned.
 func (c *Container) ExecCreate(config *ExecConfig) (string, error) {
+	return c.execCreate(config, false)
+}
+
+// execCreate creates a new exec session for the container.  No exec_create
+// event is written for health check sessions.
+func (c *Container) execCreate(config *ExecConfig, isHealthcheck bool) (string, error) {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -224,6 +232,10 @@ func (c *Container) ExecCreate(config *ExecConfig) (string, error) {
 		return "", err
 	}
 
+	if !isHealthcheck {
+		c.newExecEvent(events.ExecCreate, session)
+	}
+
 	logrus.Infof("Created exec session %s in container %s", session.ID(), c.ID())
 
 	return sessionID, nil
@@ -268,7 +280,7 @@ func (c *Container) ExecStart(sessionID string) error {
 		return err
 	}
 
-	c.newContainerEvent(events.Exec)
+	c.newExecEvent(events.Exec, session)
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
 
 	// Update and save session to reflect PID/running
@@ -320,10 +332,10 @@ func (c *Container) execStartAndAttach(sessionID string, streams *define.AttachS
 		return err
 	}
 
-	if isHealthcheck {
-		c.newContainerEvent(events.HealthStatus)
-	} else {
-		c.newContainerEvent(events.Exec)
+	// Health check sessions report their result through the health_status
+	// event once the check has completed.
+	if !isHealthcheck {
+		c.newExecEvent(events.Exec, session)
 	}
 
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
@@ -496,7 +508,7 @@ func (c *Container) ExecHTTPStartAndAttach(sessionID string, r *http.Request, w
 	// TODO: Investigate whether more of this can be made common with
 	// ExecStartAndAttach
 
-	c.newContainerEvent(events.Exec)
+	c.newExecEvent(events.Exec, session)
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
 
 	var lastErr error
@@ -761,7 +773,7 @@ func (c *Container) Exec(config *ExecConfig, streams *define.AttachStreams, resi
 // run, and remove an exec session. Returns exit code and error. Exit code is
 // not guaranteed to be set sanely if error is not nil.
 func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resizeChan <-chan resize.TerminalSize, isHealthcheck bool) (int, error) {
-	sessionID, err := c.ExecCreate(config)
+	sessionID, err := c.execCreate(config, isHealthcheck)
 	if err != nil {
 		return -1, err
 	}
@@ -825,6 +837,93 @@ func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resi
 	return exitCode, nil
 }
 
+// runLifecycleHook executes a post-start or pre-stop hook in the container and
+// waits at most timeout for it to exit. The container is killed if the hook
+// does not exit in time. The output of the hook is included in the error if it
+// fails.
+// Must be called with the container locked. Unless the container is batched,
+// the lock is released while the hook runs.
+func (c *Container) runLifecycleHook(name string, command []string, timeout time.Duration) error {
+	logrus.Debugf("Running %s hook %v of container %s with timeout %s", name, command, c.ID(), timeout)
+
+	rPipe, wPipe, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("unable to create pipe for %s hook: %w", name, err)
+	}
+	defer rPipe.Close()
+
+	output := new(strings.Builder)
+	outputDone := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(output, rPipe)
+		close(outputDone)
+	}()
+
+	streams := new(define.AttachStreams)
+	streams.OutputStream = wPipe
+	streams.ErrorStream = wPipe
+	streams.AttachOutput = true
+	streams.AttachError = true
+
+	config := new(ExecConfig)
+	config.Command = command
+	config.AttachStdout = true
+	config.AttachStderr = true
+
+	// The exec API locks the container for each step of the exec session
+	// and releases the lock while it runs, so other commands are not
+	// blocked by a long running hook.
+	if !c.batched {
+		c.lock.Unlock()
+		defer func() {
+			c.lock.Lock()
+			if err := c.syncContainer(); err != nil {
+				logrus.Errorf("Syncing container %s state: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	type execResult struct {
+		exitCode int
+		err      error
+	}
+	resultChan := make(chan execResult, 1)
+	go func() {
+		exitCode, err := c.exec(config, streams, nil, false)
+		wPipe.Close()
+		resultChan <- execResult{exitCode: exitCode, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var result execResult
+	select {
+	case result = <-resultChan:
+	case <-timer.C:
+		logrus.Warnf("%s hook of container %s did not complete within %s, killing the container", name, c.ID(), timeout)
+		all, err := c.signalAllProcesses()
+		if err != nil {
+			return err
+		}
+		if err := c.ociRuntime.KillContainer(c, uint(unix.SIGKILL), all); err != nil {
+			return fmt.Errorf("killing container %s after %s hook timeout: %w", c.ID(), name, err)
+		}
+		// The exec session ends together with the container.
+		<-resultChan
+		return fmt.Errorf("%s hook of container %s did not complete within %s: %w", name, c.ID(), timeout, context.DeadlineExceeded)
+	}
+	<-outputDone
+
+	if result.err != nil {
+		return fmt.Errorf("running %s hook of container %s: %w", name, c.ID(), result.err)
+	}
+	if result.exitCode != 0 {
+		return fmt.Errorf("%s hook of container %s exited with code %d: %s", name, c.ID(), result.exitCode, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
 // cleanupExecBundle cleanups an exec session after its done
 // Please be careful when using this function since it might temporarily unlock
 // the container when os.RemoveAll($bundlePath) fails with ENOTEMPTY or EBUSY
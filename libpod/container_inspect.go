@@ -132,6 +132,7 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 			Error:          runtimeInfo.Error,
 			StartedAt:      runtimeInfo.StartedTime,
 			FinishedAt:     runtimeInfo.FinishedTime,
+			Ready:          c.isReady(),
 			Checkpointed:   runtimeInfo.Checkpointed,
 			CgroupPath:     cgroupPath,
 			RestoredAt:     runtimeInfo.RestoredTime,
@@ -227,8 +228,8 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 }
 
 // Get inspect-formatted mounts list.
-// Only includes user-specified mounts. Only includes bind mounts and named
-// volumes, not tmpfs volumes.
+// Only includes user-specified mounts. Only includes bind mounts, named,
+// image and overlay volumes, not tmpfs volumes.
 func (c *Container) GetMounts(namedVolumes []*ContainerNamedVolume, imageVolumes []*ContainerImageVolume, mounts []spec.Mount) ([]define.InspectMount, error) {
 	inspectMounts := []define.InspectMount{}
 
@@ -272,6 +273,23 @@ func (c *Container) GetMounts(namedVolumes []*ContainerNamedVolume, imageVolumes
 		inspectMounts = append(inspectMounts, mountStruct)
 	}
 
+	for _, volume := range c.config.OverlayVolumes {
+		mountStruct := define.InspectMount{}
+		mountStruct.Type = define.TypeOverlay
+		mountStruct.Destination = volume.Dest
+		mountStruct.Source = volume.Source
+
+		opts := make([]string, 0, len(volume.Options))
+		for _, o := range volume.Options {
+			if o != "O" {
+				opts = append(opts, o)
+			}
+		}
+		parseMountOptionsForInspect(opts, &mountStruct)
+
+		inspectMounts = append(inspectMounts, mountStruct)
+	}
+
 	for _, mount := range mounts {
 		// It's a mount.
 		// Is it a tmpfs? If so, discard.
@@ -398,6 +416,11 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 
 	ctrConfig.HealthcheckOnFailureAction = c.config.HealthCheckOnFailureAction.String()
 
+	ctrConfig.Readinesscheck = c.config.ReadinessCheckConfig
+
+	ctrConfig.PostStartHook = c.config.PostStartHook
+	ctrConfig.PreStopHook = c.config.PreStopHook
+
 	ctrConfig.CreateCommand = c.config.CreateCommand
 
 	ctrConfig.Timezone = c.config.Timezone
@@ -555,6 +578,7 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	// Only populate if we are creating the network namespace to configure the network.
 	if c.config.CreateNetNS {
 		hostConfig.PortBindings = makeInspectPortBindings(c.config.PortMappings)
+		hostConfig.NetworkShaping = c.config.NetworkShaping
 	} else {
 		hostConfig.PortBindings = make(map[string][]define.InspectHostPort)
 	}
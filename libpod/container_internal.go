@@ -218,6 +218,7 @@ func (c *Container) handleExitFile(exitFile string, fi os.FileInfo) error {
 	oomFilePath := filepath.Join(c.bundlePath(), "oom")
 	if _, err = os.Stat(oomFilePath); err == nil {
 		c.state.OOMKilled = true
+		c.newContainerEvent(events.OOM)
 	}
 
 	c.state.Exited = true
@@ -626,6 +627,9 @@ func resetState(state *ContainerState) {
 	state.StartupHCPassed = false
 	state.StartupHCSuccessCount = 0
 	state.StartupHCFailureCount = 0
+	state.Ready = false
+	state.ReadinessCheckSuccessCount = 0
+	state.ReadinessCheckFailureCount = 0
 	state.NetNS = ""
 	state.NetworkStatus = nil
 	state.NetworkStatusOld = nil
@@ -1009,6 +1013,17 @@ func (c *Container) completeNetworkSetup() error {
 	return resolvconf.Add(resolvBindMount, nameservers)
 }
 
+// closeVolumeSubPaths closes the subpaths of volumes opened by
+// openVolumeSubPath.
+func (c *Container) closeVolumeSubPaths() {
+	for _, f := range c.volumeSubPaths {
+		if err := f.Close(); err != nil {
+			logrus.Debugf("Unable to close subpath %s of container %s: %v", f.Name(), c.ID(), err)
+		}
+	}
+	c.volumeSubPaths = nil
+}
+
 // Initialize a container, creating it in the runtime
 func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	// Unconditionally remove conmon temporary files.
@@ -1017,6 +1032,9 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		return err
 	}
 
+	// The spec may mount files opened while generating it.
+	defer c.closeVolumeSubPaths()
+
 	// Generate the OCI newSpec
 	newSpec, err := c.generateSpec(ctx)
 	if err != nil {
@@ -1076,6 +1094,9 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	c.state.StartupHCFailureCount = 0
 	c.state.StartupHCSuccessCount = 0
 	c.state.StartupHCPassed = false
+	c.state.Ready = false
+	c.state.ReadinessCheckSuccessCount = 0
+	c.state.ReadinessCheckFailureCount = 0
 
 	if !retainRetries {
 		c.state.RestartCount = 0
@@ -1103,6 +1124,11 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 			logrus.Error(err)
 		}
 	}
+	if c.config.ReadinessCheckConfig != nil {
+		if err := c.createReadinessTimer(); err != nil {
+			logrus.Error(err)
+		}
+	}
 
 	defer c.newContainerEvent(events.Init)
 	return c.completeNetworkSetup()
@@ -1231,6 +1257,9 @@ func (c *Container) start() error {
 	logrus.Debugf("Started container %s", c.ID())
 
 	c.state.State = define.ContainerStateRunning
+	c.state.Ready = false
+	c.state.ReadinessCheckSuccessCount = 0
+	c.state.ReadinessCheckFailureCount = 0
 
 	if c.config.SdNotifyMode != define.SdNotifyModeIgnore {
 		payload := fmt.Sprintf("MAINPID=%d", c.state.ConmonPID)
@@ -1256,37 +1285,70 @@ func (c *Container) start() error {
 			logrus.Error(err)
 		}
 	}
+	if c.config.ReadinessCheckConfig != nil {
+		if err := c.startReadinessTimer(); err != nil {
+			logrus.Error(err)
+		}
+	}
 
 	defer c.newContainerEvent(events.Start)
 
-	return c.save()
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	if len(c.config.PostStartHook) > 0 {
+		// The post-start hook is bounded by the stop timeout, the
+		// container is killed if the hook does not exit in time.
+		hookTimeout := time.Duration(c.StopTimeout()) * time.Second
+		if err := c.runLifecycleHook("post-start", c.config.PostStartHook, hookTimeout); err != nil {
+			// Like Kubernetes, do not leave the container running
+			// if its post-start hook failed.
+			stopTimeout := c.StopTimeout()
+			if errors.Is(err, context.DeadlineExceeded) {
+				stopTimeout = 0
+			}
+			if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+				if stopErr := c.stop(stopTimeout); stopErr != nil {
+					logrus.Errorf("Stopping container %s after failed post-start hook: %v", c.ID(), stopErr)
+				}
+			}
+			return err
+		}
+	}
+
+	return c.notifyWhenReady()
 }
 
 // Internal, non-locking function to stop container
 func (c *Container) stop(timeout uint) error {
 	logrus.Debugf("Stopping ctr %s (timeout %d)", c.ID(), timeout)
 
-	// If the container is running in a PID Namespace, then killing the
-	// primary pid is enough to kill the container.  If it is not running in
-	// a pid namespace then the OCI Runtime needs to kill ALL processes in
-	// the container's cgroup in order to make sure the container is stopped.
-	all := !c.hasNamespace(spec.PIDNamespace)
-	// We can't use --all if Cgroups aren't present.
-	// Rootless containers with Cgroups v1 and NoCgroups are both cases
-	// where this can happen.
-	if all {
-		if c.config.NoCgroups {
-			all = false
-		} else if rootless.IsRootless() {
-			// Only do this check if we need to
-			unified, err := cgroups.IsCgroup2UnifiedMode()
-			if err != nil {
-				return err
-			}
-			if !unified {
-				all = false
-			}
+	// The pre-stop hook requires a running container to exec into. It
+	// counts against the stop timeout, so it is skipped when the container
+	// is to be killed right away.
+	if len(c.config.PreStopHook) > 0 && timeout > 0 && c.state.State == define.ContainerStateRunning {
+		hookStart := time.Now()
+		if err := c.runLifecycleHook("pre-stop", c.config.PreStopHook, time.Duration(timeout)*time.Second); err != nil {
+			logrus.Error(err)
+		}
+		remaining := time.Duration(timeout)*time.Second - time.Since(hookStart)
+		if remaining > 0 {
+			timeout = uint((remaining + time.Second - 1) / time.Second)
+		} else {
+			timeout = 0
 		}
+
+		// The container was unlocked while the hook ran.
+		if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+			logrus.Debugf("Container %q state changed to %q while running its pre-stop hook: discontinuing stop procedure", c.ID(), c.state.State)
+			return nil
+		}
+	}
+
+	all, err := c.signalAllProcesses()
+	if err != nil {
+		return err
 	}
 
 	// Set the container state to "stopping" and unlock the container
@@ -1345,6 +1407,34 @@ func (c *Container) stop(timeout uint) error {
 	return c.waitForConmonToExitAndSave()
 }
 
+// signalAllProcesses returns whether the OCI runtime must signal all processes
+// in the cgroup of the container to stop it.
+func (c *Container) signalAllProcesses() (bool, error) {
+	// If the container is running in a PID Namespace, then killing the
+	// primary pid is enough to kill the container.  If it is not running in
+	// a pid namespace then the OCI Runtime needs to kill ALL processes in
+	// the container's cgroup in order to make sure the container is stopped.
+	all := !c.hasNamespace(spec.PIDNamespace)
+	// We can't use --all if Cgroups aren't present.
+	// Rootless containers with Cgroups v1 and NoCgroups are both cases
+	// where this can happen.
+	if all {
+		if c.config.NoCgroups {
+			all = false
+		} else if rootless.IsRootless() {
+			// Only do this check if we need to
+			unified, err := cgroups.IsCgroup2UnifiedMode()
+			if err != nil {
+				return false, err
+			}
+			if !unified {
+				all = false
+			}
+		}
+	}
+	return all, nil
+}
+
 func (c *Container) waitForConmonToExitAndSave() error {
 	conmonAlive, err := c.ociRuntime.CheckConmonRunning(c)
 	if err != nil {
@@ -1434,6 +1524,11 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 				logrus.Error(err.Error())
 			}
 		}
+		if c.config.ReadinessCheckConfig != nil {
+			if err := c.removeReadinessTimer(context.Background()); err != nil {
+				logrus.Error(err.Error())
+			}
+		}
 		// Old versions of conmon have a bug where they create the exit file before
 		// closing open file descriptors causing a race condition when restarting
 		// containers with open ports since we cannot bind the ports as they're not
@@ -1694,7 +1789,9 @@ func (c *Container) mountNamedVolume(v *ContainerNamedVolume, mountpoint string)
 		return nil, err
 	}
 	_, hasNoCopy := vol.config.Options["nocopy"]
-	if vol.state.NeedsCopyUp && !cutil.StringInSlice("nocopy", v.Options) && !hasNoCopy {
+	// The contents of the container directory are not copied into a
+	// subpath, only the whole volume is populated on first use.
+	if vol.state.NeedsCopyUp && !cutil.StringInSlice("nocopy", v.Options) && !hasNoCopy && v.SubPath == "" {
 		logrus.Debugf("Copying up contents from container %s to volume %s", c.ID(), vol.Name())
 
 		srcDir, err := securejoin.SecureJoin(mountpoint, v.Dest)
@@ -1901,6 +1998,11 @@ func (c *Container) cleanup(ctx context.Context) error {
 			logrus.Errorf("Removing timer for container %s healthcheck: %v", c.ID(), err)
 		}
 	}
+	if c.config.ReadinessCheckConfig != nil {
+		if err := c.removeReadinessTimer(ctx); err != nil {
+			logrus.Errorf("Removing timer for container %s readiness check: %v", c.ID(), err)
+		}
+	}
 
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
@@ -2318,12 +2420,23 @@ func (c *Container) SortUserVolumes(ctrSpec *spec.Spec) ([]*ContainerNamedVolume
 	for _, mount := range ctrSpec.Mounts {
 		mounts[mount.Destination] = mount
 	}
+	// Overlay and image volumes are only added to the spec when the
+	// container is started, they are not user mounts.
+	otherVolumes := make(map[string]bool)
+	for _, overlayVol := range c.config.OverlayVolumes {
+		otherVolumes[overlayVol.Dest] = true
+	}
+	for _, imageVol := range c.config.ImageVolumes {
+		otherVolumes[imageVol.Dest] = true
+	}
 
 	for _, vol := range c.config.UserVolumes {
 		if volume, ok := namedVolumes[vol]; ok {
 			namedUserVolumes = append(namedUserVolumes, volume)
 		} else if mount, ok := mounts[vol]; ok {
 			userMounts = append(userMounts, mount)
+		} else if otherVolumes[vol] {
+			continue
 		} else {
 			logrus.Warnf("Could not find mount at destination %q when parsing user volumes for container %s", vol, c.ID())
 		}
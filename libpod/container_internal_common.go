@@ -164,6 +164,37 @@ func getOverlayUpperAndWorkDir(options []string) (string, string, error) {
 	return upperDir, workDir, nil
 }
 
+// secureJoinVolumeSubPath returns the path of subPath in the volume mounted at
+// mountPoint. Symlinks are resolved as if mountPoint was the root directory,
+// so a symlink in the volume cannot point outside of it. Missing directories
+// are created with the owner of the volume, like Kubernetes does for subPath
+// mounts. The path may be changed by a container using the volume once it is
+// returned, volumeSubPath should be used where supported.
+func secureJoinVolumeSubPath(mountPoint, subPath string) (string, error) {
+	path, err := securejoin.SecureJoin(mountPoint, subPath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Lstat(path); err == nil || !os.IsNotExist(err) {
+		return path, err
+	}
+
+	st, err := os.Stat(mountPoint)
+	if err != nil {
+		return "", err
+	}
+	owner := idtools.IDPair{}
+	if stat, ok := st.Sys().(*syscall.Stat_t); ok {
+		owner.UID, owner.GID = int(stat.Uid), int(stat.Gid)
+	}
+	if err := idtools.MkdirAllAndChown(path, 0755, owner); err != nil {
+		return "", err
+	}
+	// resolve the path again, a component may have been replaced in the
+	// meantime by a container using the volume
+	return securejoin.SecureJoin(mountPoint, subPath)
+}
+
 // Generate spec for a container
 // Accepts a map of the container's dependencies
 func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
@@ -223,7 +254,10 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		return nil, err
 	}
 
-	// Add named volumes
+	// Add named volumes. The subpaths of volumes are mounted by the source
+	// returned by openVolumeSubPath once their paths were relabeled and
+	// chowned below.
+	subPathSources := make(map[string]string)
 	for _, namedVol := range c.config.NamedVolumes {
 		volume, err := c.runtime.GetVolume(namedVol.Name)
 		if err != nil {
@@ -234,8 +268,12 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 			return nil, err
 		}
 
+		mountSource := mountPoint
 		if len(namedVol.SubPath) > 0 {
-			mountPoint = filepath.Join(mountPoint, namedVol.SubPath)
+			mountPoint, mountSource, err = c.openVolumeSubPath(mountPoint, namedVol.SubPath)
+			if err != nil {
+				return nil, fmt.Errorf("resolving subpath %q of volume %s: %w", namedVol.SubPath, namedVol.Name, err)
+			}
 		}
 
 		overlayFlag := false
@@ -266,7 +304,7 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 				GraphOpts:              c.runtime.store.GraphOptions(),
 			}
 
-			overlayMount, err = overlay.MountWithOptions(contentDir, mountPoint, namedVol.Dest, overlayOpts)
+			overlayMount, err = overlay.MountWithOptions(contentDir, mountSource, namedVol.Dest, overlayOpts)
 			if err != nil {
 				return nil, fmt.Errorf("mounting overlay failed %q: %w", mountPoint, err)
 			}
@@ -291,6 +329,9 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 				Options:     namedVol.Options,
 			}
 			g.AddMount(volMount)
+			if mountSource != mountPoint {
+				subPathSources[namedVol.Dest] = mountSource
+			}
 		}
 	}
 
@@ -332,6 +373,9 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 			}
 		}
 		m.Options = options
+		if source, ok := subPathSources[m.Destination]; ok {
+			m.Source = source
+		}
 	}
 
 	c.setProcessLabel(&g)
@@ -364,6 +408,16 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		if err != nil {
 			return nil, err
 		}
+		if upperDir != "" {
+			// The upper and work directories keep the writes to the
+			// overlay across restarts, create them on first use.
+			if err := os.MkdirAll(upperDir, 0755); err != nil {
+				return nil, fmt.Errorf("creating overlay upperdir %q: %w", upperDir, err)
+			}
+			if err := os.MkdirAll(workDir, 0700); err != nil {
+				return nil, fmt.Errorf("creating overlay workdir %q: %w", workDir, err)
+			}
+		}
 		contentDir, err := overlay.TempDir(c.config.StaticDir, c.RootUID(), c.RootGID())
 		if err != nil {
 			return nil, err
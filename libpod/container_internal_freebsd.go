@@ -316,3 +316,13 @@ func (c *Container) jailName() string {
 		return c.ID()
 	}
 }
+
+// openVolumeSubPath returns the path of subPath in the volume mounted at
+// mountPoint as path and as source to mount it by.
+func (c *Container) openVolumeSubPath(mountPoint, subPath string) (string, string, error) {
+	path, err := secureJoinVolumeSubPath(mountPoint, subPath)
+	if err != nil {
+		return "", "", err
+	}
+	return path, path, nil
+}
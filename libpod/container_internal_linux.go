@@ -679,3 +679,120 @@ func (c *Container) getConmonPidFd() int {
 	}
 	return -1
 }
+
+// openVolumeSubPath opens subPath in the volume mounted at mountPoint. It
+// returns the current path of the subpath and the source to mount it by,
+// the file descriptor of the opened subpath in /proc. Mounting the file
+// descriptor mounts exactly the opened file, even if a container using the
+// volume replaces a component of the path in the meantime. The OCI runtime
+// resolves the source in its own process, so it refers to the file descriptor
+// of this process rather than /proc/self. The subpath is kept open until the
+// container was created in the OCI runtime.
+func (c *Container) openVolumeSubPath(mountPoint, subPath string) (string, string, error) {
+	f, err := volumeSubPath(mountPoint, subPath)
+	if err != nil {
+		return "", "", err
+	}
+	c.volumeSubPaths = append(c.volumeSubPaths, f)
+	source := fmt.Sprintf("/proc/%d/fd/%d", os.Getpid(), f.Fd())
+	path, err := os.Readlink(source)
+	if err != nil {
+		return "", "", err
+	}
+	return path, source, nil
+}
+
+// volumeSubPath opens subPath in the volume mounted at mountPoint with
+// O_PATH. Symlinks are resolved as if mountPoint was the root directory, so a
+// symlink in the volume cannot point outside of it. Missing directories are
+// created with the owner of the volume, like Kubernetes does for subPath
+// mounts. The subpath is walked one component at a time, each one opened or
+// created relative to its opened parent without following symlinks, so
+// concurrent changes to the volume cannot move the subpath out of it.
+func volumeSubPath(mountPoint, subPath string) (*os.File, error) {
+	root, err := unix.Open(mountPoint, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: mountPoint, Err: err}
+	}
+	var st unix.Stat_t
+	if err := unix.Fstat(root, &st); err != nil {
+		unix.Close(root)
+		return nil, err
+	}
+
+	// dirs holds the opened components of the subpath, starting with the
+	// root of the volume
+	dirs := []int{root}
+	defer func() {
+		for _, fd := range dirs {
+			unix.Close(fd)
+		}
+	}()
+	remaining := strings.Split(subPath, "/")
+	for links := 0; len(remaining) > 0; {
+		name := remaining[0]
+		remaining = remaining[1:]
+		switch name {
+		case "", ".":
+			continue
+		case "..":
+			// never above the root of the volume
+			if len(dirs) > 1 {
+				unix.Close(dirs[len(dirs)-1])
+				dirs = dirs[:len(dirs)-1]
+			}
+			continue
+		}
+
+		parent := dirs[len(dirs)-1]
+		fd, err := unix.Openat(parent, name, unix.O_PATH|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		if errors.Is(err, unix.ENOENT) {
+			if err := unix.Mkdirat(parent, name, 0o755); err == nil {
+				if err := unix.Fchownat(parent, name, int(st.Uid), int(st.Gid), unix.AT_SYMLINK_NOFOLLOW); err != nil {
+					return nil, err
+				}
+			} else if !errors.Is(err, unix.EEXIST) {
+				return nil, err
+			}
+			fd, err = unix.Openat(parent, name, unix.O_PATH|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var fdSt unix.Stat_t
+		if err := unix.Fstat(fd, &fdSt); err != nil {
+			unix.Close(fd)
+			return nil, err
+		}
+		if fdSt.Mode&unix.S_IFMT != unix.S_IFLNK {
+			dirs = append(dirs, fd)
+			continue
+		}
+
+		// resolve the symlink within the volume
+		links++
+		if links > 255 {
+			unix.Close(fd)
+			return nil, &os.PathError{Op: "open", Path: subPath, Err: unix.ELOOP}
+		}
+		buf := make([]byte, unix.PathMax)
+		n, err := unix.Readlinkat(fd, "", buf)
+		unix.Close(fd)
+		if err != nil {
+			return nil, err
+		}
+		target := string(buf[:n])
+		if filepath.IsAbs(target) {
+			for _, fd := range dirs[1:] {
+				unix.Close(fd)
+			}
+			dirs = dirs[:1]
+		}
+		remaining = append(strings.Split(target, "/"), remaining...)
+	}
+
+	fd := dirs[len(dirs)-1]
+	dirs = dirs[:len(dirs)-1]
+	return os.NewFile(uintptr(fd), filepath.Join(mountPoint, subPath)), nil
+}
@@ -4,6 +4,9 @@
 package libpod
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -61,3 +64,93 @@ func TestGenerateUserGroupEntry(t *testing.T) {
 	}
 	assert.Equal(t, group, "567890:x:567890:567890\n")
 }
+
+func TestSecureJoinVolumeSubPath(t *testing.T) {
+	mountPoint := t.TempDir()
+	outside := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(mountPoint, "config", "app"), 0755))
+	assert.NoError(t, os.Symlink("config/app", filepath.Join(mountPoint, "relative")))
+	assert.NoError(t, os.Symlink(outside, filepath.Join(mountPoint, "escape")))
+	assert.NoError(t, os.Symlink("../../..", filepath.Join(mountPoint, "config", "up")))
+
+	path, err := secureJoinVolumeSubPath(mountPoint, "config/app")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(mountPoint, "config", "app"), path)
+
+	path, err = secureJoinVolumeSubPath(mountPoint, "relative")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(mountPoint, "config", "app"), path)
+
+	// symlinks pointing outside of the volume are resolved inside of it
+	path, err = secureJoinVolumeSubPath(mountPoint, "escape")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(mountPoint, outside), path)
+	assert.DirExists(t, path)
+
+	path, err = secureJoinVolumeSubPath(mountPoint, "config/up/data")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(mountPoint, "data"), path)
+	assert.DirExists(t, path)
+
+	entries, err := os.ReadDir(outside)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestVolumeSubPath(t *testing.T) {
+	mountPoint := t.TempDir()
+	outside := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(mountPoint, "config", "app"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(mountPoint, "config", "app.conf"), nil, 0644))
+	assert.NoError(t, os.Symlink("config/app", filepath.Join(mountPoint, "relative")))
+	assert.NoError(t, os.Symlink(outside, filepath.Join(mountPoint, "escape")))
+	assert.NoError(t, os.Symlink("../../..", filepath.Join(mountPoint, "config", "up")))
+
+	openedPath := func(subPath string) string {
+		f, err := volumeSubPath(mountPoint, subPath)
+		if !assert.NoError(t, err, subPath) {
+			return ""
+		}
+		defer f.Close()
+		path, err := os.Readlink(fmt.Sprintf("/proc/self/fd/%d", f.Fd()))
+		assert.NoError(t, err)
+		return path
+	}
+
+	assert.Equal(t, filepath.Join(mountPoint, "config", "app"), openedPath("config/app"))
+	assert.Equal(t, filepath.Join(mountPoint, "config", "app.conf"), openedPath("config/app.conf"))
+	assert.Equal(t, filepath.Join(mountPoint, "config", "app"), openedPath("relative"))
+
+	// symlinks pointing outside of the volume are resolved inside of it
+	assert.Equal(t, filepath.Join(mountPoint, outside), openedPath("escape"))
+	assert.DirExists(t, filepath.Join(mountPoint, outside))
+	assert.Equal(t, filepath.Join(mountPoint, "data"), openedPath("config/up/data"))
+	assert.DirExists(t, filepath.Join(mountPoint, "data"))
+
+	entries, err := os.ReadDir(outside)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = volumeSubPath(mountPoint, "config/app.conf/data")
+	assert.Error(t, err)
+}
+
+func TestOpenVolumeSubPath(t *testing.T) {
+	mountPoint := t.TempDir()
+	c := Container{config: &ContainerConfig{ID: "test"}}
+
+	path, source, err := c.openVolumeSubPath(mountPoint, "data")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(mountPoint, "data"), path)
+	assert.Equal(t, fmt.Sprintf("/proc/%d/fd/%d", os.Getpid(), c.volumeSubPaths[0].Fd()), source)
+
+	// the source keeps referring to the opened directory
+	assert.NoError(t, os.Rename(path, filepath.Join(mountPoint, "moved")))
+	assert.NoError(t, os.Symlink("/", path))
+	target, err := os.Readlink(source)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(mountPoint, "moved"), target)
+
+	c.closeVolumeSubPaths()
+	assert.Empty(t, c.volumeSubPaths)
+}
@@ -144,6 +144,18 @@ const (
 	// of the container
 	UlimitAnnotation = "io.podman.annotations.ulimit"
 
+	// IngressImageAnnotation is used by kube play to specify the image of
+	// the proxy of a Kubernetes Ingress.
+	IngressImageAnnotation = "io.podman.annotations.ingress.image"
+
+	// IngressHTTPPortAnnotation is used by kube play to specify the host
+	// port on which the proxy of a Kubernetes Ingress serves HTTP.
+	IngressHTTPPortAnnotation = "io.podman.annotations.ingress.http-port"
+
+	// IngressHTTPSPortAnnotation is used by kube play to specify the host
+	// port on which the proxy of a Kubernetes Ingress serves HTTPS.
+	IngressHTTPSPortAnnotation = "io.podman.annotations.ingress.https-port"
+
 	// MaxKubeAnnotation is the max length of annotations allowed by Kubernetes.
 	MaxKubeAnnotation = 63
 )
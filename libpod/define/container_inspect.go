@@ -57,6 +57,14 @@ type InspectContainerConfig struct {
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
 	// HealthcheckOnFailureAction defines an action to take once the container turns unhealthy.
 	HealthcheckOnFailureAction string `json:"HealthcheckOnFailureAction,omitempty"`
+	// Configured readiness check for the container
+	Readinesscheck *ReadinessCheck `json:"Readinesscheck,omitempty"`
+	// PostStartHook is the command executed in the container after it
+	// has been started.
+	PostStartHook []string `json:"PostStartHook,omitempty"`
+	// PreStopHook is the command executed in the container before it is
+	// stopped.
+	PreStopHook []string `json:"PreStopHook,omitempty"`
 	// CreateCommand is the full command plus arguments of the process the
 	// container has been created with.
 	CreateCommand []string `json:"CreateCommand,omitempty"`
@@ -176,7 +184,7 @@ type InspectHostPort struct {
 // included, and tmpfs volumes are not included even if the user specified them.
 type InspectMount struct {
 	// Whether the mount is a volume or bind mount. Allowed values are
-	// "volume" and "bind".
+	// "volume", "bind", "image" and "overlay".
 	Type string `json:"Type"`
 	// The name of the volume. Empty for bind mounts.
 	Name string `json:"Name,omitempty"`
@@ -220,6 +228,7 @@ type InspectContainerState struct {
 	StartedAt      time.Time          `json:"StartedAt"`
 	FinishedAt     time.Time          `json:"FinishedAt"`
 	Health         HealthCheckResults `json:"Health,omitempty"`
+	Ready          bool               `json:"Ready,omitempty"`
 	Checkpointed   bool               `json:"Checkpointed,omitempty"`
 	CgroupPath     string             `json:"CgroupPath,omitempty"`
 	CheckpointedAt time.Time          `json:"CheckpointedAt,omitempty"`
@@ -307,6 +316,9 @@ type InspectContainerHostConfig struct {
 	// and represents the container port. A single container port may be
 	// bound to multiple host ports (on different IPs).
 	PortBindings map[string][]InspectHostPort `json:"PortBindings"`
+	// NetworkShaping contains the traffic control applied to the network
+	// interfaces of the container. Only set if configured.
+	NetworkShaping *NetworkShaping `json:"NetworkShaping,omitempty"`
 	// RestartPolicy contains the container's restart policy.
 	RestartPolicy *InspectRestartPolicy `json:"RestartPolicy"`
 	// AutoRemove is whether the container will be automatically removed on
@@ -16,6 +16,12 @@ const (
 	// and the start-period (time allowed for the container to start and application
 	// to be running) expires.
 	HealthCheckStarting string = "starting"
+	// ReadinessCheckReady describes a container which passed its readiness
+	// check and is ready to serve requests
+	ReadinessCheckReady string = "ready"
+	// ReadinessCheckNotReady describes a running container which has not
+	// passed its readiness check (yet)
+	ReadinessCheckNotReady string = "not ready"
 )
 
 // HealthCheckStatus represents the current state of a container
@@ -145,3 +151,14 @@ type StartupHealthCheck struct {
 	// If set to 0, a single success will mark the HC as passed.
 	Successes int `json:",omitempty"`
 }
+
+// ReadinessCheck is the configuration of a readiness check. Unlike the regular
+// healthcheck, it does not change the health status of the container but
+// decides whether the container is ready to serve requests.
+type ReadinessCheck struct {
+	manifest.Schema2HealthConfig
+	// Successes are the number of consecutive successes required to mark
+	// the container as ready.
+	// If set to 0, a single success will mark the container as ready.
+	Successes int `json:",omitempty"`
+}
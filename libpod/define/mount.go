@@ -7,4 +7,9 @@ const (
 	TypeTmpfs = "tmpfs"
 	// TypeDevpts is the type for creating a devpts
 	TypeDevpts = "devpts"
+	// TypeGlob is the type for bind mounting all host paths matching a
+	// glob pattern
+	TypeGlob = "glob"
+	// TypeOverlay is the type for overlay mounting a host dir
+	TypeOverlay = "overlay"
 )
@@ -0,0 +1,142 @@
+package define
+
+import (
+	"time"
+
+	"github.com/containers/common/pkg/util"
+)
+
+// NetworkShaping describes the traffic control applied to the network
+// interfaces inside the network namespace of a container.
+type NetworkShaping struct {
+	// IngressRate is the maximum rate of traffic received by the
+	// container in bits per second. Traffic exceeding it is dropped.
+	// 0 means unlimited.
+	IngressRate uint64 `json:"IngressRate,omitempty"`
+	// EgressRate is the maximum rate of traffic sent by the container in
+	// bits per second. 0 means unlimited.
+	EgressRate uint64 `json:"EgressRate,omitempty"`
+	// Delay is added to all packets sent by the container.
+	Delay time.Duration `json:"Delay,omitempty"`
+	// Loss is the percentage of packets sent by the container that are
+	// dropped.
+	Loss float64 `json:"Loss,omitempty"`
+}
+
+// IsZero returns true if no traffic control is configured.
+func (n *NetworkShaping) IsZero() bool {
+	return n == nil || *n == NetworkShaping{}
+}
+
+// ServicePort redirects the port of a Kubernetes Service to the target port
+// in the pods selected by the Service.
+type ServicePort struct {
+	// Service is the name of the Kubernetes Service.
+	Service string `json:"Service"`
+	// Protocol is tcp, udp or sctp.
+	Protocol string `json:"Protocol"`
+	// Port is the port of the Service.
+	Port uint16 `json:"Port"`
+	// TargetPort is the port the containers of the pod listen on.
+	TargetPort uint16 `json:"TargetPort"`
+}
+
+// NetworkPolicy restricts the network traffic of the containers in a pod.
+// It is created from Kubernetes NetworkPolicy objects selecting the pod.
+type NetworkPolicy struct {
+	// Names of the Kubernetes NetworkPolicy objects the policy was
+	// created from.
+	Names []string `json:"Names,omitempty"`
+	// IsolateIngress drops all incoming traffic not allowed by a rule in
+	// Ingress.
+	IsolateIngress bool `json:"IsolateIngress,omitempty"`
+	// IsolateEgress drops all outgoing traffic not allowed by a rule in
+	// Egress.
+	IsolateEgress bool `json:"IsolateEgress,omitempty"`
+	// Ingress are the rules for allowed incoming traffic.
+	Ingress []NetworkPolicyRule `json:"Ingress,omitempty"`
+	// Egress are the rules for allowed outgoing traffic.
+	Egress []NetworkPolicyRule `json:"Egress,omitempty"`
+}
+
+// NetworkPolicyRule allows traffic from (ingress) or to (egress) one of the
+// peers on one of the ports.
+type NetworkPolicyRule struct {
+	// Peers the traffic is allowed for. All peers are allowed if empty.
+	Peers []NetworkPolicyPeer `json:"Peers,omitempty"`
+	// Ports the traffic is allowed on. All ports are allowed if empty.
+	Ports []NetworkPolicyPort `json:"Ports,omitempty"`
+}
+
+// NetworkPolicyPeer is either a set of pods selected by their labels or an
+// IP block.
+type NetworkPolicyPeer struct {
+	// PodSelector selects the pods by their labels.
+	PodSelector *LabelSelector `json:"PodSelector,omitempty"`
+	// CIDR is the IP block of the peer.
+	CIDR string `json:"CIDR,omitempty"`
+	// Except are IP blocks in CIDR which are excluded.
+	Except []string `json:"Except,omitempty"`
+}
+
+// NetworkPolicyPort is a port or port range of a protocol.
+type NetworkPolicyPort struct {
+	// Protocol is tcp, udp or sctp.
+	Protocol string `json:"Protocol"`
+	// Port is the first port of the range. All ports of the protocol are
+	// matched if it is 0.
+	Port uint16 `json:"Port,omitempty"`
+	// EndPort is the last port of the range, if it is a range.
+	EndPort uint16 `json:"EndPort,omitempty"`
+}
+
+// LabelSelector selects objects by their labels, following the semantics
+// of Kubernetes label selectors.
+type LabelSelector struct {
+	// MatchLabels must all be set with the given value.
+	MatchLabels map[string]string `json:"MatchLabels,omitempty"`
+	// MatchExpressions must all be fulfilled.
+	MatchExpressions []LabelSelectorRequirement `json:"MatchExpressions,omitempty"`
+}
+
+// LabelSelectorRequirement requires the value of a label to be In or NotIn
+// the values, or the label to exist (Exists) or not (DoesNotExist).
+type LabelSelectorRequirement struct {
+	Key      string   `json:"Key"`
+	Operator string   `json:"Operator"`
+	Values   []string `json:"Values,omitempty"`
+}
+
+// Matches returns true if the labels fulfill all requirements of the
+// selector. An empty selector matches all labels.
+func (s *LabelSelector) Matches(labels map[string]string) bool {
+	for key, value := range s.MatchLabels {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	for _, req := range s.MatchExpressions {
+		value, ok := labels[req.Key]
+		switch req.Operator {
+		case "In":
+			if !ok || !util.StringInSlice(value, req.Values) {
+				return false
+			}
+		case "NotIn":
+			if ok && util.StringInSlice(value, req.Values) {
+				return false
+			}
+		case "Exists":
+			if !ok {
+				return false
+			}
+		case "DoesNotExist":
+			if ok {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
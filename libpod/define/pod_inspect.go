@@ -21,8 +21,15 @@ type InspectPodData struct {
 	CreateCommand []string `json:"CreateCommand,omitempty"`
 	// ExitPolicy of the pod.
 	ExitPolicy string `json:"ExitPolicy,omitempty"`
+	// QOSClass is the Kubernetes quality of service class of the pod,
+	// Guaranteed, Burstable or BestEffort. It is only set for pods
+	// created by kube play.
+	QOSClass string `json:"QOSClass,omitempty"`
 	// State represents the current state of the pod.
 	State string `json:"State"`
+	// Ready is whether all containers of the pod, except init containers,
+	// are ready to serve requests.
+	Ready bool
 	// Hostname is the hostname that the pod will set.
 	Hostname string
 	// Labels is a set of key-value labels that have been applied to the
@@ -83,6 +90,11 @@ type InspectPodData struct {
 	BlkioWeight uint64 `json:"blkio_weight,omitempty"`
 	// BlkioWeightDevice contains the blkio weight device limits for the pod
 	BlkioWeightDevice []InspectBlkioWeightDevice `json:"blkio_weight_device,omitempty"`
+	// NetworkPolicy restricts the network traffic of the pod
+	NetworkPolicy *NetworkPolicy `json:"network_policy,omitempty"`
+	// ServicePorts are the ports of Kubernetes Services redirected to
+	// another port of the pod
+	ServicePorts []ServicePort `json:"service_ports,omitempty"`
 }
 
 // InspectPodInfraConfig contains the configuration of the pod's infra
@@ -146,4 +158,6 @@ type InspectPodContainerInfo struct {
 	Name string
 	// State is the current status of the container.
 	State string
+	// Ready is whether the container is ready to serve requests.
+	Ready bool
 }
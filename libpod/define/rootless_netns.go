@@ -0,0 +1,67 @@
+package define
+
+import "github.com/containers/common/libnetwork/types"
+
+// RootlessNetNSInfo describes the network namespace shared by all rootless
+// containers using a bridge network.
+type RootlessNetNSInfo struct {
+	// Path is the path of the network namespace.
+	Path string `json:"Path"`
+	// Exists is true if the network namespace is set up. All other fields
+	// are empty if it is not.
+	Exists bool `json:"Exists"`
+	// Helper is the process connecting the namespace to the host network.
+	Helper *RootlessNetNSHelper `json:"Helper,omitempty"`
+	// Interfaces are the network interfaces in the namespace.
+	Interfaces []RootlessNetNSInterface `json:"Interfaces"`
+	// Routes are the routes of the main routing table of the namespace.
+	Routes []RootlessNetNSRoute `json:"Routes"`
+	// Containers are the containers attached to bridge networks through
+	// the namespace.
+	Containers []RootlessNetNSContainer `json:"Containers"`
+}
+
+// RootlessNetNSHelper describes the process providing the network connection
+// of the rootless network namespace.
+type RootlessNetNSHelper struct {
+	// Name of the program, e.g. slirp4netns.
+	Name string `json:"Name"`
+	// PID of the process.
+	PID int `json:"PID"`
+	// Running is false if the process exited, in which case the
+	// containers in the namespace have no network connection to the host.
+	Running bool `json:"Running"`
+}
+
+// RootlessNetNSInterface describes a network interface in the rootless
+// network namespace.
+type RootlessNetNSInterface struct {
+	Name       string   `json:"Name"`
+	Type       string   `json:"Type"`
+	MacAddress string   `json:"MacAddress,omitempty"`
+	MTU        int      `json:"MTU"`
+	State      string   `json:"State"`
+	Addresses  []string `json:"Addresses,omitempty"`
+}
+
+// RootlessNetNSRoute describes a route in the rootless network namespace.
+type RootlessNetNSRoute struct {
+	// Destination is the destination subnet, "default" for the default
+	// route.
+	Destination string `json:"Destination"`
+	Gateway     string `json:"Gateway,omitempty"`
+	Interface   string `json:"Interface"`
+}
+
+// RootlessNetNSContainer describes a container attached to the rootless
+// network namespace.
+type RootlessNetNSContainer struct {
+	ID   string `json:"ID"`
+	Name string `json:"Name"`
+	// Pod is the ID of the pod if the container is an infra container.
+	Pod string `json:"Pod,omitempty"`
+	// Networks are the names of the bridge networks of the container.
+	Networks []string `json:"Networks"`
+	// Ports are the ports forwarded from the host to the container.
+	Ports []types.PortMapping `json:"Ports,omitempty"`
+}
@@ -7,14 +7,15 @@ const (
 	SdNotifyModeContainer = "container"
 	SdNotifyModeConmon    = "conmon"
 	SdNotifyModeIgnore    = "ignore"
+	SdNotifyModeReady     = "ready"
 )
 
 // ValidateSdNotifyMode validates the specified mode.
 func ValidateSdNotifyMode(mode string) error {
 	switch mode {
-	case "", SdNotifyModeContainer, SdNotifyModeConmon, SdNotifyModeIgnore:
+	case "", SdNotifyModeContainer, SdNotifyModeConmon, SdNotifyModeIgnore, SdNotifyModeReady:
 		return nil
 	default:
-		return fmt.Errorf("%w: invalid sdnotify value %q: must be %s, %s or %s", ErrInvalidArg, mode, SdNotifyModeContainer, SdNotifyModeConmon, SdNotifyModeIgnore)
+		return fmt.Errorf("%w: invalid sdnotify value %q: must be %s, %s, %s or %s", ErrInvalidArg, mode, SdNotifyModeContainer, SdNotifyModeConmon, SdNotifyModeIgnore, SdNotifyModeReady)
 	}
 }
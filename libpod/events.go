@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/containers/podman/v4/libpod/events"
@@ -64,17 +65,28 @@ func (c *Container) newContainerEventWithInspectData(status events.Status, inspe
 		}
 	}
 
-	// if the current event is a HealthStatus event, we need to get the current
-	// status of the container to pass to the event
-	if status == events.HealthStatus {
-		containerHealthStatus, err := c.healthCheckStatus()
-		if err != nil {
-			e.HealthStatus = fmt.Sprintf("%v", err)
-		}
-		e.HealthStatus = containerHealthStatus
+	return c.runtime.eventer.Write(e)
+}
+
+// newHealthStatusEvent creates a new event carrying the result of a
+// container's health check
+func (c *Container) newHealthStatusEvent(healthStatus string) {
+	e := events.NewEvent(events.HealthStatus)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+	e.HealthStatus = healthStatus
+
+	e.Details = events.Details{
+		ID:         e.ID,
+		PodID:      c.PodID(),
+		Attributes: c.Labels(),
 	}
 
-	return c.runtime.eventer.Write(e)
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write health status event: %q", err)
+	}
 }
 
 // newContainerExitedEvent creates a new event for a container's death
@@ -97,25 +109,42 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
+// newExecEvent creates a new event for an exec session of the container.
+// The command of the session is recorded in the execCommand attribute.
+func (c *Container) newExecEvent(status events.Status, session *ExecSession) {
+	e := c.execEvent(status, session.ID())
+	e.Attributes["execCommand"] = strings.Join(session.Config.Command, " ")
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write exec event: %q", err)
+	}
+}
+
 // newExecDiedEvent creates a new event for an exec session's death
 func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
-	e := events.NewEvent(events.ExecDied)
+	e := c.execEvent(events.ExecDied, sessionID)
+	e.ContainerExitCode = exitCode
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write exec died event: %q", err)
+	}
+}
+
+// execEvent returns an event for the given exec session.  The ID of the
+// session is recorded in the execID attribute next to the container labels.
+func (c *Container) execEvent(status events.Status, sessionID string) events.Event {
+	e := events.NewEvent(status)
 	e.ID = c.ID()
 	e.Name = c.Name()
 	e.Image = c.config.RootfsImageName
 	e.Type = events.Container
-	e.ContainerExitCode = exitCode
-	e.Attributes = make(map[string]string)
-	e.Attributes["execID"] = sessionID
 
 	e.Details = events.Details{
 		ID:         e.ID,
+		PodID:      c.PodID(),
 		Attributes: c.Labels(),
 	}
-
-	if err := c.runtime.eventer.Write(e); err != nil {
-		logrus.Errorf("Unable to write exec died event: %q", err)
-	}
+	e.Attributes["execID"] = sessionID
+	return e
 }
 
 // netNetworkEvent creates a new event based on a network connect/disconnect
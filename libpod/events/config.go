@@ -142,6 +142,8 @@ const (
 	Create Status = "create"
 	// Exec ...
 	Exec Status = "exec"
+	// ExecCreate indicates that an exec session was created in a container.
+	ExecCreate Status = "exec_create"
 	// ExecDied indicates that an exec session in a container died.
 	ExecDied Status = "exec_died"
 	// Exited indicates that a container's process died
@@ -166,8 +168,15 @@ const (
 	NetworkConnect Status = "connect"
 	// NetworkDisconnect
 	NetworkDisconnect Status = "disconnect"
+	// OOM indicates that a container ran out of memory and was killed
+	// by the kernel.
+	OOM Status = "oom"
 	// Pause ...
 	Pause Status = "pause"
+	// PortAdd indicates that ports of a container were published
+	PortAdd Status = "port-add"
+	// PortRemove indicates that published ports of a container were removed
+	PortRemove Status = "port-remove"
 	// Prune ...
 	Prune Status = "prune"
 	// Pull ...
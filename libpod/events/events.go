@@ -91,6 +91,10 @@ func (e *Event) ToHumanReadable(truncate bool) string {
 		}
 		humanFormat += ")"
 	case Network:
+		if e.Status == PortAdd || e.Status == PortRemove {
+			humanFormat = fmt.Sprintf("%s %s %s %s (container=%s, ports=%s)", e.Time, e.Type, e.Status, id, id, e.Attributes["ports"])
+			break
+		}
 		humanFormat = fmt.Sprintf("%s %s %s %s (container=%s, name=%s)", e.Time, e.Type, e.Status, id, id, e.Network)
 	case Image:
 		humanFormat = fmt.Sprintf("%s %s %s %s %s", e.Time, e.Type, e.Status, id, e.Name)
@@ -168,6 +172,8 @@ func StringToStatus(name string) (Status, error) {
 		return Create, nil
 	case Exec.String():
 		return Exec, nil
+	case ExecCreate.String():
+		return ExecCreate, nil
 	case ExecDied.String():
 		return ExecDied, nil
 	case Exited.String():
@@ -192,8 +198,14 @@ func StringToStatus(name string) (Status, error) {
 		return NetworkConnect, nil
 	case NetworkDisconnect.String():
 		return NetworkDisconnect, nil
+	case OOM.String():
+		return OOM, nil
 	case Pause.String():
 		return Pause, nil
+	case PortAdd.String():
+		return PortAdd, nil
+	case PortRemove.String():
+		return PortRemove, nil
 	case Prune.String():
 		return Prune, nil
 	case Pull.String():
@@ -166,6 +166,7 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 	if err != nil {
 		return hcResult, "", fmt.Errorf("unable to update health check log %s for %s: %w", c.healthCheckLogPath(), c.ID(), err)
 	}
+	c.newHealthStatusEvent(logStatus)
 
 	return hcResult, logStatus, hcErr
 }
@@ -22,3 +22,20 @@ func (c *Container) startTimer(isStartup bool) error {
 func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool) error {
 	return nil
 }
+
+// createReadinessTimer creates the systemd timer for the readiness check of a
+// container
+func (c *Container) createReadinessTimer() error {
+	return nil
+}
+
+// startReadinessTimer starts the systemd timer for the readiness check
+func (c *Container) startReadinessTimer() error {
+	return nil
+}
+
+// removeReadinessTimer removes the systemd timer and unit files of the
+// readiness check
+func (c *Container) removeReadinessTimer(ctx context.Context) error {
+	return nil
+}
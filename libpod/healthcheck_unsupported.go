@@ -23,3 +23,20 @@ func (c *Container) startTimer(isStartup bool) error {
 func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool) error {
 	return errors.New("not implemented (*Container) removeTransientFiles")
 }
+
+// createReadinessTimer creates the systemd timer for the readiness check of a
+// container
+func (c *Container) createReadinessTimer() error {
+	return errors.New("not implemented (*Container) createReadinessTimer")
+}
+
+// startReadinessTimer starts the systemd timer for the readiness check
+func (c *Container) startReadinessTimer() error {
+	return errors.New("not implemented (*Container) startReadinessTimer")
+}
+
+// removeReadinessTimer removes the systemd timer and unit files of the
+// readiness check
+func (c *Container) removeReadinessTimer(ctx context.Context) error {
+	return errors.New("not implemented (*Container) removeReadinessTimer")
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/containers/podman/v4/pkg/namespaces"
 	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/containers/podman/v4/pkg/util"
+	"github.com/containers/storage/pkg/regexp"
+	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 )
@@ -727,6 +729,16 @@ func containerToV1Container(ctx context.Context, c *Container, getService bool)
 	kubeContainer.StdinOnce = false
 	kubeContainer.TTY = c.Terminal()
 
+	if len(c.config.PostStartHook) > 0 || len(c.config.PreStopHook) > 0 {
+		kubeContainer.Lifecycle = &v1.Lifecycle{}
+		if len(c.config.PostStartHook) > 0 {
+			kubeContainer.Lifecycle.PostStart = &v1.Handler{Exec: &v1.ExecAction{Command: c.config.PostStartHook}}
+		}
+		if len(c.config.PreStopHook) > 0 {
+			kubeContainer.Lifecycle.PreStop = &v1.Handler{Exec: &v1.ExecAction{Command: c.config.PreStopHook}}
+		}
+	}
+
 	resources := c.LinuxResources()
 	if resources != nil {
 		if resources.Memory != nil &&
@@ -761,6 +773,33 @@ func containerToV1Container(ctx context.Context, c *Container, getService bool)
 				}
 			}
 		}
+
+		if resources.Memory != nil &&
+			resources.Memory.Reservation != nil &&
+			*resources.Memory.Reservation > 0 {
+			if kubeContainer.Resources.Requests == nil {
+				kubeContainer.Resources.Requests = v1.ResourceList{}
+			}
+
+			qty := kubeContainer.Resources.Requests.Memory()
+			qty.Set(*resources.Memory.Reservation)
+			kubeContainer.Resources.Requests[v1.ResourceMemory] = *qty
+		}
+
+		// Kubernetes converts CPU requests to CPU shares, with 1024 shares
+		// per CPU, rounding down. Round up to get the request back. The
+		// minimum of 2 shares is used without a request.
+		if resources.CPU != nil &&
+			resources.CPU.Shares != nil &&
+			*resources.CPU.Shares > 2 {
+			if kubeContainer.Resources.Requests == nil {
+				kubeContainer.Resources.Requests = v1.ResourceList{}
+			}
+
+			qty := kubeContainer.Resources.Requests.Cpu()
+			qty.SetMilli((int64(*resources.CPU.Shares)*1000 + 1023) / 1024)
+			kubeContainer.Resources.Requests[v1.ResourceCPU] = *qty
+		}
 	}
 
 	// Obtain the DNS entries from the container
@@ -893,9 +932,46 @@ func libpodMountsToKubeVolumeMounts(c *Container) ([]v1.VolumeMount, []v1.Volume
 		vms = append(vms, vm)
 		vos = append(vos, vo)
 	}
+	for _, v := range c.config.ImageVolumes {
+		vm, vo := generateKubeImageVolume(v)
+		vms = append(vms, vm)
+		vos = append(vos, vo)
+	}
 	return vms, vos, annotations, nil
 }
 
+// imageVolumeNameRegex matches the characters of an image reference which are
+// not allowed in volume names.
+var imageVolumeNameRegex = regexp.Delayed(`[^a-z0-9]+`)
+
+// generateKubeImageVolume converts a ContainerImageVolume to a Kubernetes image volume
+func generateKubeImageVolume(v *ContainerImageVolume) (v1.VolumeMount, v1.Volume) {
+	if v.ReadWrite {
+		logrus.Warnf("Image volume %s mounted at %s is writable, Kubernetes mounts image volumes read-only", v.Source, v.Dest)
+	}
+
+	// Volume names must be DNS labels, so the name is derived from the
+	// sanitized and truncated reference. Different references can end up
+	// the same that way, so a short hash of the full reference is added.
+	// The "-image" suffix avoids conflicts with other types of volumes.
+	name := strings.Trim(imageVolumeNameRegex.ReplaceAllString(strings.ToLower(v.Source), "-"), "-")
+	if len(name) > 48 {
+		name = strings.TrimRight(name[:48], "-")
+	}
+	name = fmt.Sprintf("%s-%s-image", name, digest.FromString(v.Source).Encoded()[:8])
+
+	vm := v1.VolumeMount{}
+	vm.Name = name
+	vm.MountPath = v.Dest
+	vm.ReadOnly = true
+
+	vs := v1.VolumeSource{}
+	vs.Image = &v1.ImageVolumeSource{Reference: v.Source}
+	vo := v1.Volume{Name: name, VolumeSource: vs}
+
+	return vm, vo
+}
+
 // generateKubePersistentVolumeClaim converts a ContainerNamedVolume to a Kubernetes PersistentVolumeClaim
 func generateKubePersistentVolumeClaim(v *ContainerNamedVolume) (v1.VolumeMount, v1.Volume) {
 	ro := cutil.StringInSlice("ro", v.Options)
@@ -907,6 +983,7 @@ func generateKubePersistentVolumeClaim(v *ContainerNamedVolume) (v1.VolumeMount,
 	vm.Name = name
 	vm.MountPath = v.Dest
 	vm.ReadOnly = ro
+	vm.SubPath = v.SubPath
 
 	pvc := v1.PersistentVolumeClaimVolumeSource{ClaimName: v.Name, ReadOnly: ro}
 	vs := v1.VolumeSource{}
@@ -0,0 +1,69 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleHooks(t *testing.T) {
+	r, fake := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r,
+		WithPostStartHook([]string{"/bin/post-start"}),
+		WithPreStopHook([]string{"/bin/pre-stop", "--drain"}),
+	)
+
+	var hooks [][]string
+	fake.ExecFunc = func(ctr *Container, options *ExecOptions) int {
+		hooks = append(hooks, options.Cmd)
+		return 0
+	}
+
+	require.NoError(t, ctr.Start(ctx, false))
+	assert.Equal(t, [][]string{{"/bin/post-start"}}, hooks)
+	require.NoError(t, ctr.Stop())
+	assert.Equal(t, [][]string{{"/bin/post-start"}, {"/bin/pre-stop", "--drain"}}, hooks)
+
+	// The pre-stop hook is not run for stopped containers.
+	require.NoError(t, ctr.Cleanup(ctx))
+	require.NoError(t, r.RemoveContainer(ctx, ctr, true, false, nil))
+	assert.Len(t, hooks, 2)
+}
+
+func TestLifecycleHooksFailure(t *testing.T) {
+	r, fake := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r,
+		WithPostStartHook([]string{"/bin/post-start"}),
+		WithPreStopHook([]string{"/bin/pre-stop"}),
+	)
+
+	var hooks []string
+	fake.ExecFunc = func(ctr *Container, options *ExecOptions) int {
+		hooks = append(hooks, options.Cmd[0])
+		return 1
+	}
+
+	// A failed post-start hook stops the container again, which runs
+	// the pre-stop hook. Its failure does not prevent stopping.
+	err := ctr.Start(ctx, false)
+	assert.ErrorContains(t, err, "post-start hook of container "+ctr.ID()+" exited with code 1")
+	assert.Equal(t, []string{"/bin/post-start", "/bin/pre-stop"}, hooks)
+	require.NoError(t, ctr.Cleanup(ctx))
+	state, err := ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateExited, state)
+}
+
+func TestLifecycleHooksEmptyCommand(t *testing.T) {
+	ctr := &Container{config: new(ContainerConfig)}
+	assert.ErrorIs(t, WithPostStartHook(nil)(ctr), define.ErrInvalidArg)
+	assert.ErrorIs(t, WithPreStopHook([]string{})(ctr), define.ErrInvalidArg)
+}
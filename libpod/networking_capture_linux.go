@@ -0,0 +1,226 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/capture"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// captureReadTimeout is the interval in which a running capture checks if it
+// was cancelled while no packets are received.
+const captureReadTimeout = 250 * time.Millisecond
+
+// Capture records the network traffic of the container on one interface in
+// pcap format to w. It runs until ctx is cancelled, the configured number of
+// packets was captured or the interface is removed. Errors about the
+// container or the options are returned before anything is written to w.
+func (c *Container) Capture(ctx context.Context, options ContainerCaptureOptions, w io.Writer) error {
+	filter, err := capture.ParseFilter(options.Filter)
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+	}
+	if options.Count < 0 {
+		return fmt.Errorf("packet count must not be negative: %w", define.ErrInvalidArg)
+	}
+
+	fd, link, linkType, err := c.openCaptureSocket(options.Interface, filter)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	loopback := link.Attrs().Flags&net.FlagLoopback != 0
+	logrus.Debugf("Capturing on interface %s of container %s", link.Attrs().Name, c.ID())
+
+	pw, err := capture.NewWriter(w, capture.DefaultSnaplen, linkType)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, capture.DefaultSnaplen)
+	captured := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		// MSG_TRUNC returns the length of the packet even if it does
+		// not fit into the buffer
+		n, from, err := unix.Recvfrom(fd, buf, unix.MSG_TRUNC)
+		if err != nil {
+			switch {
+			case errors.Is(err, unix.EAGAIN), errors.Is(err, unix.EINTR):
+				continue
+			case errors.Is(err, unix.ENETDOWN), errors.Is(err, unix.ENXIO):
+				// the interface was removed, i.e. the container stopped
+				return nil
+			}
+			return fmt.Errorf("reading packet from interface %s: %w", link.Attrs().Name, err)
+		}
+		ts := time.Now()
+		// on loopback interfaces every packet is received twice, once
+		// outgoing and once incoming
+		if sa, ok := from.(*unix.SockaddrLinklayer); ok && loopback && sa.Pkttype == unix.PACKET_OUTGOING {
+			continue
+		}
+		data := buf
+		if n < len(buf) {
+			data = buf[:n]
+		}
+		if err := pw.WritePacket(ts, data, n); err != nil {
+			return err
+		}
+		captured++
+		if options.Count > 0 && captured >= options.Count {
+			return nil
+		}
+	}
+}
+
+// openCaptureSocket opens a packet socket bound to the interface with the
+// given name in the network namespace of the container. The filter is
+// attached to the socket, so the kernel only queues the matching packets. The
+// socket stays in that namespace, so the capture does not keep the container
+// locked.
+func (c *Container) openCaptureSocket(name string, filter *capture.Filter) (int, netlink.Link, capture.LinkType, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return -1, nil, 0, err
+	}
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return -1, nil, 0, fmt.Errorf("container %s must be running to capture its network traffic: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+	// The network namespace of rootless containers is owned by the rootless
+	// user namespace podman runs in, so it is entered the same way as for
+	// root, no matter if the container uses slirp4netns, pasta or a bridge
+	// network set up from the rootless netns.
+	nsPath, _, err := getContainerNetNS(c)
+	if err != nil {
+		return -1, nil, 0, err
+	}
+	if nsPath == "" {
+		return -1, nil, 0, fmt.Errorf("container %s does not have a network namespace to capture on: %w", c.ID(), define.ErrNetworkModeInvalid)
+	}
+
+	fd := -1
+	var (
+		link     netlink.Link
+		linkType capture.LinkType
+	)
+	err = ns.WithNetNSPath(nsPath, func(_ ns.NetNS) error {
+		var err error
+		link, err = captureLink(name)
+		if err != nil {
+			return err
+		}
+		linkType, err = captureLinkType(link)
+		if err != nil {
+			return err
+		}
+		prog, err := filter.Program(linkType, capture.DefaultSnaplen)
+		if err != nil {
+			return fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+		}
+		// A socket for protocol 0 does not receive any packets until it
+		// is bound to the interface, so no packets of other interfaces
+		// are queued in the meantime.
+		fd, err = unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+		if err != nil {
+			return fmt.Errorf("opening packet socket: %w", err)
+		}
+		if err := attachCaptureFilter(fd, prog); err != nil {
+			return err
+		}
+		addr := &unix.SockaddrLinklayer{
+			Protocol: htons(unix.ETH_P_ALL),
+			Ifindex:  link.Attrs().Index,
+		}
+		if err := unix.Bind(fd, addr); err != nil {
+			return fmt.Errorf("binding packet socket to interface %s: %w", link.Attrs().Name, err)
+		}
+		timeout := unix.NsecToTimeval(captureReadTimeout.Nanoseconds())
+		if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+			return fmt.Errorf("setting packet socket timeout: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if fd >= 0 {
+			unix.Close(fd)
+		}
+		return -1, nil, 0, fmt.Errorf("capturing network traffic of container %s: %w", c.ID(), err)
+	}
+	return fd, link, linkType, nil
+}
+
+// attachCaptureFilter attaches the classic BPF program to the packet socket.
+// Without a program, all packets are captured.
+func attachCaptureFilter(fd int, prog []capture.Instruction) error {
+	if len(prog) == 0 {
+		return nil
+	}
+	filter := make([]unix.SockFilter, 0, len(prog))
+	for _, ins := range prog {
+		filter = append(filter, unix.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K})
+	}
+	fprog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	if err := unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, &fprog); err != nil {
+		return fmt.Errorf("attaching packet filter: %w", err)
+	}
+	return nil
+}
+
+// captureLink returns the interface with the given name or, if name is empty,
+// the first interface which is up and not a loopback interface. It must be
+// called in the network namespace of the container.
+func captureLink(name string) (netlink.Link, error) {
+	if name != "" {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("interface %s: %v: %w", name, err, define.ErrInvalidArg)
+		}
+		return link, nil
+	}
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		flags := link.Attrs().Flags
+		if flags&net.FlagUp != 0 && flags&net.FlagLoopback == 0 {
+			return link, nil
+		}
+	}
+	return nil, errors.New("no network interface found, use an explicit interface name")
+}
+
+// captureLinkType returns the type of the link layer header of the packets
+// received on link.
+func captureLinkType(link netlink.Link) (capture.LinkType, error) {
+	switch link.Attrs().EncapType {
+	case "ether", "loopback":
+		return capture.LinkTypeEthernet, nil
+	case "none":
+		return capture.LinkTypeRaw, nil
+	}
+	return 0, fmt.Errorf("capturing on interface %s with link type %q is not supported: %w", link.Attrs().Name, link.Attrs().EncapType, define.ErrNotImplemented)
+}
+
+func htons(i uint16) uint16 {
+	return i<<8 | i>>8
+}
@@ -26,12 +26,17 @@ import (
 // This is need because a HostIP of 127.0.0.1 would now allow the gvproxy forwarder to reach to open ports.
 // For machine the HostIP must only be used by gvproxy and never in the VM.
 func (c *Container) convertPortMappings() []types.PortMapping {
-	if !machine.IsGvProxyBased() || len(c.config.PortMappings) == 0 {
-		return c.config.PortMappings
+	return convertPortMappings(c.config.PortMappings)
+}
+
+// convertPortMappings removes the HostIP part from the given ports when running inside podman machine.
+func convertPortMappings(ports []types.PortMapping) []types.PortMapping {
+	if !machine.IsGvProxyBased() || len(ports) == 0 {
+		return ports
 	}
 	// if we run in a machine VM we have to ignore the host IP part
-	newPorts := make([]types.PortMapping, 0, len(c.config.PortMappings))
-	for _, port := range c.config.PortMappings {
+	newPorts := make([]types.PortMapping, 0, len(ports))
+	for _, port := range ports {
 		port.HostIP = ""
 		newPorts = append(newPorts, port)
 	}
@@ -186,13 +191,26 @@ func (r *Runtime) reloadContainerNetwork(ctr *Container) (map[string]types.Statu
 		}
 	}
 
-	networkOpts, err := ctr.networks()
+	networkOpts, err := ctr.currentNetworkOptions()
+	if err != nil {
+		return nil, err
+	}
+	ctr.perNetworkOpts = networkOpts
+
+	return r.configureNetNS(ctr, ctr.state.NetNS)
+}
+
+// currentNetworkOptions returns the per network options of the container with
+// the interface names, MAC and IP addresses of its current network status, so
+// that the networks can be set up again with the same settings.
+func (c *Container) currentNetworkOptions() (map[string]types.PerNetworkOptions, error) {
+	networkOpts, err := c.networks()
 	if err != nil {
 		return nil, err
 	}
 
 	// Set the same network settings as before..
-	netStatus := ctr.getNetworkStatus()
+	netStatus := c.getNetworkStatus()
 	for network, perNetOpts := range networkOpts {
 		for name, netInt := range netStatus[network].Interfaces {
 			perNetOpts.InterfaceName = name
@@ -206,9 +224,7 @@ func (r *Runtime) reloadContainerNetwork(ctr *Container) (map[string]types.Statu
 		}
 		networkOpts[network] = perNetOpts
 	}
-	ctr.perNetworkOpts = networkOpts
-
-	return r.configureNetNS(ctr, ctr.state.NetNS)
+	return networkOpts, nil
 }
 
 // Produce an InspectNetworkSettings containing information on the container
@@ -543,6 +559,10 @@ func (c *Container) NetworkConnect(nameOrID, netName string, netOpts types.PerNe
 	if len(results) != 1 {
 		return errors.New("when adding aliases, results must be of length 1")
 	}
+	// apply the traffic control to the new interface as well
+	if err := c.configureNetworkShaping(c.state.NetNS); err != nil {
+		return err
+	}
 
 	// we need to get the old host entries before we add the new one to the status
 	// if we do not add do it here we will get the wrong existing entries which will throw of the logic
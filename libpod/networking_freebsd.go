@@ -4,16 +4,19 @@
 package libpod
 
 import (
+	"context"
 	"crypto/rand"
 	jdec "encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os/exec"
 	"path/filepath"
 
 	"github.com/containers/buildah/pkg/jail"
 	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/storage/pkg/lockfile"
 	"github.com/sirupsen/logrus"
 )
@@ -144,7 +147,7 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		return nil, err
 	}
 
-	return netStatus, err
+	return netStatus, ctr.configureNetworkShaping(ctrNS)
 }
 
 // Create and configure a new network namespace for a container
@@ -268,6 +271,47 @@ func (c *Container) reloadRootlessRLKPortMapping() error {
 	return errors.New("unsupported (*Container).reloadRootlessRLKPortMapping")
 }
 
+func (c *Container) hasRootlessPortForwarder() bool {
+	return false
+}
+
+func (c *Container) reloadRootlessPortForwarder() error {
+	return errors.New("unsupported (*Container).reloadRootlessPortForwarder")
+}
+
 func (c *Container) setupRootlessNetwork() error {
 	return nil
 }
+
+// setupNetworkShaping is not supported on FreeBSD.
+func (c *Container) setupNetworkShaping(nsPath string) error {
+	if c.config.NetworkShaping.IsZero() {
+		return nil
+	}
+	return fmt.Errorf("network shaping is not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
+
+// Capture is not supported on FreeBSD.
+func (c *Container) Capture(ctx context.Context, options ContainerCaptureOptions, w io.Writer) error {
+	return fmt.Errorf("capturing network traffic is not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
+
+// setupNetworkPolicy is not supported on FreeBSD.
+func (c *Container) setupNetworkPolicy(nsPath string, policy *define.NetworkPolicy) error {
+	return fmt.Errorf("network policies are not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
+
+// RootlessNetNSInspect is not supported on FreeBSD.
+func (r *Runtime) RootlessNetNSInspect() (*define.RootlessNetNSInfo, error) {
+	return nil, fmt.Errorf("the rootless network namespace is not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
+
+// CleanupRootlessNetNS is not supported on FreeBSD.
+func (r *Runtime) CleanupRootlessNetNS() error {
+	return fmt.Errorf("the rootless network namespace is not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
+
+// RootlessNetNSExec is not supported on FreeBSD.
+func (r *Runtime) RootlessNetNSExec(toRun func() error) error {
+	return fmt.Errorf("the rootless network namespace is not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
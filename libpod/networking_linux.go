@@ -258,6 +258,22 @@ func (r *RootlessNetNS) Cleanup(runtime *Runtime) error {
 		// the directory does not exist, so no need for cleanup
 		return nil
 	}
+	ctrs, err := r.activeContainers(runtime)
+	if err != nil {
+		return err
+	}
+	// no cleanup if we found no other containers with a netns
+	// we will always find one container (the container cleanup that is currently calling us)
+	if len(ctrs) > 1 {
+		return nil
+	}
+	return r.teardown()
+}
+
+// activeContainers returns the containers with a bridge network which still
+// have a network namespace, i.e. the containers attached to the rootless
+// netns. It expects that r.Lock is locked.
+func (r *RootlessNetNS) activeContainers(runtime *Runtime) ([]*Container, error) {
 	activeNetns := func(c *Container) bool {
 		// no bridge => no need to check
 		if !c.config.NetMode.IsBridge() {
@@ -283,17 +299,14 @@ func (r *RootlessNetNS) Cleanup(runtime *Runtime) error {
 		// only if the netns is empty we know that we do not need cleanup
 		return c.state.NetNS != ""
 	}
-	ctrs, err := runtime.GetContainers(false, activeNetns)
-	if err != nil {
-		return err
-	}
-	// no cleanup if we found no other containers with a netns
-	// we will always find one container (the container cleanup that is currently calling us)
-	if len(ctrs) > 1 {
-		return nil
-	}
+	return runtime.GetContainers(false, activeNetns)
+}
+
+// teardown removes the rootless netns and kills the slirp4netns process.
+// It expects that r.Lock is locked.
+func (r *RootlessNetNS) teardown() error {
 	logrus.Debug("Cleaning up rootless network namespace")
-	err = netns.UnmountNS(r.ns.Path())
+	err := netns.UnmountNS(r.ns.Path())
 	if err != nil {
 		return err
 	}
@@ -302,14 +315,10 @@ func (r *RootlessNetNS) Cleanup(runtime *Runtime) error {
 	if err != nil {
 		logrus.Error(err)
 	}
-	b, err := os.ReadFile(r.getPath(rootlessNetNsSilrp4netnsPidFile))
+	pid, err := r.slirp4netnsPid()
 	if err == nil {
-		var i int
-		i, err = strconv.Atoi(string(b))
-		if err == nil {
-			// kill the slirp process so we do not leak it
-			err = syscall.Kill(i, syscall.SIGTERM)
-		}
+		// kill the slirp process so we do not leak it
+		err = syscall.Kill(pid, syscall.SIGTERM)
 	}
 	if err != nil {
 		logrus.Errorf("Failed to kill slirp4netns process: %v", err)
@@ -321,6 +330,26 @@ func (r *RootlessNetNS) Cleanup(runtime *Runtime) error {
 	return nil
 }
 
+// slirp4netnsPid returns the pid of the slirp4netns process of the rootless
+// netns.
+func (r *RootlessNetNS) slirp4netnsPid() (int, error) {
+	b, err := os.ReadFile(r.getPath(rootlessNetNsSilrp4netnsPidFile))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(b))
+}
+
+// rootlessNetNsName returns the name of the rootless netns of the runtime.
+func (r *Runtime) rootlessNetNsName() string {
+	// create a hash from the static dir
+	// the cleanup will check if there are running containers
+	// if you run a several libpod instances with different root/runroot directories this check will fail
+	// we want one netns for each libpod static dir so we use the hash to prevent name collisions
+	hash := sha256.Sum256([]byte(r.config.Engine.StaticDir))
+	return fmt.Sprintf("%s-%x", rootlessNetNsName, hash[:10])
+}
+
 // GetRootlessNetNs returns the rootless netns object. If create is set to true
 // the rootless network namespace will be created if it does not already exist.
 // If called as root it returns always nil.
@@ -357,12 +386,7 @@ func (r *Runtime) GetRootlessNetNs(new bool) (*RootlessNetNS, error) {
 		return nil, err
 	}
 
-	// create a hash from the static dir
-	// the cleanup will check if there are running containers
-	// if you run a several libpod instances with different root/runroot directories this check will fail
-	// we want one netns for each libpod static dir so we use the hash to prevent name collisions
-	hash := sha256.Sum256([]byte(r.config.Engine.StaticDir))
-	netnsName := fmt.Sprintf("%s-%x", rootlessNetNsName, hash[:10])
+	netnsName := r.rootlessNetNsName()
 
 	path := filepath.Join(nsDir, netnsName)
 	ns, err := ns.GetNS(path)
@@ -561,10 +585,16 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		}
 	}()
 	if ctr.config.NetMode.IsSlirp4netns() {
-		return nil, r.setupSlirp4netns(ctr, ctrNS)
+		if err := r.setupSlirp4netns(ctr, ctrNS); err != nil {
+			return nil, err
+		}
+		return nil, ctr.configureNetworkShaping(ctrNS)
 	}
 	if ctr.config.NetMode.IsPasta() {
-		return nil, r.setupPasta(ctr, ctrNS)
+		if err := r.setupPasta(ctr, ctrNS); err != nil {
+			return nil, err
+		}
+		return nil, ctr.configureNetworkShaping(ctrNS)
 	}
 	networks, err := ctr.networks()
 	if err != nil {
@@ -591,9 +621,17 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		// make sure to fix this in container.handleRestartPolicy() as well
 		// Important we have to call this after r.setUpNetwork() so that
 		// we can use the proper netStatus
-		err = r.setupRootlessPortMappingViaRLK(ctr, ctrNS, netStatus)
+		if err := r.setupRootlessPortMappingViaRLK(ctr, ctrNS, netStatus); err != nil {
+			return nil, err
+		}
+	}
+	if err := ctr.configureNetworkShaping(ctrNS); err != nil {
+		return netStatus, err
+	}
+	if err := ctr.configureServicePorts(ctrNS); err != nil {
+		return netStatus, err
 	}
-	return netStatus, err
+	return netStatus, ctr.configureNetworkPolicy(ctrNS)
 }
 
 // Create and configure a new network namespace for a container
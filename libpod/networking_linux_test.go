@@ -527,3 +527,163 @@ func Benchmark_ocicniPortsToNetTypesPorts1m(b *testing.B) {
 	b.ResetTimer()
 	benchmarkOCICNIPortsToNetTypesPorts(b, ports)
 }
+
+func Test_removePortMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []types.PortMapping
+		port     types.PortMapping
+		want     []types.PortMapping
+		wantErr  bool
+	}{
+		{
+			name:     "single port",
+			mappings: []types.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 1}},
+			port:     types.PortMapping{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+			want:     []types.PortMapping{},
+		},
+		{
+			name: "any host port",
+			mappings: []types.PortMapping{
+				{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 1},
+				{HostPort: 8081, ContainerPort: 80, Protocol: "tcp", Range: 1, HostIP: "127.0.0.1"},
+				{HostPort: 8082, ContainerPort: 80, Protocol: "udp", Range: 1},
+			},
+			port: types.PortMapping{ContainerPort: 80, Protocol: "tcp"},
+			want: []types.PortMapping{{HostPort: 8082, ContainerPort: 80, Protocol: "udp", Range: 1}},
+		},
+		{
+			name:     "split range",
+			mappings: []types.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 5}},
+			port:     types.PortMapping{HostPort: 8082, ContainerPort: 82, Protocol: "tcp"},
+			want: []types.PortMapping{
+				{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 2},
+				{HostPort: 8083, ContainerPort: 83, Protocol: "tcp", Range: 2},
+			},
+		},
+		{
+			name:     "other host port",
+			mappings: []types.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 1}},
+			port:     types.PortMapping{HostPort: 8081, ContainerPort: 80, Protocol: "tcp"},
+			wantErr:  true,
+		},
+		{
+			name:     "other protocol",
+			mappings: []types.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 1}},
+			port:     types.PortMapping{ContainerPort: 80, Protocol: "udp"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := removePortMapping(tt.mappings, tt.port)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, define.ErrInvalidArg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_checkPortMappingConflict(t *testing.T) {
+	mappings := []types.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 3},
+		{HostPort: 9000, ContainerPort: 90, Protocol: "udp", Range: 1, HostIP: "127.0.0.1"},
+	}
+	tests := []struct {
+		name     string
+		port     types.PortMapping
+		conflict bool
+	}{
+		{name: "inside range", port: types.PortMapping{HostPort: 8082, ContainerPort: 82, Protocol: "tcp"}, conflict: true},
+		{name: "overlapping range", port: types.PortMapping{HostPort: 8078, ContainerPort: 78, Protocol: "tcp", Range: 3}, conflict: true},
+		{name: "after range", port: types.PortMapping{HostPort: 8083, ContainerPort: 83, Protocol: "tcp"}},
+		{name: "other protocol", port: types.PortMapping{HostPort: 8080, ContainerPort: 80, Protocol: "udp"}},
+		{name: "same host ip", port: types.PortMapping{HostPort: 9000, ContainerPort: 91, Protocol: "udp", HostIP: "127.0.0.1"}, conflict: true},
+		{name: "other host ip", port: types.PortMapping{HostPort: 9000, ContainerPort: 91, Protocol: "udp", HostIP: "127.0.0.2"}},
+		{name: "all host ips", port: types.PortMapping{HostPort: 9000, ContainerPort: 91, Protocol: "udp"}, conflict: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPortMappingConflict(mappings, tt.port)
+			if tt.conflict {
+				assert.ErrorIs(t, err, define.ErrInvalidArg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_networkPolicyRules(t *testing.T) {
+	pods := []networkPolicyPod{
+		{labels: map[string]string{"role": "frontend"}, ips: []net.IP{net.ParseIP("10.88.0.2"), net.ParseIP("fd00::2")}},
+		{labels: map[string]string{"role": "db"}, ips: []net.IP{net.ParseIP("10.88.0.3")}},
+	}
+	policy := &define.NetworkPolicy{
+		IsolateIngress: true,
+		IsolateEgress:  true,
+		Ingress: []define.NetworkPolicyRule{{
+			Peers: []define.NetworkPolicyPeer{
+				{PodSelector: &define.LabelSelector{MatchLabels: map[string]string{"role": "frontend"}}},
+				{CIDR: "192.168.0.0/16", Except: []string{"192.168.1.0/24"}},
+			},
+			Ports: []define.NetworkPolicyPort{{Protocol: "tcp", Port: 80, EndPort: 90}, {Protocol: "udp"}},
+		}},
+	}
+
+	assert.Equal(t, `*filter
+:INPUT ACCEPT [0:0]
+:FORWARD ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:NETPOL-INGRESS - [0:0]
+:NETPOL-INGRESS-0 - [0:0]
+:NETPOL-INGRESS-0-1 - [0:0]
+-A INPUT -j NETPOL-INGRESS
+-A NETPOL-INGRESS -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT
+-A NETPOL-INGRESS -i lo -j ACCEPT
+-A NETPOL-INGRESS-0 -p tcp --dport 80:90 -j ACCEPT
+-A NETPOL-INGRESS-0 -p udp -j ACCEPT
+-A NETPOL-INGRESS -s 10.88.0.2 -j NETPOL-INGRESS-0
+-A NETPOL-INGRESS-0-1 -s 192.168.1.0/24 -j RETURN
+-A NETPOL-INGRESS-0-1 -j NETPOL-INGRESS-0
+-A NETPOL-INGRESS -s 192.168.0.0/16 -j NETPOL-INGRESS-0-1
+-A NETPOL-INGRESS -j DROP
+:NETPOL-EGRESS - [0:0]
+-A OUTPUT -j NETPOL-EGRESS
+-A NETPOL-EGRESS -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT
+-A NETPOL-EGRESS -o lo -j ACCEPT
+-A NETPOL-EGRESS -j DROP
+COMMIT
+`, networkPolicyRules(policy, pods, false))
+
+	rules := networkPolicyRules(policy, pods, true)
+	assert.Contains(t, rules, "-A NETPOL-INGRESS -s fd00::2 -j NETPOL-INGRESS-0\n")
+	assert.Contains(t, rules, "-A NETPOL-INGRESS -p ipv6-icmp --icmpv6-type neighbour-solicitation -j ACCEPT\n")
+	assert.NotContains(t, rules, "10.88.0.2")
+	assert.NotContains(t, rules, "192.168.0.0/16")
+
+	// a pod which is not isolated in any direction gets an empty table
+	assert.Equal(t, "*filter\n:INPUT ACCEPT [0:0]\n:FORWARD ACCEPT [0:0]\n:OUTPUT ACCEPT [0:0]\nCOMMIT\n",
+		networkPolicyRules(&define.NetworkPolicy{}, pods, false))
+}
+
+func Test_servicePortRules(t *testing.T) {
+	ports := []define.ServicePort{
+		{Service: "web", Protocol: "tcp", Port: 80, TargetPort: 8080},
+		{Service: "dns", Protocol: "udp", Port: 53, TargetPort: 5353},
+	}
+	assert.Equal(t, `*nat
+:PREROUTING ACCEPT [0:0]
+:INPUT ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:POSTROUTING ACCEPT [0:0]
+-A PREROUTING -p tcp --dport 80 -j REDIRECT --to-ports 8080
+-A PREROUTING -p udp --dport 53 -j REDIRECT --to-ports 5353
+COMMIT
+`, servicePortRules(ports))
+}
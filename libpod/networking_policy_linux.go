@@ -0,0 +1,250 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	networkPolicyIngressChain = "NETPOL-INGRESS"
+	networkPolicyEgressChain  = "NETPOL-EGRESS"
+)
+
+// networkPolicyPod holds the labels and addresses of a pod which may be
+// selected as peer by a network policy.
+type networkPolicyPod struct {
+	labels map[string]string
+	ips    []net.IP
+}
+
+// configureNetworkPolicy applies the network policy of the pod of the
+// container to the network namespace at nsPath. It does nothing if the
+// container is not the infra container of a pod with a network policy.
+func (c *Container) configureNetworkPolicy(nsPath string) error {
+	if !c.IsInfra() || c.config.Pod == "" {
+		return nil
+	}
+	pod, err := c.runtime.state.Pod(c.config.Pod)
+	if err != nil {
+		return err
+	}
+	if pod.config.NetworkPolicy == nil {
+		return nil
+	}
+	return c.setupNetworkPolicy(nsPath, pod.config.NetworkPolicy)
+}
+
+// setupNetworkPolicy replaces the firewall rules in the network namespace at
+// nsPath with the rules of the network policy. The rules are applied inside
+// the namespace, so they only affect the traffic of the pod and are removed
+// together with the namespace. Peers selected by pod labels are resolved to
+// the addresses the pods have right now.
+func (c *Container) setupNetworkPolicy(nsPath string, policy *define.NetworkPolicy) error {
+	pods, err := c.runtime.networkPolicyPods()
+	if err != nil {
+		return err
+	}
+
+	err = c.restoreIptablesRules(nsPath, "enforce network policies", func(ipv6 bool) string {
+		return networkPolicyRules(policy, pods, ipv6)
+	})
+	if err != nil {
+		return fmt.Errorf("applying network policy of pod %s: %w", c.config.Pod, err)
+	}
+	logrus.Debugf("Applied network policy %v to pod %s", policy.Names, c.config.Pod)
+	return nil
+}
+
+// restoreIptablesRules runs iptables-restore, and ip6tables-restore if IPv6 is
+// enabled, in the network namespace at nsPath with the rules returned by
+// rules. purpose describes what the rules are needed for in errors.
+func (c *Container) restoreIptablesRules(nsPath, purpose string, rules func(ipv6 bool) string) error {
+	var env []string
+	if rootless.IsRootless() {
+		// the default lock file in /run is not writable for rootless users
+		env = append(os.Environ(), "XTABLES_LOCKFILE="+filepath.Join(c.runtime.config.Engine.TmpDir, "xtables.lock"))
+	}
+	commands := []string{"iptables-restore"}
+	if _, err := os.Stat("/proc/net/if_inet6"); err == nil {
+		commands = append(commands, "ip6tables-restore")
+	}
+	return ns.WithNetNSPath(nsPath, func(_ ns.NetNS) error {
+		for _, command := range commands {
+			// The command is started from the thread locked to the
+			// namespace, so it runs inside of it as well.
+			cmd := exec.Command(command)
+			cmd.Stdin = strings.NewReader(rules(command == "ip6tables-restore"))
+			cmd.Env = env
+			if out, err := cmd.CombinedOutput(); err != nil {
+				if errors.Is(err, exec.ErrNotFound) {
+					return fmt.Errorf("%s is required to %s: %w", command, purpose, err)
+				}
+				return fmt.Errorf("running %s: %w: %s", command, err, strings.TrimSpace(string(out)))
+			}
+		}
+		return nil
+	})
+}
+
+// networkPolicyPods returns the labels and addresses of all pods with an
+// infra container. The state is read without taking the locks of the pods
+// and containers, since this is called with the lock of an infra container
+// held and locking another pod could deadlock with that pod being started at
+// the same time. The addresses are a snapshot, Pod.RefreshNetworkPolicy
+// updates the rules when pods were added or removed.
+func (r *Runtime) networkPolicyPods() ([]networkPolicyPod, error) {
+	allPods, err := r.state.AllPods()
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]networkPolicyPod, 0, len(allPods))
+	for _, pod := range allPods {
+		if err := r.state.UpdatePod(pod); err != nil {
+			logrus.Debugf("Reading state of pod %s for network policy: %v", pod.ID(), err)
+			continue
+		}
+		if pod.state.InfraContainerID == "" {
+			continue
+		}
+		infra, err := r.state.Container(pod.state.InfraContainerID)
+		if err == nil {
+			err = r.state.UpdateContainer(infra)
+		}
+		if err != nil {
+			logrus.Debugf("Reading state of infra container of pod %s for network policy: %v", pod.ID(), err)
+			continue
+		}
+		var ips []net.IP
+		for _, status := range infra.getNetworkStatus() {
+			for _, netInt := range status.Interfaces {
+				for _, subnet := range netInt.Subnets {
+					ips = append(ips, subnet.IPNet.IP)
+				}
+			}
+		}
+		pods = append(pods, networkPolicyPod{labels: pod.config.Labels, ips: ips})
+	}
+	return pods, nil
+}
+
+// networkPolicyRules returns the input for iptables-restore, or
+// ip6tables-restore if ipv6 is set, which replaces the filter table with the
+// rules of the policy. Traffic which is not allowed by any rule is dropped if
+// the policy isolates the pod in that direction, replies to allowed traffic
+// and traffic on the loopback interface are always allowed.
+func networkPolicyRules(policy *define.NetworkPolicy, pods []networkPolicyPod, ipv6 bool) string {
+	var b strings.Builder
+	b.WriteString("*filter\n:INPUT ACCEPT [0:0]\n:FORWARD ACCEPT [0:0]\n:OUTPUT ACCEPT [0:0]\n")
+	if policy.IsolateIngress {
+		writeNetworkPolicyChain(&b, networkPolicyIngressChain, "INPUT", "-i", "-s", policy.Ingress, pods, ipv6)
+	}
+	if policy.IsolateEgress {
+		writeNetworkPolicyChain(&b, networkPolicyEgressChain, "OUTPUT", "-o", "-d", policy.Egress, pods, ipv6)
+	}
+	b.WriteString("COMMIT\n")
+	return b.String()
+}
+
+// writeNetworkPolicyChain writes the chain for one direction. ifaceFlag and
+// peerFlag are the iptables options matching the interface and the address
+// of the peer in that direction.
+func writeNetworkPolicyChain(b *strings.Builder, chain, parent, ifaceFlag, peerFlag string, rules []define.NetworkPolicyRule, pods []networkPolicyPod, ipv6 bool) {
+	var chains, entries []string
+	chains = append(chains, chain)
+	entries = append(entries,
+		fmt.Sprintf("-A %s -j %s", parent, chain),
+		fmt.Sprintf("-A %s -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT", chain),
+		fmt.Sprintf("-A %s %s lo -j ACCEPT", chain, ifaceFlag),
+	)
+	if ipv6 {
+		// neighbor discovery must not be blocked for IPv6 to work at all
+		for _, icmpType := range []string{"router-solicitation", "router-advertisement", "neighbour-solicitation", "neighbour-advertisement"} {
+			entries = append(entries, fmt.Sprintf("-A %s -p ipv6-icmp --icmpv6-type %s -j ACCEPT", chain, icmpType))
+		}
+	}
+
+	for i, rule := range rules {
+		ruleChain := fmt.Sprintf("%s-%d", chain, i)
+		chains = append(chains, ruleChain)
+		if len(rule.Ports) == 0 {
+			entries = append(entries, fmt.Sprintf("-A %s -j ACCEPT", ruleChain))
+		}
+		for _, port := range rule.Ports {
+			entry := fmt.Sprintf("-A %s -p %s", ruleChain, port.Protocol)
+			if port.Port != 0 {
+				entry += fmt.Sprintf(" --dport %d", port.Port)
+				if port.EndPort > port.Port {
+					entry += fmt.Sprintf(":%d", port.EndPort)
+				}
+			}
+			entries = append(entries, entry+" -j ACCEPT")
+		}
+
+		if len(rule.Peers) == 0 {
+			entries = append(entries, fmt.Sprintf("-A %s -j %s", chain, ruleChain))
+			continue
+		}
+		for j, peer := range rule.Peers {
+			target := ruleChain
+			var addresses []string
+			if peer.PodSelector != nil {
+				for _, pod := range pods {
+					if !peer.PodSelector.Matches(pod.labels) {
+						continue
+					}
+					for _, ip := range pod.ips {
+						if (ip.To4() == nil) == ipv6 {
+							addresses = append(addresses, ip.String())
+						}
+					}
+				}
+			} else if isIPv6CIDR(peer.CIDR) == ipv6 {
+				addresses = append(addresses, peer.CIDR)
+				var excepts []string
+				for _, except := range peer.Except {
+					if isIPv6CIDR(except) == ipv6 {
+						excepts = append(excepts, except)
+					}
+				}
+				if len(excepts) > 0 {
+					// excluded addresses skip the rule
+					target = fmt.Sprintf("%s-%d", ruleChain, j)
+					chains = append(chains, target)
+					for _, except := range excepts {
+						entries = append(entries, fmt.Sprintf("-A %s %s %s -j RETURN", target, peerFlag, except))
+					}
+					entries = append(entries, fmt.Sprintf("-A %s -j %s", target, ruleChain))
+				}
+			}
+			for _, address := range addresses {
+				entries = append(entries, fmt.Sprintf("-A %s %s %s -j %s", chain, peerFlag, address, target))
+			}
+		}
+	}
+	entries = append(entries, fmt.Sprintf("-A %s -j DROP", chain))
+
+	for _, name := range chains {
+		fmt.Fprintf(b, ":%s - [0:0]\n", name)
+	}
+	for _, entry := range entries {
+		b.WriteString(entry)
+		b.WriteByte('\n')
+	}
+}
+
+func isIPv6CIDR(cidr string) bool {
+	return strings.Contains(cidr, ":")
+}
@@ -0,0 +1,309 @@
+//go:build linux || freebsd
+// +build linux freebsd
+
+package libpod
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/sirupsen/logrus"
+)
+
+// AddPortMappings publishes the given ports of the container. The ports must
+// not overlap with ports that are already published. If the container is
+// running, its port forwarding is updated right away.
+func (c *Container) AddPortMappings(ports []types.PortMapping) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if err := c.checkPortMappingsUpdatable(); err != nil {
+		return err
+	}
+
+	newPorts := make([]types.PortMapping, 0, len(c.config.PortMappings)+len(ports))
+	newPorts = append(newPorts, c.config.PortMappings...)
+	for _, port := range ports {
+		if err := checkPortMappingConflict(newPorts, port); err != nil {
+			return err
+		}
+		newPorts = append(newPorts, port)
+	}
+
+	if err := c.updatePortMappings(newPorts, ports, nil); err != nil {
+		return err
+	}
+	c.newPortEvent(events.PortAdd, ports)
+	return nil
+}
+
+// RemovePortMappings unpublishes the given ports of the container. A port
+// without host port matches all host ports the container port is published
+// on. If the container is running, its port forwarding is updated right away.
+func (c *Container) RemovePortMappings(ports []types.PortMapping) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if err := c.checkPortMappingsUpdatable(); err != nil {
+		return err
+	}
+
+	newPorts := c.config.PortMappings
+	for _, port := range ports {
+		var err error
+		newPorts, err = removePortMapping(newPorts, port)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.updatePortMappings(newPorts, nil, ports); err != nil {
+		return err
+	}
+	c.newPortEvent(events.PortRemove, ports)
+	return nil
+}
+
+// checkPortMappingsUpdatable returns an error if the published ports of the
+// container cannot be changed because of its network mode.
+func (c *Container) checkPortMappingsUpdatable() error {
+	if c.config.NetNsCtr != "" {
+		return fmt.Errorf("container %s shares the network namespace of container %s, ports must be published there: %w", c.ID(), c.config.NetNsCtr, define.ErrNetworkModeInvalid)
+	}
+	netMode := c.config.NetMode
+	if !netMode.IsBridge() && !netMode.IsSlirp4netns() && !netMode.IsPasta() {
+		return fmt.Errorf("ports can only be published with bridge, slirp4netns or pasta networking, container %s uses %q: %w", c.ID(), netMode, define.ErrNetworkModeInvalid)
+	}
+	return nil
+}
+
+// updatePortMappings replaces the port mappings of the container with
+// newPorts and rewrites its config. The added and removed ports are used to
+// update the port forwarding of podman machine.
+// The container must be locked.
+func (c *Container) updatePortMappings(newPorts, added, removed []types.PortMapping) error {
+	oldPorts := c.config.PortMappings
+	c.config.PortMappings = newPorts
+
+	if c.state.NetNS != "" {
+		if err := c.reloadPortMappings(oldPorts); err != nil {
+			c.config.PortMappings = oldPorts
+			return err
+		}
+		if err := c.runtime.exposeMachinePorts(added); err != nil {
+			logrus.Errorf("Failed to expose gvproxy machine ports: %v", err)
+		}
+		if err := c.runtime.unexposeMachinePorts(removed); err != nil {
+			logrus.Errorf("Failed to free gvproxy machine ports: %v", err)
+		}
+	}
+
+	// SafeRewriteContainerConfig must be used with care. Make sure to not change config fields by accident.
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+		c.config.PortMappings = oldPorts
+		return fmt.Errorf("rewriting the config of container %s: %w", c.ID(), err)
+	}
+	return c.save()
+}
+
+// reloadPortMappings updates the port forwarding of the container with a
+// network namespace from oldPorts to the ports of its config.
+func (c *Container) reloadPortMappings(oldPorts []types.PortMapping) error {
+	if c.config.NetMode.IsPasta() {
+		return fmt.Errorf("published ports of container %s cannot be changed while it is running with pasta networking: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+	// Rootless, the ports are forwarded by the rootlessport or slirp4netns
+	// process straight to the container. It is only started when the
+	// container has published ports at start, it cannot be started for a
+	// running container.
+	if c.config.NetMode.IsSlirp4netns() || rootless.IsRootless() {
+		if !c.hasRootlessPortForwarder() {
+			return fmt.Errorf("container %s has no running port forwarder, restart it to publish ports: %w", c.ID(), define.ErrCtrStateInvalid)
+		}
+		return c.reloadRootlessPortForwarder()
+	}
+	if c.config.NetMode.IsBridge() {
+		return c.reloadNetworkPortMappings(oldPorts)
+	}
+	return nil
+}
+
+// reloadNetworkPortMappings replaces the port forwarding rules the network
+// backend created for oldPorts with rules for the ports of the container
+// config. The backends only set up the port forwarding together with the
+// networks of the container, so like network reload, the networks are set up
+// again with the interface names, MAC and IP addresses they have right now.
+func (c *Container) reloadNetworkPortMappings(oldPorts []types.PortMapping) error {
+	networks, err := c.currentNetworkOptions()
+	if err != nil {
+		return err
+	}
+	if len(networks) == 0 {
+		return nil
+	}
+	opts := c.getNetworkOptions(networks)
+	opts.Networks = networks
+
+	opts.PortMappings = convertPortMappings(oldPorts)
+	if err := c.runtime.teardownNetworkBackend(c.state.NetNS, opts); err != nil {
+		logrus.Errorf("Tearing down network of container %s to update ports: %v", c.ID(), err)
+	}
+
+	opts.PortMappings = c.convertPortMappings()
+	status, err := c.runtime.setUpNetwork(c.state.NetNS, opts)
+	if err != nil {
+		// restore the previous port forwarding
+		opts.PortMappings = convertPortMappings(oldPorts)
+		if oldStatus, rerr := c.runtime.setUpNetwork(c.state.NetNS, opts); rerr == nil {
+			c.state.NetworkStatus = oldStatus
+		} else {
+			logrus.Errorf("Restoring network of container %s: %v", c.ID(), rerr)
+		}
+		return err
+	}
+	c.state.NetworkStatus = status
+	// the interfaces were created again, so their traffic control is gone
+	return c.configureNetworkShaping(c.state.NetNS)
+}
+
+// newPortEvent creates a new network event for ports published or
+// unpublished by a container
+func (c *Container) newPortEvent(status events.Status, ports []types.PortMapping) {
+	e := events.NewEvent(status)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Type = events.Network
+	e.Details = events.Details{
+		ID:         e.ID,
+		Attributes: map[string]string{"ports": formatPortMappings(ports)},
+	}
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write port event: %q", err)
+	}
+}
+
+// formatPortMappings returns the ports in the host-ip:host-port->container-port/protocol
+// format also used by podman ps. Ports without host port are formatted as
+// container-port/protocol.
+func formatPortMappings(ports []types.PortMapping) string {
+	formatted := make([]string, 0, len(ports))
+	for _, port := range ports {
+		if port.HostPort == 0 {
+			if portRange(port) > 1 {
+				formatted = append(formatted, fmt.Sprintf("%d-%d/%s", port.ContainerPort, int(port.ContainerPort)+portRange(port)-1, port.Protocol))
+				continue
+			}
+			formatted = append(formatted, fmt.Sprintf("%d/%s", port.ContainerPort, port.Protocol))
+			continue
+		}
+		hostIP := port.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		if portRange(port) > 1 {
+			formatted = append(formatted, fmt.Sprintf("%s:%d-%d->%d-%d/%s", hostIP, port.HostPort, int(port.HostPort)+portRange(port)-1,
+				port.ContainerPort, int(port.ContainerPort)+portRange(port)-1, port.Protocol))
+			continue
+		}
+		formatted = append(formatted, fmt.Sprintf("%s:%d->%d/%s", hostIP, port.HostPort, port.ContainerPort, port.Protocol))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// portRange returns the number of ports in the mapping.
+func portRange(port types.PortMapping) int {
+	if port.Range == 0 {
+		return 1
+	}
+	return int(port.Range)
+}
+
+// portProtocols returns the protocols of the mapping, tcp if none is set.
+func portProtocols(port types.PortMapping) []string {
+	if port.Protocol == "" {
+		return []string{"tcp"}
+	}
+	return strings.Split(port.Protocol, ",")
+}
+
+// checkPortMappingConflict returns an error if the host ports of port are
+// already used by one of the mappings.
+func checkPortMappingConflict(mappings []types.PortMapping, port types.PortMapping) error {
+	for _, m := range mappings {
+		if m.HostIP != "" && port.HostIP != "" && m.HostIP != port.HostIP {
+			continue
+		}
+		if int(m.HostPort) >= int(port.HostPort)+portRange(port) || int(port.HostPort) >= int(m.HostPort)+portRange(m) {
+			continue
+		}
+		for _, protocol := range portProtocols(port) {
+			for _, existing := range portProtocols(m) {
+				if protocol == existing {
+					return fmt.Errorf("host port %d/%s is already published: %w", port.HostPort, protocol, define.ErrInvalidArg)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// removePortMapping removes the ports of port from the mappings, splitting up
+// port ranges where needed.
+func removePortMapping(mappings []types.PortMapping, port types.PortMapping) ([]types.PortMapping, error) {
+	for _, protocol := range portProtocols(port) {
+		for i := 0; i < portRange(port); i++ {
+			containerPort := int(port.ContainerPort) + i
+			hostPort := 0
+			if port.HostPort != 0 {
+				hostPort = int(port.HostPort) + i
+			}
+			var found bool
+			mappings, found = removeSinglePort(mappings, port.HostIP, protocol, hostPort, containerPort)
+			if !found {
+				return nil, fmt.Errorf("port %d/%s is not published: %w", containerPort, protocol, define.ErrInvalidArg)
+			}
+		}
+	}
+	return mappings, nil
+}
+
+// removeSinglePort removes a single container port from the mappings. An
+// empty hostIP or a hostPort of 0 match any host IP or port.
+func removeSinglePort(mappings []types.PortMapping, hostIP, protocol string, hostPort, containerPort int) ([]types.PortMapping, bool) {
+	found := false
+	result := make([]types.PortMapping, 0, len(mappings)+1)
+	for _, m := range mappings {
+		offset := containerPort - int(m.ContainerPort)
+		if m.Protocol != protocol || (hostIP != "" && m.HostIP != hostIP) ||
+			offset < 0 || offset >= portRange(m) ||
+			(hostPort != 0 && int(m.HostPort)+offset != hostPort) {
+			result = append(result, m)
+			continue
+		}
+		found = true
+		// keep the ports of the range before and after the removed port
+		if offset > 0 {
+			before := m
+			before.Range = uint16(offset)
+			result = append(result, before)
+		}
+		if offset+1 < portRange(m) {
+			after := m
+			after.HostPort += uint16(offset + 1)
+			after.ContainerPort += uint16(offset + 1)
+			after.Range = uint16(portRange(m) - offset - 1)
+			result = append(result, after)
+		}
+	}
+	return result, found
+}
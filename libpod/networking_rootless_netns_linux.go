@@ -0,0 +1,233 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/common/pkg/netns"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// rootlessNetNSPath returns the path of the rootless netns. It does not check
+// if the netns exists.
+func (r *Runtime) rootlessNetNSPath() (string, error) {
+	if !rootless.IsRootless() {
+		return "", fmt.Errorf("the rootless network namespace is only used by rootless podman: %w", define.ErrInvalidArg)
+	}
+	nsDir, err := netns.GetNSRunDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(nsDir, r.rootlessNetNsName()), nil
+}
+
+// rootlessNetNSExists returns the path of the rootless netns and whether it
+// was created.
+func (r *Runtime) rootlessNetNSExists() (string, bool, error) {
+	path, err := r.rootlessNetNSPath()
+	if err != nil {
+		return "", false, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return path, false, nil
+		}
+		return "", false, err
+	}
+	return path, true, nil
+}
+
+// RootlessNetNSInspect returns the interfaces, routes, helper process and
+// attached containers of the rootless network namespace. The namespace is not
+// created if it does not exist.
+func (r *Runtime) RootlessNetNSInspect() (*define.RootlessNetNSInfo, error) {
+	path, exists, err := r.rootlessNetNSExists()
+	if err != nil {
+		return nil, err
+	}
+	info := &define.RootlessNetNSInfo{
+		Path:       path,
+		Interfaces: []define.RootlessNetNSInterface{},
+		Routes:     []define.RootlessNetNSRoute{},
+		Containers: []define.RootlessNetNSContainer{},
+	}
+	if !exists {
+		return info, nil
+	}
+
+	rootlessNetNS, err := r.GetRootlessNetNs(false)
+	if err != nil {
+		return nil, err
+	}
+	info.Exists = true
+	if pid, err := rootlessNetNS.slirp4netnsPid(); err == nil {
+		info.Helper = &define.RootlessNetNSHelper{
+			Name:    "slirp4netns",
+			PID:     pid,
+			Running: unix.Kill(pid, 0) == nil,
+		}
+	}
+	err = rootlessNetNS.ns.Do(func(_ ns.NetNS) error {
+		var err error
+		info.Interfaces, info.Routes, err = rootlessNetNSLinks()
+		return err
+	})
+	// Unlock before looking at the containers, network setup takes the
+	// container lock first and the rootless netns lock second.
+	rootlessNetNS.Lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("reading interfaces of rootless network namespace: %w", err)
+	}
+
+	ctrs, err := r.GetContainers(false, func(c *Container) bool {
+		return c.config.NetMode.IsBridge()
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range ctrs {
+		c.lock.Lock()
+		err := c.syncContainer()
+		netNS := c.state.NetNS
+		c.lock.Unlock()
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return nil, err
+		}
+		if netNS == "" {
+			continue
+		}
+		networks, err := c.networks()
+		if err != nil {
+			return nil, err
+		}
+		ctr := define.RootlessNetNSContainer{
+			ID:       c.ID(),
+			Name:     c.Name(),
+			Networks: make([]string, 0, len(networks)),
+			Ports:    c.config.PortMappings,
+		}
+		if c.IsInfra() {
+			ctr.Pod = c.PodID()
+		}
+		for name := range networks {
+			ctr.Networks = append(ctr.Networks, name)
+		}
+		sort.Strings(ctr.Networks)
+		info.Containers = append(info.Containers, ctr)
+	}
+	return info, nil
+}
+
+// rootlessNetNSLinks returns the interfaces and the routes of the main
+// routing table of the current network namespace.
+func rootlessNetNSLinks() ([]define.RootlessNetNSInterface, []define.RootlessNetNSRoute, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make(map[int]string, len(links))
+	interfaces := make([]define.RootlessNetNSInterface, 0, len(links))
+	for _, link := range links {
+		attrs := link.Attrs()
+		names[attrs.Index] = attrs.Name
+		iface := define.RootlessNetNSInterface{
+			Name:  attrs.Name,
+			Type:  link.Type(),
+			MTU:   attrs.MTU,
+			State: attrs.OperState.String(),
+		}
+		if len(attrs.HardwareAddr) > 0 {
+			iface.MacAddress = attrs.HardwareAddr.String()
+		}
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, addr := range addrs {
+			iface.Addresses = append(iface.Addresses, addr.IPNet.String())
+		}
+		interfaces = append(interfaces, iface)
+	}
+
+	routeList, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, nil, err
+	}
+	routes := make([]define.RootlessNetNSRoute, 0, len(routeList))
+	for _, route := range routeList {
+		r := define.RootlessNetNSRoute{
+			Destination: "default",
+			Interface:   names[route.LinkIndex],
+		}
+		if route.Dst != nil {
+			r.Destination = route.Dst.String()
+		}
+		if route.Gw != nil {
+			r.Gateway = route.Gw.String()
+		}
+		routes = append(routes, r)
+	}
+	return interfaces, routes, nil
+}
+
+// CleanupRootlessNetNS removes the rootless network namespace and stops its
+// slirp4netns process. It fails with define.ErrNetworkInUse if containers are
+// still attached to the namespace. Nothing is done if it does not exist.
+func (r *Runtime) CleanupRootlessNetNS() error {
+	_, exists, err := r.rootlessNetNSExists()
+	if err != nil || !exists {
+		return err
+	}
+	rootlessNetNS, err := r.GetRootlessNetNs(false)
+	if err != nil {
+		return err
+	}
+	defer rootlessNetNS.Lock.Unlock()
+
+	ctrs, err := rootlessNetNS.activeContainers(r)
+	if err != nil {
+		return err
+	}
+	if len(ctrs) > 0 {
+		names := make([]string, 0, len(ctrs))
+		for _, c := range ctrs {
+			names = append(names, c.Name())
+		}
+		return fmt.Errorf("rootless network namespace is used by containers %s: %w", strings.Join(names, ", "), define.ErrNetworkInUse)
+	}
+	return rootlessNetNS.teardown()
+}
+
+// RootlessNetNSExec runs toRun in the rootless network namespace, with the
+// same mounts the network backends see. The namespace must exist, it is not
+// created, and it is not locked while toRun runs.
+func (r *Runtime) RootlessNetNSExec(toRun func() error) error {
+	_, exists, err := r.rootlessNetNSExists()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("rootless network namespace does not exist, it is created when the first rootless container with a bridge network is started: %w", define.ErrInvalidArg)
+	}
+	rootlessNetNS, err := r.GetRootlessNetNs(false)
+	if err != nil {
+		return err
+	}
+	rootlessNetNS.Lock.Unlock()
+	return rootlessNetNS.Do(toRun)
+}
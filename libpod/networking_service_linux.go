@@ -0,0 +1,51 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// configureServicePorts redirects the ports of the Kubernetes Services
+// selecting the pod of the container to their target ports in the network
+// namespace at nsPath. It does nothing if the container is not the infra
+// container of a pod with service ports.
+func (c *Container) configureServicePorts(nsPath string) error {
+	if !c.IsInfra() || c.config.Pod == "" {
+		return nil
+	}
+	pod, err := c.runtime.state.Pod(c.config.Pod)
+	if err != nil {
+		return err
+	}
+	if len(pod.config.ServicePorts) == 0 {
+		return nil
+	}
+
+	err = c.restoreIptablesRules(nsPath, "redirect service ports", func(bool) string {
+		return servicePortRules(pod.config.ServicePorts)
+	})
+	if err != nil {
+		return fmt.Errorf("redirecting service ports of pod %s: %w", c.config.Pod, err)
+	}
+	logrus.Debugf("Redirected service ports of pod %s", c.config.Pod)
+	return nil
+}
+
+// servicePortRules returns the input for iptables-restore which replaces the
+// nat table with rules redirecting incoming traffic on the service ports to
+// the target ports. The same rules work for IPv4 and IPv6.
+func servicePortRules(ports []define.ServicePort) string {
+	var b strings.Builder
+	b.WriteString("*nat\n:PREROUTING ACCEPT [0:0]\n:INPUT ACCEPT [0:0]\n:OUTPUT ACCEPT [0:0]\n:POSTROUTING ACCEPT [0:0]\n")
+	for _, port := range ports {
+		fmt.Fprintf(&b, "-A PREROUTING -p %s --dport %d -j REDIRECT --to-ports %d\n", port.Protocol, port.Port, port.TargetPort)
+	}
+	b.WriteString("COMMIT\n")
+	return b.String()
+}
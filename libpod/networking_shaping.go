@@ -0,0 +1,54 @@
+//go:build linux || freebsd
+// +build linux freebsd
+
+package libpod
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v4/libpod/define"
+)
+
+// UpdateNetworkShaping replaces the traffic control applied to the network
+// interfaces of the container. A nil or empty shaping removes it. If the
+// container is running, the change is applied right away.
+func (c *Container) UpdateNetworkShaping(shaping *define.NetworkShaping) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if !c.config.CreateNetNS {
+		return fmt.Errorf("container %s does not have a private network namespace, network shaping cannot be used: %w", c.ID(), define.ErrNetworkModeInvalid)
+	}
+	if shaping.IsZero() {
+		shaping = nil
+	}
+
+	oldShaping := c.config.NetworkShaping
+	c.config.NetworkShaping = shaping
+	if c.state.NetNS != "" {
+		if err := c.setupNetworkShaping(c.state.NetNS); err != nil {
+			c.config.NetworkShaping = oldShaping
+			return err
+		}
+	}
+
+	// SafeRewriteContainerConfig must be used with care. Make sure to not change config fields by accident.
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+		c.config.NetworkShaping = oldShaping
+		return fmt.Errorf("rewriting the config of container %s: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// configureNetworkShaping applies the traffic control configured for the
+// container to the interfaces in the network namespace at nsPath. It does
+// nothing if no traffic control is configured.
+func (c *Container) configureNetworkShaping(nsPath string) error {
+	if c.config.NetworkShaping.IsZero() {
+		return nil
+	}
+	return c.setupNetworkShaping(nsPath)
+}
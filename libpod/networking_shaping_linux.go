@@ -0,0 +1,176 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// shapingLatency is the maximum time a packet may wait in the egress
+	// rate limiter before it is dropped.
+	shapingLatency = 50000 // in us
+	// shapingBurstTime is the time of traffic at full rate that may be sent
+	// or received at once.
+	shapingBurstTime = 10000 // in us
+)
+
+var (
+	shapingRootHandle    = netlink.MakeHandle(1, 0)
+	shapingIngressHandle = netlink.MakeHandle(0xffff, 0)
+)
+
+// setupNetworkShaping applies the traffic control configured for the
+// container to all interfaces in the network namespace at nsPath. Traffic
+// control applied before is replaced, so this can be called again after the
+// interfaces or the configuration changed. If no traffic control is
+// configured, the interfaces are reset to the default queueing discipline.
+func (c *Container) setupNetworkShaping(nsPath string) error {
+	shaping := c.config.NetworkShaping
+	if shaping != nil && shaping.IngressRate/8 > math.MaxUint32 {
+		return fmt.Errorf("ingress rate limit of %d bit/s is too large: %w", shaping.IngressRate, define.ErrInvalidArg)
+	}
+	err := ns.WithNetNSPath(nsPath, func(_ ns.NetNS) error {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			if link.Attrs().Flags&net.FlagLoopback != 0 {
+				continue
+			}
+			if err := shapeLink(link, shaping); err != nil {
+				return fmt.Errorf("interface %s: %w", link.Attrs().Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting up network shaping for container %s: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// shapeLink replaces the traffic control of the link.
+func shapeLink(link netlink.Link, shaping *define.NetworkShaping) error {
+	if err := resetLinkShaping(link); err != nil {
+		return err
+	}
+	if shaping.IsZero() {
+		return nil
+	}
+
+	index := link.Attrs().Index
+	parent := uint32(netlink.HANDLE_ROOT)
+	handle := shapingRootHandle
+	if shaping.Delay > 0 || shaping.Loss > 0 {
+		netem := netlink.NewNetem(netlink.QdiscAttrs{
+			LinkIndex: index,
+			Parent:    parent,
+			Handle:    handle,
+		}, netlink.NetemQdiscAttrs{
+			Latency: uint32(shaping.Delay.Microseconds()),
+			Loss:    float32(shaping.Loss),
+		})
+		if err := netlink.QdiscAdd(netem); err != nil {
+			return fmt.Errorf("adding netem qdisc: %w", err)
+		}
+		// the rate limit is applied after the delay and loss
+		parent = netlink.MakeHandle(1, 1)
+		handle = netlink.MakeHandle(2, 0)
+	}
+
+	if shaping.EgressRate > 0 {
+		rate := shaping.EgressRate / 8
+		burst := shapingBurst(rate, link.Attrs().MTU)
+		tbf := &netlink.Tbf{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: index,
+				Parent:    parent,
+				Handle:    handle,
+			},
+			Rate:   rate,
+			Buffer: netlink.Xmittime(rate, burst),
+			Limit:  uint32(rate*shapingLatency/1000000) + burst,
+		}
+		if err := netlink.QdiscAdd(tbf); err != nil {
+			return fmt.Errorf("adding tbf qdisc: %w", err)
+		}
+	}
+
+	if shaping.IngressRate > 0 {
+		ingress := &netlink.Ingress{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: index,
+				Parent:    netlink.HANDLE_INGRESS,
+				Handle:    shapingIngressHandle,
+			},
+		}
+		if err := netlink.QdiscAdd(ingress); err != nil {
+			return fmt.Errorf("adding ingress qdisc: %w", err)
+		}
+		rate := shaping.IngressRate / 8
+		police := netlink.NewPoliceAction()
+		police.Rate = uint32(rate)
+		police.Burst = shapingBurst(rate, link.Attrs().MTU)
+		police.ExceedAction = netlink.TC_POLICE_SHOT
+		police.NotExceedAction = netlink.TC_POLICE_OK
+		// a u32 filter without selector matches all packets
+		filter := &netlink.U32{
+			FilterAttrs: netlink.FilterAttrs{
+				LinkIndex: index,
+				Parent:    shapingIngressHandle,
+				Priority:  1,
+				Protocol:  unix.ETH_P_ALL,
+			},
+			Actions: []netlink.Action{police},
+		}
+		if err := netlink.FilterAdd(filter); err != nil {
+			return fmt.Errorf("adding ingress police filter: %w", err)
+		}
+	}
+	return nil
+}
+
+// resetLinkShaping removes the root and ingress qdiscs added for shaping from
+// the link.
+func resetLinkShaping(link netlink.Link) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return fmt.Errorf("listing qdiscs: %w", err)
+	}
+	for _, qdisc := range qdiscs {
+		attrs := qdisc.Attrs()
+		if (attrs.Parent == netlink.HANDLE_ROOT && attrs.Handle == shapingRootHandle) ||
+			(attrs.Parent == netlink.HANDLE_INGRESS && attrs.Handle == shapingIngressHandle) {
+			if err := netlink.QdiscDel(qdisc); err != nil && !errors.Is(err, unix.ENOENT) {
+				return fmt.Errorf("removing %s qdisc: %w", qdisc.Type(), err)
+			}
+			logrus.Debugf("Removed %s qdisc from interface %s", qdisc.Type(), link.Attrs().Name)
+		}
+	}
+	return nil
+}
+
+// shapingBurst returns the burst size in bytes for the rate in bytes per
+// second. It is large enough to hold at least two packets of the given MTU.
+func shapingBurst(rate uint64, mtu int) uint32 {
+	burst := rate * shapingBurstTime / 1000000
+	if min := uint64(2 * mtu); burst < min {
+		burst = min
+	}
+	if burst > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(burst)
+}
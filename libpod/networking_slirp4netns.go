@@ -24,6 +24,7 @@ import (
 	"github.com/containers/podman/v4/pkg/rootless"
 	"github.com/containers/podman/v4/pkg/rootlessport"
 	"github.com/containers/podman/v4/pkg/servicereaper"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/sirupsen/logrus"
 )
 
@@ -232,11 +233,7 @@ func (r *Runtime) setupSlirp4netns(ctr *Container, netns string) error {
 	havePortMapping := len(ctr.config.PortMappings) > 0
 	logPath := filepath.Join(ctr.runtime.config.Engine.TmpDir, fmt.Sprintf("slirp4netns-%s.log", ctr.config.ID))
 
-	ctrNetworkSlipOpts := []string{}
-	if ctr.config.NetworkOptions != nil {
-		ctrNetworkSlipOpts = append(ctrNetworkSlipOpts, ctr.config.NetworkOptions["slirp4netns"]...)
-	}
-	netOptions, err := parseSlirp4netnsNetworkOptions(r, ctrNetworkSlipOpts)
+	netOptions, err := ctr.slirp4netnsOptions()
 	if err != nil {
 		return err
 	}
@@ -258,7 +255,7 @@ func (r *Runtime) setupSlirp4netns(ctr *Container, netns string) error {
 
 	var apiSocket string
 	if havePortMapping && netOptions.isSlirpHostForward {
-		apiSocket = filepath.Join(ctr.runtime.config.Engine.TmpDir, fmt.Sprintf("%s.net", ctr.config.ID))
+		apiSocket = ctr.slirp4netnsAPISocketPath()
 		cmdArgs = append(cmdArgs, "--api-socket", apiSocket)
 	}
 	netnsPath := ""
@@ -636,15 +633,6 @@ func (r *Runtime) setupRootlessPortMappingViaSlirp(ctr *Container, cmd *exec.Cmd
 
 // openSlirp4netnsPort sends the slirp4netns pai quey to the given socket
 func openSlirp4netnsPort(apiSocket, proto, hostip string, hostport, guestport uint16) error {
-	conn, err := net.Dial("unix", apiSocket)
-	if err != nil {
-		return fmt.Errorf("cannot open connection to %s: %w", apiSocket, err)
-	}
-	defer func() {
-		if err := conn.Close(); err != nil {
-			logrus.Errorf("Unable to close slirp4netns connection: %q", err)
-		}
-	}()
 	apiCmd := slirp4netnsCmd{
 		Execute: "add_hostfwd",
 		Args: slirp4netnsCmdArg{
@@ -654,9 +642,27 @@ func openSlirp4netnsPort(apiSocket, proto, hostip string, hostport, guestport ui
 			GuestPort: guestport,
 		},
 	}
+	if err := slirp4netnsAPICall(apiSocket, &apiCmd, nil); err != nil {
+		return fmt.Errorf("from slirp4netns while setting up port redirection: %w", err)
+	}
+	return nil
+}
+
+// slirp4netnsAPICall sends the command to the slirp4netns API socket and
+// decodes the returned value into result if it is not nil.
+func slirp4netnsAPICall(apiSocket string, apiCmd interface{}, result interface{}) error {
+	conn, err := net.Dial("unix", apiSocket)
+	if err != nil {
+		return fmt.Errorf("cannot open connection to %s: %w", apiSocket, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.Errorf("Unable to close slirp4netns connection: %q", err)
+		}
+	}()
 	// create the JSON payload and send it.  Mark the end of request shutting down writes
 	// to the socket, as requested by slirp4netns.
-	data, err := json.Marshal(&apiCmd)
+	data, err := json.Marshal(apiCmd)
 	if err != nil {
 		return fmt.Errorf("cannot marshal JSON for slirp4netns: %w", err)
 	}
@@ -666,19 +672,63 @@ func openSlirp4netnsPort(apiSocket, proto, hostip string, hostport, guestport ui
 	if err := conn.(*net.UnixConn).CloseWrite(); err != nil {
 		return fmt.Errorf("cannot shutdown the socket %s: %w", apiSocket, err)
 	}
-	buf := make([]byte, 2048)
-	readLength, err := conn.Read(buf)
+	buf, err := io.ReadAll(conn)
 	if err != nil {
 		return fmt.Errorf("cannot read from control socket %s: %w", apiSocket, err)
 	}
 	// if there is no 'error' key in the received JSON data, then the operation was
 	// successful.
-	var y map[string]interface{}
-	if err := json.Unmarshal(buf[0:readLength], &y); err != nil {
+	var y struct {
+		Return jsoniter.RawMessage `json:"return"`
+		Error  interface{}         `json:"error"`
+	}
+	if err := json.Unmarshal(buf, &y); err != nil {
 		return fmt.Errorf("parsing error status from slirp4netns: %w", err)
 	}
-	if e, found := y["error"]; found {
-		return fmt.Errorf("from slirp4netns while setting up port redirection: %v", e)
+	if y.Error != nil {
+		return fmt.Errorf("%v", y.Error)
+	}
+	if result != nil {
+		if err := json.Unmarshal(y.Return, result); err != nil {
+			return fmt.Errorf("parsing result from slirp4netns: %w", err)
+		}
+	}
+	return nil
+}
+
+// reloadSlirp4netnsPortMappings replaces the ports forwarded by slirp4netns
+// with the ports of the container config.
+func (c *Container) reloadSlirp4netnsPortMappings(apiSocket string) error {
+	var list struct {
+		Entries []struct {
+			ID int `json:"id"`
+		} `json:"entries"`
+	}
+	if err := slirp4netnsAPICall(apiSocket, map[string]string{"execute": "list_hostfwd"}, &list); err != nil {
+		return fmt.Errorf("from slirp4netns while listing port redirections: %w", err)
+	}
+	for _, entry := range list.Entries {
+		apiCmd := map[string]interface{}{
+			"execute":   "remove_hostfwd",
+			"arguments": map[string]int{"id": entry.ID},
+		}
+		if err := slirp4netnsAPICall(apiSocket, apiCmd, nil); err != nil {
+			return fmt.Errorf("from slirp4netns while removing port redirection: %w", err)
+		}
+	}
+	for _, port := range c.convertPortMappings() {
+		protocols := strings.Split(port.Protocol, ",")
+		for _, protocol := range protocols {
+			hostIP := port.HostIP
+			if hostIP == "" {
+				hostIP = "0.0.0.0"
+			}
+			for i := uint16(0); i < port.Range; i++ {
+				if err := openSlirp4netnsPort(apiSocket, protocol, hostIP, port.HostPort+i, port.ContainerPort+i); err != nil {
+					return err
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -718,14 +768,18 @@ func (c *Container) reloadRootlessRLKPortMapping() error {
 	}
 	childIP := getRootlessPortChildIP(c, c.state.NetworkStatus)
 	logrus.Debugf("reloading rootless ports for container %s, childIP is %s", c.config.ID, childIP)
+	return c.sendRootlessPortReload(childIP)
+}
 
-	conn, err := openUnixSocket(filepath.Join(c.runtime.config.Engine.TmpDir, "rp", c.config.ID))
+// sendRootlessPortReload sends the reload request to the rootlessport process of the container.
+func (c *Container) sendRootlessPortReload(request interface{}) error {
+	conn, err := openUnixSocket(c.rootlessPortSocketPath())
 	if err != nil {
 		return fmt.Errorf("could not reload rootless port mappings, port forwarding may no longer work correctly: %w", err)
 	}
 	defer conn.Close()
 	enc := json.NewEncoder(conn)
-	err = enc.Encode(childIP)
+	err = enc.Encode(request)
 	if err != nil {
 		return fmt.Errorf("port reloading failed: %w", err)
 	}
@@ -739,3 +793,66 @@ func (c *Container) reloadRootlessRLKPortMapping() error {
 	}
 	return nil
 }
+
+// rootlessPortSocketPath returns the path of the reload socket of the rootlessport process.
+func (c *Container) rootlessPortSocketPath() string {
+	return filepath.Join(c.runtime.config.Engine.TmpDir, "rp", c.config.ID)
+}
+
+// slirp4netnsAPISocketPath returns the path of the slirp4netns API socket.
+func (c *Container) slirp4netnsAPISocketPath() string {
+	return filepath.Join(c.runtime.config.Engine.TmpDir, fmt.Sprintf("%s.net", c.config.ID))
+}
+
+// slirp4netnsOptions returns the slirp4netns options of the container.
+func (c *Container) slirp4netnsOptions() (*slirp4netnsNetworkOptions, error) {
+	ctrNetworkSlipOpts := []string{}
+	if c.config.NetworkOptions != nil {
+		ctrNetworkSlipOpts = append(ctrNetworkSlipOpts, c.config.NetworkOptions["slirp4netns"]...)
+	}
+	return parseSlirp4netnsNetworkOptions(c.runtime, ctrNetworkSlipOpts)
+}
+
+// hasRootlessPortForwarder returns true if the process forwarding the ports
+// of the container, rootlessport or slirp4netns, can be reached.
+func (c *Container) hasRootlessPortForwarder() bool {
+	socket := c.rootlessPortSocketPath()
+	if c.config.NetMode.IsSlirp4netns() {
+		netOptions, err := c.slirp4netnsOptions()
+		if err != nil {
+			return false
+		}
+		if netOptions.isSlirpHostForward {
+			socket = c.slirp4netnsAPISocketPath()
+		}
+	}
+	_, err := os.Stat(socket)
+	return err == nil
+}
+
+// reloadRootlessPortForwarder replaces the ports forwarded by the rootlessport
+// or slirp4netns process of the container with the ports of its config.
+func (c *Container) reloadRootlessPortForwarder() error {
+	if c.config.NetMode.IsSlirp4netns() {
+		netOptions, err := c.slirp4netnsOptions()
+		if err != nil {
+			return err
+		}
+		if netOptions.isSlirpHostForward {
+			return c.reloadSlirp4netnsPortMappings(c.slirp4netnsAPISocketPath())
+		}
+		if netOptions.cidr != "" {
+			_, ipv4network, err := net.ParseCIDR(netOptions.cidr)
+			if err != nil {
+				return fmt.Errorf("invalid cidr %q", netOptions.cidr)
+			}
+			c.slirp4netnsSubnet = ipv4network
+		}
+	}
+	reload := rootlessport.Reload{
+		ChildIP:  getRootlessPortChildIP(c, c.state.NetworkStatus),
+		Mappings: c.convertPortMappings(),
+	}
+	logrus.Debugf("reloading rootless ports for container %s, ports are %v", c.config.ID, reload.Mappings)
+	return c.sendRootlessPortReload(reload)
+}
@@ -4,7 +4,9 @@
 package libpod
 
 import (
+	"context"
 	"errors"
+	"io"
 	"net"
 	"path/filepath"
 
@@ -52,6 +54,18 @@ func (r *Runtime) ConnectContainerToNetwork(nameOrID, netName string, netOpts ty
 	return errors.New("not implemented (*Runtime) ConnectContainerToNetwork")
 }
 
+func (c *Container) AddPortMappings(ports []types.PortMapping) error {
+	return errors.New("not implemented (*Container) AddPortMappings")
+}
+
+func (c *Container) RemovePortMappings(ports []types.PortMapping) error {
+	return errors.New("not implemented (*Container) RemovePortMappings")
+}
+
+func (c *Container) UpdateNetworkShaping(shaping *define.NetworkShaping) error {
+	return errors.New("not implemented (*Container) UpdateNetworkShaping")
+}
+
 // getPath will join the given path to the rootless netns dir
 func (r *RootlessNetNS) getPath(path string) string {
 	return filepath.Join(r.dir, path)
@@ -89,3 +103,23 @@ func (c *Container) convertPortMappings() []types.PortMapping {
 func GetSlirp4netnsIP(subnet *net.IPNet) (*net.IP, error) {
 	return nil, errors.New("not implemented GetSlirp4netnsIP")
 }
+
+func (c *Container) Capture(ctx context.Context, options ContainerCaptureOptions, w io.Writer) error {
+	return errors.New("not implemented (*Container) Capture")
+}
+
+func (c *Container) setupNetworkPolicy(nsPath string, policy *define.NetworkPolicy) error {
+	return errors.New("not implemented (*Container) setupNetworkPolicy")
+}
+
+func (r *Runtime) RootlessNetNSInspect() (*define.RootlessNetNSInfo, error) {
+	return nil, errors.New("not implemented (*Runtime) RootlessNetNSInspect")
+}
+
+func (r *Runtime) CleanupRootlessNetNS() error {
+	return errors.New("not implemented (*Runtime) CleanupRootlessNetNS")
+}
+
+func (r *Runtime) RootlessNetNSExec(toRun func() error) error {
+	return errors.New("not implemented (*Runtime) RootlessNetNSExec")
+}
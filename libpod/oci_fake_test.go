@@ -0,0 +1,416 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containers/common/pkg/cgroups"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/libpod/lock"
+	"github.com/containers/podman/v4/pkg/parallel"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/containers/storage"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore is a containers/storage store only keeping the directories of
+// containers, which is all libpod needs for containers using a rootfs. Real
+// stores chown their directories, which requires privileges. Calling any other
+// method panics.
+type fakeStore struct {
+	storage.Store
+	root       string
+	containers map[string]*storage.Container
+}
+
+func newFakeStore(root string) *fakeStore {
+	return &fakeStore{root: root, containers: make(map[string]*storage.Container)}
+}
+
+func (s *fakeStore) CreateContainer(id string, names []string, image, layer, metadata string, options *storage.ContainerOptions) (*storage.Container, error) {
+	if _, ok := s.containers[id]; ok {
+		return nil, storage.ErrDuplicateID
+	}
+	ctr := &storage.Container{ID: id, Names: names, ImageID: image, LayerID: id, Metadata: metadata}
+	for _, dir := range []string{"root", "run"} {
+		if err := os.MkdirAll(filepath.Join(s.root, dir, id), 0o700); err != nil {
+			return nil, err
+		}
+	}
+	s.containers[id] = ctr
+	return ctr, nil
+}
+
+func (s *fakeStore) Container(id string) (*storage.Container, error) {
+	ctr, ok := s.containers[id]
+	if !ok {
+		return nil, storage.ErrContainerUnknown
+	}
+	return ctr, nil
+}
+
+func (s *fakeStore) DeleteContainer(id string) error {
+	if _, ok := s.containers[id]; !ok {
+		return storage.ErrContainerUnknown
+	}
+	delete(s.containers, id)
+	for _, dir := range []string{"root", "run"} {
+		if err := os.RemoveAll(filepath.Join(s.root, dir, id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeStore) ContainerDirectory(id string) (string, error) {
+	return filepath.Join(s.root, "root", id), nil
+}
+
+func (s *fakeStore) ContainerRunDirectory(id string) (string, error) {
+	return filepath.Join(s.root, "run", id), nil
+}
+
+func (s *fakeStore) Names(id string) ([]string, error) {
+	if ctr, ok := s.containers[id]; ok {
+		return ctr.Names, nil
+	}
+	return nil, storage.ErrLayerUnknown
+}
+
+func (s *fakeStore) SetNames(id string, names []string) error {
+	return nil
+}
+
+func (s *fakeStore) Metadata(id string) (string, error) {
+	ctr, err := s.Container(id)
+	if err != nil {
+		return "", err
+	}
+	return ctr.Metadata, nil
+}
+
+func (s *fakeStore) SetMetadata(id, metadata string) error {
+	ctr, err := s.Container(id)
+	if err != nil {
+		return err
+	}
+	ctr.Metadata = metadata
+	return nil
+}
+
+func (s *fakeStore) Mounted(id string) (int, error) {
+	return 0, nil
+}
+
+func (s *fakeStore) Unmount(id string, force bool) (bool, error) {
+	return false, nil
+}
+
+func (s *fakeStore) GraphRoot() string {
+	return filepath.Join(s.root, "root")
+}
+
+func (s *fakeStore) RunRoot() string {
+	return filepath.Join(s.root, "run")
+}
+
+func (s *fakeStore) GraphDriverName() string {
+	return "fake"
+}
+
+func (s *fakeStore) GraphOptions() []string {
+	return nil
+}
+
+func (s *fakeStore) TransientStore() bool {
+	return false
+}
+
+// getFakeRuntime returns a runtime using the fake OCI runtime, with its state
+// and storage in a temporary directory. No networks are set up, containers
+// must use a rootfs and no network namespace.
+func getFakeRuntime(t *testing.T) (*Runtime, *FakeRuntime) {
+	tmpDir := t.TempDir()
+
+	conf, err := config.DefaultConfig()
+	require.NoError(t, err)
+	conf.Engine.OCIRuntime = FakeOCIRuntimeName
+	conf.Engine.TmpDir = filepath.Join(tmpDir, "tmp")
+	conf.Engine.StaticDir = filepath.Join(tmpDir, "static")
+	conf.Engine.VolumePath = filepath.Join(tmpDir, "volumes")
+	conf.Engine.CgroupManager = config.CgroupfsCgroupsManager
+	conf.Engine.EventsLogger = events.Null.String()
+
+	r := &Runtime{
+		config: conf,
+		valid:  true,
+	}
+	r.lockManager, err = lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	r.state, err = NewBoltState(filepath.Join(tmpDir, "bolt_state.db"), r)
+	require.NoError(t, err)
+	t.Cleanup(func() { r.state.Close() })
+	r.eventer, err = events.NewEventer(events.EventerOptions{EventerType: events.Null.String()})
+	require.NoError(t, err)
+
+	r.store = newFakeStore(filepath.Join(tmpDir, "storage"))
+	r.storageService = getStorageService(r.store)
+
+	fake, err := newFakeOCIRuntime(FakeOCIRuntimeName, r)
+	require.NoError(t, err)
+	r.ociRuntimes = map[string]OCIRuntime{FakeOCIRuntimeName: fake}
+	r.defaultOCIRuntime = fake
+	return r, fake
+}
+
+// getFakeContainer creates a container with an empty rootfs in the given
+// runtime.
+func getFakeContainer(t *testing.T, r *Runtime, options ...CtrCreateOption) *Container {
+	rootfs := t.TempDir()
+	ctrSpec := &spec.Spec{
+		Version: spec.Version,
+		Process: &spec.Process{
+			Args: []string{"/bin/true"},
+			Cwd:  "/",
+		},
+		Root: &spec.Root{Path: rootfs},
+		// Map root in the container to the current user, libpod chowns
+		// the directories of the container to it.
+		Linux: &spec.Linux{
+			UIDMappings: []spec.LinuxIDMapping{{ContainerID: 0, HostID: uint32(os.Getuid()), Size: 1}},
+			GIDMappings: []spec.LinuxIDMapping{{ContainerID: 0, HostID: uint32(os.Getgid()), Size: 1}},
+		},
+	}
+	options = append([]CtrCreateOption{WithRootFS(rootfs, false, nil), WithNoShm(true)}, options...)
+	ctr, err := r.NewContainer(context.Background(), ctrSpec, nil, false, options...)
+	require.NoError(t, err)
+	return ctr
+}
+
+func TestFakeRuntimeLifecycle(t *testing.T) {
+	r, fake := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r)
+
+	state, err := ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateConfigured, state)
+
+	require.NoError(t, ctr.Start(ctx, false))
+	state, err = ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateRunning, state)
+	pid, err := ctr.PID()
+	require.NoError(t, err)
+	assert.NotZero(t, pid)
+
+	// Signals other than the stop signal and SIGKILL do not stop it.
+	require.NoError(t, ctr.Kill(uint(syscall.SIGHUP)))
+	state, err = ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateRunning, state)
+	assert.Equal(t, []uint{uint(syscall.SIGHUP)}, fake.Signals(ctr))
+
+	require.NoError(t, fake.Exit(ctr, 3))
+	require.NoError(t, ctr.Cleanup(ctx))
+	exitCode, err := ctr.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), exitCode)
+	state, err = ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateExited, state)
+
+	// An exited container can be started again.
+	require.NoError(t, ctr.Start(ctx, false))
+	require.NoError(t, ctr.Stop())
+	require.NoError(t, ctr.Cleanup(ctx))
+	exitCode, err = ctr.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(128+syscall.SIGTERM), exitCode)
+	assert.ErrorIs(t, ctr.Kill(uint(syscall.SIGKILL)), define.ErrCtrStateInvalid)
+
+	require.NoError(t, r.RemoveContainer(ctx, ctr, false, false, nil))
+}
+
+func TestFakeRuntimePause(t *testing.T) {
+	if rootless.IsRootless() {
+		if cgroupv2, _ := cgroups.IsCgroup2UnifiedMode(); !cgroupv2 {
+			t.Skip("pausing rootless containers requires cgroup v2")
+		}
+	}
+	r, _ := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r)
+
+	assert.ErrorIs(t, ctr.Pause(), define.ErrCtrStateInvalid)
+	require.NoError(t, ctr.Start(ctx, false))
+	require.NoError(t, ctr.Pause())
+	state, err := ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStatePaused, state)
+	assert.ErrorIs(t, ctr.Pause(), define.ErrCtrStateInvalid)
+	require.NoError(t, ctr.Unpause())
+	state, err = ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateRunning, state)
+}
+
+func TestFakeRuntimeStopSignal(t *testing.T) {
+	r, _ := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r, WithStopSignal(syscall.SIGINT))
+
+	require.NoError(t, ctr.Start(ctx, false))
+	require.NoError(t, ctr.StopWithTimeout(0))
+	require.NoError(t, ctr.Cleanup(ctx))
+	exitCode, err := ctr.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(128+syscall.SIGKILL), exitCode)
+
+	require.NoError(t, ctr.Start(ctx, false))
+	require.NoError(t, ctr.Stop())
+	require.NoError(t, ctr.Cleanup(ctx))
+	exitCode, err = ctr.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(128+syscall.SIGINT), exitCode)
+}
+
+func TestFakeRuntimeExec(t *testing.T) {
+	r, fake := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r)
+
+	fake.ExecFunc = func(ctr *Container, options *ExecOptions) int {
+		if options.Cmd[0] == "false" {
+			return 1
+		}
+		return 0
+	}
+
+	_, err := ctr.Exec(&ExecConfig{Command: []string{"true"}}, &define.AttachStreams{}, nil)
+	assert.ErrorIs(t, err, define.ErrCtrStateInvalid)
+
+	require.NoError(t, ctr.Start(ctx, false))
+	exitCode, err := ctr.Exec(&ExecConfig{Command: []string{"true"}}, &define.AttachStreams{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	exitCode, err = ctr.Exec(&ExecConfig{Command: []string{"false"}}, &define.AttachStreams{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+}
+
+func TestFakeRuntimeAttach(t *testing.T) {
+	r, fake := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r)
+	require.NoError(t, ctr.Init(ctx, false))
+
+	attachErr, err := ctr.StartAndAttach(ctx, &define.AttachStreams{AttachOutput: true}, "", nil, false)
+	require.NoError(t, err)
+	state, err := ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateRunning, state)
+	select {
+	case err = <-attachErr:
+		t.Fatalf("attach returned before the container exited: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, fake.Exit(ctr, 0))
+	select {
+	case err = <-attachErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("attach did not return after the container exited")
+	}
+}
+
+func TestFakeRuntimeRestartPolicy(t *testing.T) {
+	r, fake := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r, WithRestartPolicy(define.RestartPolicyOnFailure), WithRestartRetries(1))
+
+	require.NoError(t, ctr.Start(ctx, false))
+	require.NoError(t, fake.Exit(ctr, 1))
+
+	// The cleanup process restarts the container on failure.
+	require.NoError(t, ctr.Cleanup(ctx))
+	state, err := ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateRunning, state)
+	assert.Equal(t, uint(1), ctr.state.RestartCount)
+
+	// The retries are used up.
+	require.NoError(t, fake.Exit(ctr, 1))
+	require.NoError(t, ctr.Cleanup(ctx))
+	exitCode, err := ctr.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), exitCode)
+	state, err = ctr.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateExited, state)
+
+	// Containers stopped by the user are not restarted.
+	ctr2 := getFakeContainer(t, r, WithRestartPolicy(define.RestartPolicyAlways))
+	require.NoError(t, ctr2.Start(ctx, false))
+	require.NoError(t, ctr2.Stop())
+	require.NoError(t, ctr2.Cleanup(ctx))
+	state, err = ctr2.State()
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateExited, state)
+}
+
+func TestFakeRuntimePod(t *testing.T) {
+	r, fake := getFakeRuntime(t)
+	ctx := context.Background()
+	// Pod operations run on the parallel job queue, set up by podman itself.
+	require.NoError(t, parallel.SetMaxThreads(uint(runtime.NumCPU())))
+	pod, err := r.NewPod(ctx, specgen.PodSpecGenerator{}, WithPodName("fake"))
+	require.NoError(t, err)
+	ctr1 := getFakeContainer(t, r, r.WithPod(pod))
+	ctr2 := getFakeContainer(t, r, r.WithPod(pod))
+
+	podStatus := func() string {
+		status, err := pod.GetPodStatus()
+		require.NoError(t, err)
+		return status
+	}
+
+	ctrErrs, err := pod.Start(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ctrErrs)
+	assert.Equal(t, define.PodStateRunning, podStatus())
+
+	require.NoError(t, fake.Exit(ctr1, 0))
+	require.NoError(t, ctr1.Cleanup(ctx))
+	assert.Equal(t, define.PodStateDegraded, podStatus())
+
+	_, err = pod.Kill(ctx, uint(syscall.SIGKILL))
+	require.NoError(t, err)
+	require.NoError(t, ctr2.Cleanup(ctx))
+	exitCode, err := ctr2.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(128+syscall.SIGKILL), exitCode)
+
+	ctrErrs, err = pod.Start(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ctrErrs)
+	ctrErrs, err = pod.Stop(ctx, true)
+	require.NoError(t, err)
+	assert.Empty(t, ctrErrs)
+	assert.Equal(t, define.PodStateExited, podStatus())
+
+	require.NoError(t, r.RemovePod(ctx, pod, true, false, nil))
+}
@@ -1089,6 +1089,24 @@ func WithNetNS(portMappings []nettypes.PortMapping, exposedPorts map[uint16][]st
 	}
 }
 
+// WithNetworkShaping sets the traffic control applied to the network
+// interfaces of the container.
+// This can only be used if the container creates its own network namespace.
+func WithNetworkShaping(shaping *define.NetworkShaping) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if !ctr.config.CreateNetNS {
+			return fmt.Errorf("network shaping can only be used with a private network namespace: %w", define.ErrInvalidArg)
+		}
+		ctr.config.NetworkShaping = shaping
+
+		return nil
+	}
+}
+
 // WithNetworkOptions sets additional options for the networks.
 func WithNetworkOptions(options map[string][]string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1440,6 +1458,11 @@ func WithNamedVolumes(volumes []*ContainerNamedVolume) CtrCreateOption {
 			if err != nil {
 				return fmt.Errorf("processing options for named volume %q mounted at %q: %w", vol.Name, vol.Dest, err)
 			}
+			if vol.SubPath != "" {
+				if err := util.ValidateSubPath(vol.SubPath); err != nil {
+					return fmt.Errorf("named volume %q mounted at %q: %w", vol.Name, vol.Dest, err)
+				}
+			}
 
 			ctr.config.NamedVolumes = append(ctr.config.NamedVolumes, &ContainerNamedVolume{
 				Name:        vol.Name,
@@ -1514,6 +1537,36 @@ func WithHealthCheckOnFailureAction(action define.HealthCheckOnFailureAction) Ct
 	}
 }
 
+// WithPostStartHook sets a command that is executed in the container after it
+// has been started.
+func WithPostStartHook(hook []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if len(hook) == 0 {
+			return fmt.Errorf("post-start hook requires a command: %w", define.ErrInvalidArg)
+		}
+		ctr.config.PostStartHook = hook
+		return nil
+	}
+}
+
+// WithPreStopHook sets a command that is executed in the container before it
+// is stopped.
+func WithPreStopHook(hook []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if len(hook) == 0 {
+			return fmt.Errorf("pre-stop hook requires a command: %w", define.ErrInvalidArg)
+		}
+		ctr.config.PreStopHook = hook
+		return nil
+	}
+}
+
 // WithPreserveFDs forwards from the process running Libpod into the container
 // the given number of extra FDs (starting after the standard streams) to the created container
 func WithPreserveFDs(fd uint) CtrCreateOption {
@@ -1932,6 +1985,20 @@ func WithStartupHealthcheck(startupHC *define.StartupHealthCheck) CtrCreateOptio
 	}
 }
 
+// WithReadinessCheck sets a readiness check for the container.
+func WithReadinessCheck(readinessCheck *define.ReadinessCheck) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.ReadinessCheckConfig = new(define.ReadinessCheck)
+		if err := JSONDeepCopy(readinessCheck, ctr.config.ReadinessCheckConfig); err != nil {
+			return fmt.Errorf("error copying readiness check into container: %w", err)
+		}
+		return nil
+	}
+}
+
 // Pod Creation Options
 
 // WithPodCreateCommand adds the full command plus arguments of the current
@@ -1982,6 +2049,49 @@ func WithPodExitPolicy(policy string) PodCreateOption {
 	}
 }
 
+// WithPodNetworkPolicy sets the network policy restricting the network
+// traffic of the pod. It requires an infra container whose network
+// namespace is shared by the pod.
+func WithPodNetworkPolicy(policy *define.NetworkPolicy) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		pod.config.NetworkPolicy = policy
+
+		return nil
+	}
+}
+
+// WithPodServicePorts sets the ports of Kubernetes Services which are
+// redirected to another port of the pod. It requires an infra container
+// whose network namespace is shared by the pod.
+func WithPodServicePorts(ports []define.ServicePort) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		pod.config.ServicePorts = ports
+
+		return nil
+	}
+}
+
+// WithPodQOSClass sets the Kubernetes quality of service class of the pod.
+func WithPodQOSClass(class string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		pod.config.QOSClass = class
+
+		return nil
+	}
+}
+
 // WithPodHostname sets the hostname of the pod.
 func WithPodHostname(hostname string) PodCreateOption {
 	return func(pod *Pod) error {
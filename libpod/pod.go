@@ -81,11 +81,21 @@ type PodConfig struct {
 	// The pod's exit policy.
 	ExitPolicy config.PodExitPolicy `json:"ExitPolicy,omitempty"`
 
+	// QOSClass is the Kubernetes quality of service class of the pod.
+	QOSClass string `json:"qosClass,omitempty"`
+
 	// ID of the pod's lock
 	LockID uint32 `json:"lockID"`
 
 	// ResourceLimits hold the pod level resource limits
 	ResourceLimits specs.LinuxResources
+
+	// NetworkPolicy restricts the network traffic of the pod.
+	NetworkPolicy *define.NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// ServicePorts are the ports of Kubernetes Services selecting the pod
+	// which are redirected to another port of the pod.
+	ServicePorts []define.ServicePort `json:"servicePorts,omitempty"`
 }
 
 // podState represents a pod's state
@@ -273,6 +283,30 @@ func (p *Pod) Labels() map[string]string {
 	return labels
 }
 
+// NetworkPolicy returns a copy of the network policy of the pod, or nil if
+// the pod has none.
+func (p *Pod) NetworkPolicy() *define.NetworkPolicy {
+	if p.config.NetworkPolicy == nil {
+		return nil
+	}
+	policy := new(define.NetworkPolicy)
+	if err := JSONDeepCopy(p.config.NetworkPolicy, policy); err != nil {
+		return nil
+	}
+	return policy
+}
+
+// ServicePorts returns the ports of Kubernetes Services which are redirected
+// to another port of the pod.
+func (p *Pod) ServicePorts() []define.ServicePort {
+	if len(p.config.ServicePorts) == 0 {
+		return nil
+	}
+	ports := make([]define.ServicePort, len(p.config.ServicePorts))
+	copy(ports, p.config.ServicePorts)
+	return ports
+}
+
 // CreatedTime gets the time when the pod was created
 func (p *Pod) CreatedTime() time.Time {
 	return p.config.CreatedTime
@@ -591,6 +591,36 @@ func containerStatusFromContainers(allCtrs []*Container) (map[string]define.Cont
 	return status, nil
 }
 
+// RefreshNetworkPolicy applies the network policy of the pod again, so that
+// the addresses of peer pods which were started or removed since the pod was
+// started are picked up. It does nothing if the pod has no network policy or
+// its infra container is not running on a bridge network.
+func (p *Pod) RefreshNetworkPolicy() error {
+	if !p.valid {
+		return define.ErrPodRemoved
+	}
+	if p.config.NetworkPolicy == nil {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	infra, err := p.infraContainer()
+	if err != nil {
+		return err
+	}
+	infra.lock.Lock()
+	defer infra.lock.Unlock()
+	if err := infra.syncContainer(); err != nil {
+		return err
+	}
+	if infra.state.NetNS == "" || !infra.config.NetMode.IsBridge() {
+		return nil
+	}
+	return infra.setupNetworkPolicy(infra.state.NetNS, p.config.NetworkPolicy)
+}
+
 // Inspect returns a PodInspect struct to describe the pod.
 func (p *Pod) Inspect() (*define.InspectPodData, error) {
 	p.lock.Lock()
@@ -605,6 +635,7 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 	}
 	ctrs := make([]define.InspectPodContainerInfo, 0, len(containers))
 	ctrStatuses := make(map[string]define.ContainerStatus, len(containers))
+	ctrReady := make(map[string]bool, len(containers))
 	for _, c := range containers {
 		containerStatus := "unknown"
 		// Ignoring possible errors here because we don't want this to be
@@ -613,16 +644,20 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		if err == nil {
 			containerStatus = containerState.String()
 		}
+		containerReady, _ := c.Ready()
 		ctrs = append(ctrs, define.InspectPodContainerInfo{
 			ID:    c.ID(),
 			Name:  c.Name(),
 			State: containerStatus,
+			Ready: containerReady,
 		})
 		// Do not add init containers fdr status
 		if len(c.config.InitContainerType) < 1 {
 			ctrStatuses[c.ID()] = c.state.State
+			ctrReady[c.ID()] = containerReady
 		}
 	}
+	podReady := createPodReadyResult(ctrReady)
 	podState, err := createPodStatusResults(ctrStatuses)
 	if err != nil {
 		return nil, err
@@ -715,7 +750,9 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		Created:             p.CreatedTime(),
 		CreateCommand:       p.config.CreateCommand,
 		ExitPolicy:          string(p.config.ExitPolicy),
+		QOSClass:            p.config.QOSClass,
 		State:               podState,
+		Ready:               podReady,
 		Hostname:            p.config.Hostname,
 		Labels:              p.Labels(),
 		CreateCgroup:        p.config.UsePodCgroup,
@@ -741,6 +778,8 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		CPUSetMems:          p.CPUSetMems(),
 		BlkioDeviceWriteBps: p.BlkiThrottleWriteBps(),
 		CPUShares:           p.CPUShares(),
+		NetworkPolicy:       p.NetworkPolicy(),
+		ServicePorts:        p.ServicePorts(),
 	}
 
 	return &inspectData, nil
@@ -13,6 +13,39 @@ func (p *Pod) GetPodStatus() (string, error) {
 	return createPodStatusResults(ctrStatuses)
 }
 
+// Ready returns whether all containers of the pod, except init containers,
+// are ready to serve requests.
+func (p *Pod) Ready() (bool, error) {
+	ctrs, err := p.AllContainers()
+	if err != nil {
+		return false, err
+	}
+	ctrReady := make(map[string]bool, len(ctrs))
+	for _, c := range ctrs {
+		if c.IsInitCtr() {
+			continue
+		}
+		ready, err := c.Ready()
+		if err != nil {
+			return false, err
+		}
+		ctrReady[c.ID()] = ready
+	}
+	return createPodReadyResult(ctrReady), nil
+}
+
+func createPodReadyResult(ctrReady map[string]bool) bool {
+	if len(ctrReady) == 0 {
+		return false
+	}
+	for _, ready := range ctrReady {
+		if !ready {
+			return false
+		}
+	}
+	return true
+}
+
 func createPodStatusResults(ctrStatuses map[string]define.ContainerStatus) (string, error) {
 	ctrNum := len(ctrStatuses)
 	if ctrNum == 0 {
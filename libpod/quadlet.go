@@ -0,0 +1,857 @@
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/common/libnetwork/types"
+	cutil "github.com/containers/common/pkg/util"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/annotations"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/containers/podman/v4/pkg/systemd/parser"
+	"github.com/containers/podman/v4/pkg/systemd/quadlet"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// Quadlet names volumes and networks it creates "systemd-<unit name>".
+const quadletNamePrefix = "systemd-"
+
+var (
+	numericUserRegexp      = regexp.MustCompile(`^\d+(:\d+)?$`)
+	defaultInterfaceRegexp = regexp.MustCompile(`^eth\d+$`)
+)
+
+// quadletUnitRef returns how a quadlet unit refers to the named volume or
+// network. Names created by quadlet are converted back into a reference to
+// the unit file with the given extension.
+func quadletUnitRef(name, extension string) string {
+	if strings.HasPrefix(name, quadletNamePrefix) && len(name) > len(quadletNamePrefix) {
+		return strings.TrimPrefix(name, quadletNamePrefix) + extension
+	}
+	return name
+}
+
+// escapeQuadletValue escapes systemd specifiers, quadlet copies the value
+// verbatim into the ExecStart line of the generated service.
+func escapeQuadletValue(value string) string {
+	return strings.ReplaceAll(value, "%", "%%")
+}
+
+// addQuadletArgs adds the given words to key, quoting them as needed.
+func addQuadletArgs(unit *parser.UnitFile, group, key string, args ...string) {
+	escaped := make([]string, 0, len(args))
+	for _, arg := range args {
+		escaped = append(escaped, escapeQuadletValue(arg))
+	}
+	unit.AddCmdline(group, key, escaped)
+}
+
+// addQuadletKeyVals adds one key=value entry per line for key, sorted by
+// key to keep the output stable.
+func addQuadletKeyVals(unit *parser.UnitFile, group, key string, keyvals map[string]string) {
+	keys := make([]string, 0, len(keyvals))
+	for k := range keyvals {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		addQuadletArgs(unit, group, key, k+"="+keyvals[k])
+	}
+}
+
+// GenerateForQuadlet generates a quadlet .container unit from a libpod
+// container. Options without a dedicated quadlet key are passed through
+// PodmanArgs.
+func (c *Container) GenerateForQuadlet(ctx context.Context) (*parser.UnitFile, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.IsInfra() {
+		return nil, fmt.Errorf("cannot generate a quadlet for infra container %s, generate it for its pod instead: %w", c.ID(), define.ErrNotImplemented)
+	}
+
+	var imgData *libimage.ImageData
+	if c.config.RootfsImageID != "" {
+		img, _, err := c.runtime.libimageRuntime.LookupImage(c.config.RootfsImageID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("looking up image %q of container %q: %w", c.config.RootfsImageID, c.ID(), err)
+		}
+		imgData, err = img.Inspect(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unit := parser.NewUnitFile()
+	unit.Filename = c.Name() + ".container"
+	group := quadlet.ContainerGroup
+
+	switch {
+	case c.config.Rootfs != "":
+		rootfs := c.config.Rootfs
+		if c.config.RootfsOverlay {
+			rootfs += ":O"
+		}
+		unit.Add(group, quadlet.KeyRootfs, escapeQuadletValue(rootfs))
+	case c.config.RawImageName != "":
+		unit.Add(group, quadlet.KeyImage, escapeQuadletValue(c.config.RawImageName))
+	default:
+		unit.Add(group, quadlet.KeyImage, escapeQuadletValue(c.config.RootfsImageName))
+	}
+	unit.Add(group, quadlet.KeyContainerName, escapeQuadletValue(c.Name()))
+
+	var podmanArgs []string
+
+	if c.config.Entrypoint != nil && (imgData == nil || imgData.Config == nil || !reflect.DeepEqual(c.config.Entrypoint, imgData.Config.Entrypoint)) {
+		entrypoint, err := json.Marshal(c.config.Entrypoint)
+		if err != nil {
+			return nil, err
+		}
+		podmanArgs = append(podmanArgs, "--entrypoint", string(entrypoint))
+	}
+
+	if err := c.quadletEnvironment(unit, imgData); err != nil {
+		return nil, err
+	}
+	c.quadletLabels(unit, imgData)
+
+	podArgs, err := c.quadletNetworking(unit, imgData)
+	if err != nil {
+		return nil, err
+	}
+	podmanArgs = append(podmanArgs, podArgs...)
+
+	podmanArgs = append(podmanArgs, c.quadletVolumes(unit)...)
+	podmanArgs = append(podmanArgs, c.quadletSecurity(unit, imgData)...)
+
+	if c.config.Spec.Process != nil && c.config.Spec.Process.NoNewPrivileges {
+		unit.Add(group, quadlet.KeyNoNewPrivileges, "true")
+	}
+	if c.config.Timezone != "" {
+		unit.Add(group, quadlet.KeyTimezone, escapeQuadletValue(c.config.Timezone))
+	}
+	if c.config.Spec.Annotations[define.InspectAnnotationInit] == define.InspectResponseTrue {
+		unit.Add(group, quadlet.KeyRunInit, "true")
+	}
+
+	for _, secret := range c.config.Secrets {
+		opts := []string{secret.Name, "type=mount"}
+		if secret.Target != "" {
+			opts = append(opts, "target="+secret.Target)
+		}
+		if secret.UID != 0 {
+			opts = append(opts, fmt.Sprintf("uid=%d", secret.UID))
+		}
+		if secret.GID != 0 {
+			opts = append(opts, fmt.Sprintf("gid=%d", secret.GID))
+		}
+		if secret.Mode != 0 && secret.Mode != 0o444 {
+			opts = append(opts, fmt.Sprintf("mode=%o", secret.Mode))
+		}
+		addQuadletArgs(unit, group, quadlet.KeySecret, strings.Join(opts, ","))
+	}
+	envSecrets := make([]string, 0, len(c.config.EnvSecrets))
+	for target := range c.config.EnvSecrets {
+		envSecrets = append(envSecrets, target)
+	}
+	sort.Strings(envSecrets)
+	for _, target := range envSecrets {
+		addQuadletArgs(unit, group, quadlet.KeySecret, fmt.Sprintf("%s,type=env,target=%s", c.config.EnvSecrets[target].Name, target))
+	}
+
+	c.quadletUserNamespace(unit)
+	podmanArgs = append(podmanArgs, c.quadletMisc(imgData)...)
+
+	if len(podmanArgs) > 0 {
+		addQuadletArgs(unit, group, quadlet.KeyPodmanArgs, podmanArgs...)
+	}
+
+	if len(c.config.Command) > 0 && (imgData == nil || imgData.Config == nil || !reflect.DeepEqual(c.config.Command, imgData.Config.Cmd)) {
+		addQuadletArgs(unit, group, quadlet.KeyExec, c.config.Command...)
+	}
+
+	switch c.config.RestartPolicy {
+	case define.RestartPolicyAlways, define.RestartPolicyUnlessStopped:
+		unit.Add(quadlet.ServiceGroup, "Restart", "always")
+	case define.RestartPolicyOnFailure:
+		unit.Add(quadlet.ServiceGroup, "Restart", "on-failure")
+	}
+
+	unit.Add(quadlet.InstallGroup, "WantedBy", "default.target")
+
+	return unit, nil
+}
+
+// quadletEnvironment adds the environment variables which are not set by
+// default or by the image.
+func (c *Container) quadletEnvironment(unit *parser.UnitFile, imgData *libimage.ImageData) error {
+	if c.config.Spec.Process == nil {
+		return nil
+	}
+	var imageEnv []string
+	if imgData != nil && imgData.Config != nil {
+		imageEnv = imgData.Config.Env
+	}
+	envVars, err := libpodEnvVarsToKubeEnvVars(c.config.Spec.Process.Env, imageEnv)
+	if err != nil {
+		return err
+	}
+	environment := make(map[string]string, len(envVars))
+	for _, e := range envVars {
+		// HOSTNAME is added for the hostname set by the user.
+		if e.Name == "HOSTNAME" && e.Value == c.config.Spec.Hostname {
+			continue
+		}
+		environment[e.Name] = e.Value
+	}
+	addQuadletKeyVals(unit, quadlet.ContainerGroup, quadlet.KeyEnvironment, environment)
+	return nil
+}
+
+// quadletLabels adds the labels and annotations which are not inherited
+// from the image.
+func (c *Container) quadletLabels(unit *parser.UnitFile, imgData *libimage.ImageData) {
+	labels := make(map[string]string)
+	for k, v := range c.config.Labels {
+		if imgData != nil && imgData.Labels[k] == v {
+			continue
+		}
+		labels[k] = v
+	}
+	addQuadletKeyVals(unit, quadlet.ContainerGroup, quadlet.KeyLabel, labels)
+
+	ctrAnnotations := make(map[string]string)
+	for k, v := range c.config.Spec.Annotations {
+		if define.IsReservedAnnotation(k) || annotations.IsReservedAnnotation(k) {
+			continue
+		}
+		if imgData != nil && imgData.Annotations[k] == v {
+			continue
+		}
+		ctrAnnotations[k] = v
+	}
+	addQuadletKeyVals(unit, quadlet.ContainerGroup, quadlet.KeyAnnotation, ctrAnnotations)
+}
+
+// quadletNetworking adds the network configuration and published ports of
+// the container and returns the options that go into PodmanArgs.
+func (c *Container) quadletNetworking(unit *parser.UnitFile, imgData *libimage.ImageData) ([]string, error) {
+	group := quadlet.ContainerGroup
+	var podmanArgs []string
+
+	if c.config.Pod != "" {
+		// The network configuration is owned by the pod.
+		pod, err := c.runtime.state.Pod(c.config.Pod)
+		if err != nil {
+			return nil, err
+		}
+		return []string{"--pod", pod.Name()}, nil
+	}
+
+	switch {
+	case c.config.NetNsCtr != "":
+		netCtr, err := c.runtime.state.Container(c.config.NetNsCtr)
+		if err != nil {
+			return nil, err
+		}
+		unit.Add(group, quadlet.KeyNetwork, "container:"+escapeQuadletValue(netCtr.Name()))
+	case c.config.NetMode.IsBridge():
+		networks, err := c.networks()
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(networks))
+		for name := range networks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			opts := quadletNetworkOptions(networks[name], c.ID())
+			if len(names) == 1 && name == c.runtime.config.Network.DefaultNetwork && len(opts) == 0 && !rootless.IsRootless() {
+				// This is what quadlet does by default.
+				continue
+			}
+			network := quadletUnitRef(name, ".network")
+			if len(opts) > 0 {
+				network += ":" + strings.Join(opts, ",")
+			}
+			unit.Add(group, quadlet.KeyNetwork, escapeQuadletValue(network))
+		}
+	case c.config.NetMode.IsSlirp4netns() && string(c.config.NetMode) == "slirp4netns" && rootless.IsRootless():
+		// This is what quadlet does by default.
+	case c.config.NetMode != "":
+		unit.Add(group, quadlet.KeyNetwork, escapeQuadletValue(string(c.config.NetMode)))
+	}
+
+	for _, port := range c.config.PortMappings {
+		for _, protocol := range strings.Split(port.Protocol, ",") {
+			publish := quadletPortMapping(port, protocol)
+			if protocol == "sctp" {
+				// Quadlet only accepts tcp and udp ports.
+				podmanArgs = append(podmanArgs, "--publish", publish)
+				continue
+			}
+			unit.Add(group, quadlet.KeyPublishPort, publish)
+		}
+	}
+
+	exposed := make([]string, 0, len(c.config.ExposedPorts))
+	for port, protocols := range c.config.ExposedPorts {
+		for _, protocol := range protocols {
+			if imgData != nil && imgData.Config != nil {
+				if _, ok := imgData.Config.ExposedPorts[fmt.Sprintf("%d/%s", port, protocol)]; ok {
+					continue
+				}
+			}
+			expose := strconv.Itoa(int(port))
+			if protocol != "" && protocol != "tcp" {
+				expose += "/" + protocol
+			}
+			exposed = append(exposed, expose)
+		}
+	}
+	sort.Strings(exposed)
+	for _, expose := range exposed {
+		unit.Add(group, quadlet.KeyExposeHostPort, expose)
+	}
+
+	if hostname := c.config.Spec.Hostname; hostname != "" && c.config.UTSNsCtr == "" && c.hasPrivateNamespace(spec.UTSNamespace) {
+		podmanArgs = append(podmanArgs, "--hostname", hostname)
+	}
+	for _, server := range c.config.DNSServer {
+		podmanArgs = append(podmanArgs, "--dns", server.String())
+	}
+	for _, search := range c.config.DNSSearch {
+		podmanArgs = append(podmanArgs, "--dns-search", search)
+	}
+	for _, option := range c.config.DNSOption {
+		podmanArgs = append(podmanArgs, "--dns-option", option)
+	}
+	for _, host := range c.config.HostAdd {
+		podmanArgs = append(podmanArgs, "--add-host", host)
+	}
+	return podmanArgs, nil
+}
+
+// hasPrivateNamespace returns true if the container gets a new namespace
+// of the given type.
+func (c *Container) hasPrivateNamespace(nsType spec.LinuxNamespaceType) bool {
+	if c.config.Spec.Linux == nil {
+		return false
+	}
+	for _, ns := range c.config.Spec.Linux.Namespaces {
+		if ns.Type == nsType {
+			return ns.Path == ""
+		}
+	}
+	return false
+}
+
+// quadletNetworkOptions returns the per network options of a container which
+// were set by the user.
+func quadletNetworkOptions(netOpts types.PerNetworkOptions, ctrID string) []string {
+	var opts []string
+	for _, ip := range netOpts.StaticIPs {
+		opts = append(opts, "ip="+ip.String())
+	}
+	if len(netOpts.StaticMAC) > 0 {
+		opts = append(opts, "mac="+netOpts.StaticMAC.String())
+	}
+	for _, alias := range netOpts.Aliases {
+		// The short ID is always added as alias.
+		if alias == ctrID[:12] {
+			continue
+		}
+		opts = append(opts, "alias="+alias)
+	}
+	if netOpts.InterfaceName != "" && !defaultInterfaceRegexp.MatchString(netOpts.InterfaceName) {
+		opts = append(opts, "interface_name="+netOpts.InterfaceName)
+	}
+	return opts
+}
+
+// quadletPortMapping converts a port mapping into the --publish format for
+// the given protocol.
+func quadletPortMapping(port types.PortMapping, protocol string) string {
+	portRange := func(start uint16) string {
+		if port.Range > 1 {
+			return fmt.Sprintf("%d-%d", start, uint32(start)+uint32(port.Range)-1)
+		}
+		return strconv.Itoa(int(start))
+	}
+
+	var publish string
+	if port.HostIP != "" {
+		hostIP := port.HostIP
+		if strings.Contains(hostIP, ":") {
+			hostIP = "[" + hostIP + "]"
+		}
+		publish = hostIP + ":"
+		if port.HostPort != 0 {
+			publish += portRange(port.HostPort)
+		}
+		publish += ":"
+	} else if port.HostPort != 0 {
+		publish = portRange(port.HostPort) + ":"
+	}
+	publish += portRange(port.ContainerPort)
+	if protocol != "" && protocol != "tcp" {
+		publish += "/" + protocol
+	}
+	return publish
+}
+
+// quadletVolumes adds the volumes and mounts of the container and returns
+// the mounts which need to be passed through PodmanArgs.
+func (c *Container) quadletVolumes(unit *parser.UnitFile) []string {
+	group := quadlet.ContainerGroup
+	var podmanArgs []string
+
+	namedVolumes, mounts := c.SortUserVolumes(c.config.Spec)
+	for _, namedVol := range namedVolumes {
+		if namedVol.IsAnonymous {
+			unit.Add(group, quadlet.KeyVolume, escapeQuadletValue(namedVol.Dest))
+			continue
+		}
+		if vol, err := c.runtime.state.Volume(namedVol.Name); err == nil && vol.Anonymous() {
+			unit.Add(group, quadlet.KeyVolume, escapeQuadletValue(namedVol.Dest))
+			continue
+		}
+		volume := quadletUnitRef(namedVol.Name, ".volume") + ":" + namedVol.Dest
+		// Named volumes always default to nosuid and nodev.
+		opts := quadletMountOptions(namedVol.Options, "nosuid", "nodev")
+		if namedVol.SubPath != "" {
+			opts = append([]string{"subpath=" + namedVol.SubPath}, opts...)
+		}
+		if len(opts) > 0 {
+			volume += ":" + strings.Join(opts, ",")
+		}
+		unit.Add(group, quadlet.KeyVolume, escapeQuadletValue(volume))
+	}
+
+	for _, mount := range mounts {
+		switch mount.Type {
+		case define.TypeBind:
+			opts := quadletMountOptions(mount.Options)
+			volume := mount.Source + ":" + mount.Destination
+			if len(opts) > 0 {
+				volume += ":" + strings.Join(opts, ",")
+			}
+			unit.Add(group, quadlet.KeyVolume, escapeQuadletValue(volume))
+		case define.TypeTmpfs:
+			tmpfs := mount.Destination
+			if opts := quadletMountOptions(mount.Options, "tmpcopyup", "nosuid", "nodev"); len(opts) > 0 {
+				tmpfs += ":" + strings.Join(opts, ",")
+			}
+			podmanArgs = append(podmanArgs, "--tmpfs", tmpfs)
+		default:
+			opts := []string{"type=" + mount.Type}
+			if mount.Source != "" {
+				opts = append(opts, "source="+mount.Source)
+			}
+			opts = append(opts, "destination="+mount.Destination)
+			opts = append(opts, mount.Options...)
+			podmanArgs = append(podmanArgs, "--mount", strings.Join(opts, ","))
+		}
+	}
+
+	for _, overlayVol := range c.config.OverlayVolumes {
+		opts := overlayVol.Options
+		if len(opts) == 0 {
+			opts = []string{"O"}
+		}
+		unit.Add(group, quadlet.KeyVolume, escapeQuadletValue(overlayVol.Source+":"+overlayVol.Dest+":"+strings.Join(opts, ",")))
+	}
+
+	for _, imageVol := range c.config.ImageVolumes {
+		mount := fmt.Sprintf("type=image,source=%s,destination=%s", imageVol.Source, imageVol.Dest)
+		if imageVol.ReadWrite {
+			mount += ",rw=true"
+		}
+		podmanArgs = append(podmanArgs, "--mount", mount)
+	}
+
+	if c.config.Spec.Root != nil && c.config.Spec.Root.Readonly {
+		unit.Add(group, quadlet.KeyReadOnly, "true")
+		userMounts := make(map[string]bool, len(mounts))
+		for _, mount := range mounts {
+			userMounts[mount.Destination] = true
+		}
+		for _, mount := range c.config.Spec.Mounts {
+			if mount.Destination == "/tmp" && mount.Type == define.TypeTmpfs && !userMounts[mount.Destination] {
+				unit.Add(group, quadlet.KeyVolatileTmp, "true")
+				break
+			}
+		}
+	}
+	return podmanArgs
+}
+
+// quadletMountOptions removes the mount options podman adds by default
+// when the user did not set them.
+func quadletMountOptions(options []string, defaults ...string) []string {
+	defaults = append(defaults, "rw", "rprivate", "rbind")
+	opts := make([]string, 0, len(options))
+	for _, opt := range options {
+		if !cutil.StringInSlice(opt, defaults) {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+// quadletSecurity adds the user, capabilities, devices and security options
+// of the container and returns the options that go into PodmanArgs.
+func (c *Container) quadletSecurity(unit *parser.UnitFile, imgData *libimage.ImageData) []string {
+	group := quadlet.ContainerGroup
+	var podmanArgs []string
+
+	if c.config.User != "" && (imgData == nil || imgData.User != c.config.User) {
+		if numericUserRegexp.MatchString(c.config.User) {
+			user, userGroup, hasGroup := strings.Cut(c.config.User, ":")
+			unit.Add(group, quadlet.KeyUser, user)
+			if hasGroup {
+				unit.Add(group, quadlet.KeyGroup, userGroup)
+			}
+		} else {
+			podmanArgs = append(podmanArgs, "--user", c.config.User)
+		}
+	}
+	for _, g := range c.config.Groups {
+		podmanArgs = append(podmanArgs, "--group-add", g)
+	}
+
+	if c.config.Privileged {
+		podmanArgs = append(podmanArgs, "--privileged")
+	} else {
+		if c.config.Spec.Process != nil && c.config.Spec.Process.Capabilities != nil {
+			defaultCaps := make([]string, 0, len(c.runtime.config.Containers.DefaultCapabilities))
+			for _, capability := range c.runtime.config.Containers.DefaultCapabilities {
+				capability = strings.ToUpper(capability)
+				if !strings.HasPrefix(capability, "CAP_") {
+					capability = "CAP_" + capability
+				}
+				defaultCaps = append(defaultCaps, capability)
+			}
+			if caps := determineCapAddDropFromCapabilities(defaultCaps, c.config.Spec.Process.Capabilities.Bounding); caps != nil {
+				for _, capability := range caps.Drop {
+					unit.Add(group, quadlet.KeyDropCapability, string(capability))
+				}
+				for _, capability := range caps.Add {
+					unit.Add(group, quadlet.KeyAddCapability, string(capability))
+				}
+			}
+		}
+		for _, device := range c.config.DeviceHostSrc {
+			unit.Add(group, quadlet.KeyAddDevice, escapeQuadletValue(device.Path))
+		}
+	}
+
+	if labelOpts := c.config.Spec.Annotations[define.InspectAnnotationLabel]; labelOpts != "" {
+		for _, label := range strings.Split(labelOpts, ",label=") {
+			kind, value, _ := strings.Cut(label, ":")
+			switch kind {
+			case "disable":
+				unit.Add(group, quadlet.KeySecurityLabelDisable, "true")
+			case "type":
+				unit.Add(group, quadlet.KeySecurityLabelType, value)
+			case "filetype":
+				unit.Add(group, quadlet.KeySecurityLabelFileType, value)
+			case "level":
+				unit.Add(group, quadlet.KeySecurityLabelLevel, value)
+			default:
+				podmanArgs = append(podmanArgs, "--security-opt", "label="+label)
+			}
+		}
+	}
+	if seccomp := c.config.Spec.Annotations[define.InspectAnnotationSeccomp]; seccomp != "" {
+		unit.Add(group, quadlet.KeySeccompProfile, escapeQuadletValue(seccomp))
+	}
+	if apparmor := c.config.Spec.Annotations[define.InspectAnnotationApparmor]; apparmor != "" {
+		podmanArgs = append(podmanArgs, "--security-opt", "apparmor="+apparmor)
+	}
+	return podmanArgs
+}
+
+// quadletUserNamespace adds the user namespace configuration of the
+// container.
+func (c *Container) quadletUserNamespace(unit *parser.UnitFile) {
+	group := quadlet.ContainerGroup
+	idMappings := c.config.IDMappings
+	switch {
+	case idMappings.AutoUserNs:
+		unit.Add(group, quadlet.KeyRemapUsers, "auto")
+		if idMappings.AutoUserNsOpts.Size > 0 {
+			unit.Add(group, quadlet.KeyRemapUIDSize, strconv.FormatUint(uint64(idMappings.AutoUserNsOpts.Size), 10))
+		}
+	case c.config.AddCurrentUserPasswdEntry:
+		unit.Add(group, quadlet.KeyRemapUsers, "keep-id")
+	case !idMappings.HostUIDMapping && len(idMappings.UIDMap) > 0:
+		unit.Add(group, quadlet.KeyRemapUsers, "manual")
+		for _, m := range idMappings.UIDMap {
+			unit.Add(group, quadlet.KeyRemapUID, fmt.Sprintf("%d:%d:%d", m.ContainerID, m.HostID, m.Size))
+		}
+		for _, m := range idMappings.GIDMap {
+			unit.Add(group, quadlet.KeyRemapGID, fmt.Sprintf("%d:%d:%d", m.ContainerID, m.HostID, m.Size))
+		}
+	}
+}
+
+// quadletMisc returns the options without a dedicated quadlet key.
+func (c *Container) quadletMisc(imgData *libimage.ImageData) []string {
+	var podmanArgs []string
+
+	if workDir := c.WorkingDir(); workDir != "/" && workDir != "" && (imgData == nil || imgData.Config == nil || imgData.Config.WorkingDir != workDir) {
+		podmanArgs = append(podmanArgs, "--workdir", workDir)
+	}
+	if c.config.StopSignal != 0 && c.config.StopSignal != 15 {
+		podmanArgs = append(podmanArgs, "--stop-signal", strconv.FormatUint(uint64(c.config.StopSignal), 10))
+	}
+	if c.config.StopTimeout != c.runtime.config.Engine.StopTimeout {
+		podmanArgs = append(podmanArgs, "--stop-timeout", strconv.FormatUint(uint64(c.config.StopTimeout), 10))
+	}
+
+	if hc := c.config.HealthCheckConfig; hc != nil && len(hc.Test) > 0 && (imgData == nil || !reflect.DeepEqual(hc, imgData.HealthCheck)) {
+		switch hc.Test[0] {
+		case define.HealthConfigTestNone:
+			podmanArgs = append(podmanArgs, "--no-healthcheck")
+		case define.HealthConfigTestCmdShell:
+			podmanArgs = append(podmanArgs, "--health-cmd", strings.Join(hc.Test[1:], " "))
+		case define.HealthConfigTestCmd:
+			cmd, err := json.Marshal(hc.Test[1:])
+			if err == nil {
+				podmanArgs = append(podmanArgs, "--health-cmd", string(cmd))
+			}
+		}
+		if hc.Test[0] != define.HealthConfigTestNone {
+			durations := []struct {
+				flag, def string
+				value     time.Duration
+			}{
+				{"--health-interval", define.DefaultHealthCheckInterval, hc.Interval},
+				{"--health-timeout", define.DefaultHealthCheckTimeout, hc.Timeout},
+				{"--health-start-period", define.DefaultHealthCheckStartPeriod, hc.StartPeriod},
+			}
+			for _, d := range durations {
+				if d.value.String() != d.def {
+					podmanArgs = append(podmanArgs, d.flag, d.value.String())
+				}
+			}
+			if hc.Retries != 0 && uint(hc.Retries) != define.DefaultHealthCheckRetries {
+				podmanArgs = append(podmanArgs, "--health-retries", strconv.Itoa(hc.Retries))
+			}
+		}
+	}
+
+	hooks := []struct {
+		flag    string
+		command []string
+	}{
+		{"--hook-post-start", c.config.PostStartHook},
+		{"--hook-pre-stop", c.config.PreStopHook},
+	}
+	for _, hook := range hooks {
+		if len(hook.command) == 0 {
+			continue
+		}
+		if cmd, err := json.Marshal(hook.command); err == nil {
+			podmanArgs = append(podmanArgs, hook.flag, string(cmd))
+		}
+	}
+
+	if c.config.Spec.Linux != nil && c.config.Spec.Linux.Resources != nil {
+		resources := c.config.Spec.Linux.Resources
+		if resources.Memory != nil && resources.Memory.Limit != nil && *resources.Memory.Limit > 0 {
+			podmanArgs = append(podmanArgs, "--memory", strconv.FormatInt(*resources.Memory.Limit, 10))
+		}
+		if resources.CPU != nil && resources.CPU.Quota != nil && *resources.CPU.Quota > 0 && resources.CPU.Period != nil && *resources.CPU.Period > 0 {
+			cpus := float64(*resources.CPU.Quota) / float64(*resources.CPU.Period)
+			podmanArgs = append(podmanArgs, "--cpus", strconv.FormatFloat(cpus, 'f', -1, 64))
+		}
+	}
+	return podmanArgs
+}
+
+// GenerateForQuadlet generates a quadlet .kube unit running the pod from
+// the given Kubernetes YAML file.
+func (p *Pod) GenerateForQuadlet(yamlFile string) (*parser.UnitFile, error) {
+	unit := parser.NewUnitFile()
+	unit.Filename = p.Name() + ".kube"
+	unit.Add(quadlet.KubeGroup, quadlet.KeyYaml, escapeQuadletValue(yamlFile))
+
+	if p.HasInfraContainer() {
+		infra, err := p.InfraContainer()
+		if err != nil {
+			return nil, err
+		}
+		if infra.config.NetMode.IsBridge() {
+			networks, err := infra.networks()
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(networks))
+			for name := range networks {
+				if name == p.runtime.config.Network.DefaultNetwork {
+					continue
+				}
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				unit.Add(quadlet.KubeGroup, quadlet.KeyNetwork, escapeQuadletValue(quadletUnitRef(name, ".network")))
+			}
+		}
+	}
+
+	unit.Add(quadlet.InstallGroup, "WantedBy", "default.target")
+	return unit, nil
+}
+
+// GenerateForQuadlet generates a quadlet .volume unit from a libpod volume.
+func (v *Volume) GenerateForQuadlet() (*parser.UnitFile, error) {
+	if v.UsesVolumeDriver() {
+		return nil, fmt.Errorf("volume %s uses volume driver %s, only local volumes can be converted to quadlets: %w", v.Name(), v.Driver(), define.ErrNotImplemented)
+	}
+
+	unit := parser.NewUnitFile()
+	unit.Filename = quadletUnitRef(v.Name(), ".volume")
+	group := quadlet.VolumeGroup
+
+	options := v.Options()
+	if device := options["device"]; device != "" {
+		unit.Add(group, quadlet.KeyDevice, escapeQuadletValue(device))
+	}
+	if devType := options["type"]; devType != "" {
+		unit.Add(group, quadlet.KeyType, escapeQuadletValue(devType))
+	}
+	if _, ok := options["nocopy"]; ok {
+		unit.Add(group, quadlet.KeyCopy, "false")
+	} else if _, ok := options["copy"]; ok {
+		unit.Add(group, quadlet.KeyCopy, "true")
+	}
+	if _, ok := options["NOQUOTA"]; ok {
+		return nil, fmt.Errorf("volume %s: option noquota cannot be represented in a quadlet: %w", v.Name(), define.ErrNotImplemented)
+	}
+
+	var mountOpts []string
+	for _, opt := range strings.Split(options["o"], ",") {
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "":
+		case "uid":
+			unit.Add(group, quadlet.KeyUser, value)
+		case "gid":
+			unit.Add(group, quadlet.KeyGroup, value)
+		default:
+			mountOpts = append(mountOpts, opt)
+		}
+	}
+	if len(mountOpts) > 0 {
+		if options["device"] == "" {
+			return nil, fmt.Errorf("volume %s: mount options %q without a device cannot be represented in a quadlet: %w", v.Name(), strings.Join(mountOpts, ","), define.ErrNotImplemented)
+		}
+		unit.Add(group, quadlet.KeyOptions, escapeQuadletValue(strings.Join(mountOpts, ",")))
+	}
+
+	addQuadletKeyVals(unit, group, quadlet.KeyLabel, v.Labels())
+
+	unit.Add(quadlet.InstallGroup, "WantedBy", "default.target")
+	return unit, nil
+}
+
+// GenerateNetworkForQuadlet generates a quadlet .network unit from a
+// network.
+func GenerateNetworkForQuadlet(network *types.Network) (*parser.UnitFile, error) {
+	unit := parser.NewUnitFile()
+	unit.Filename = quadletUnitRef(network.Name, ".network")
+	group := quadlet.NetworkGroup
+
+	if network.Driver != types.BridgeNetworkDriver {
+		unit.Add(group, quadlet.KeyNetworkDriver, network.Driver)
+	}
+	if !network.DNSEnabled && network.Driver == types.BridgeNetworkDriver {
+		unit.Add(group, quadlet.KeyNetworkDisableDNS, "true")
+	}
+	// Ranges are matched to subnets by position, only write them when
+	// every subnet before has one as well.
+	writeRanges := true
+	for _, subnet := range network.Subnets {
+		unit.Add(group, quadlet.KeyNetworkSubnet, subnet.Subnet.String())
+		if subnet.Gateway != nil {
+			unit.Add(group, quadlet.KeyNetworkGateway, subnet.Gateway.String())
+		}
+		if subnet.LeaseRange != nil && writeRanges {
+			ipRange, err := leaseRangeToCIDR(subnet.LeaseRange)
+			if err != nil {
+				return nil, fmt.Errorf("network %s: %w", network.Name, err)
+			}
+			unit.Add(group, quadlet.KeyNetworkIPRange, ipRange)
+		} else {
+			writeRanges = false
+		}
+	}
+	if network.IPv6Enabled {
+		unit.Add(group, quadlet.KeyNetworkIPv6, "true")
+	}
+	if network.Internal {
+		unit.Add(group, quadlet.KeyNetworkInternal, "true")
+	}
+	if driver := network.IPAMOptions[types.Driver]; driver != "" && driver != types.HostLocalIPAMDriver {
+		unit.Add(group, quadlet.KeyNetworkIPAMDriver, driver)
+	}
+
+	options := make(map[string]string, len(network.Options)+1)
+	for k, v := range network.Options {
+		options[k] = v
+	}
+	if network.NetworkInterface != "" && (network.Driver == types.MacVLANNetworkDriver || network.Driver == types.IPVLANNetworkDriver) {
+		options["parent"] = network.NetworkInterface
+	}
+	addQuadletKeyVals(unit, group, quadlet.KeyNetworkOptions, options)
+	addQuadletKeyVals(unit, group, quadlet.KeyLabel, network.Labels)
+
+	unit.Add(quadlet.InstallGroup, "WantedBy", "default.target")
+	return unit, nil
+}
+
+// leaseRangeToCIDR converts a lease range back into the CIDR notation used
+// by --ip-range.
+func leaseRangeToCIDR(leaseRange *types.LeaseRange) (string, error) {
+	start, end := leaseRange.StartIP, leaseRange.EndIP
+	bits := 128
+	if start.To4() != nil {
+		start, end = start.To4(), end.To4()
+		bits = 32
+	} else {
+		start, end = start.To16(), end.To16()
+	}
+	if start == nil || end == nil {
+		return "", fmt.Errorf("invalid lease range %s-%s", leaseRange.StartIP, leaseRange.EndIP)
+	}
+	if start.Equal(end) {
+		return fmt.Sprintf("%s/%d", start, bits), nil
+	}
+	// The range starts after the network address and ends at the broadcast
+	// address, find the network matching both.
+	first := new(big.Int).Sub(new(big.Int).SetBytes(start), big.NewInt(1))
+	last := new(big.Int).SetBytes(end)
+	for ones := bits - 1; ones >= 0; ones-- {
+		network := new(big.Int).SetBytes(start.Mask(net.CIDRMask(ones, bits)))
+		size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+		broadcast := new(big.Int).Sub(new(big.Int).Add(network, size), big.NewInt(1))
+		if network.Cmp(first) == 0 && broadcast.Cmp(last) == 0 {
+			return fmt.Sprintf("%s/%d", net.IP(first.FillBytes(make([]byte, len(start)))), ones), nil
+		}
+	}
+	return "", fmt.Errorf("lease range %s-%s cannot be expressed as CIDR", leaseRange.StartIP, leaseRange.EndIP)
+}
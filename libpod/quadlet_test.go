@@ -0,0 +1,179 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/systemd/parser"
+	"github.com/containers/podman/v4/pkg/systemd/quadlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reparseQuadlet writes the unit and parses it again, like quadlet reads
+// the file from disk.
+func reparseQuadlet(t *testing.T, unit *parser.UnitFile) *parser.UnitFile {
+	content, err := unit.ToString()
+	require.NoError(t, err)
+	parsed := parser.NewUnitFile()
+	require.NoError(t, parsed.Parse(content))
+	parsed.Filename = unit.Filename
+	return parsed
+}
+
+// execStart returns the podman arguments of the ExecStart line of a service
+// generated by quadlet.
+func execStart(t *testing.T, service *parser.UnitFile) []string {
+	args, ok := service.LookupLastArgs(quadlet.ServiceGroup, "ExecStart")
+	require.True(t, ok)
+	require.NotEmpty(t, args)
+	return args[1:]
+}
+
+// assertArgs checks that args contains want as consecutive arguments.
+func assertArgs(t *testing.T, args []string, want ...string) {
+	for i := 0; i+len(want) <= len(args); i++ {
+		if assert.ObjectsAreEqual(want, args[i:i+len(want)]) {
+			return
+		}
+	}
+	t.Errorf("%q does not contain %q", args, want)
+}
+
+func TestGenerateQuadletContainer(t *testing.T) {
+	r, _ := getFakeRuntime(t)
+	ctr := getFakeContainer(t, r,
+		WithName("web"),
+		WithCommand([]string{"sleep", "100%"}),
+		WithEntrypoint([]string{"/bin/sh", "-c"}),
+		WithLabels(map[string]string{"app": "web server"}),
+		WithUser("1000:100"),
+		WithStopSignal(syscall.SIGINT),
+		WithTimezone("UTC"),
+		WithSdNotifyMode(define.SdNotifyModeContainer),
+		WithRestartPolicy(define.RestartPolicyAlways),
+		WithNamedVolumes([]*ContainerNamedVolume{{Name: "systemd-data", Dest: "/data", Options: []string{"ro"}}}),
+		WithUserVolumes([]string{"/data"}),
+		WithPreStopHook([]string{"/bin/drain", "--wait"}),
+	)
+
+	unit, err := ctr.GenerateForQuadlet(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "web.container", unit.Filename)
+	restart, _ := unit.Lookup(quadlet.ServiceGroup, "Restart")
+	assert.Equal(t, "always", restart)
+
+	service, err := quadlet.ConvertContainer(reparseQuadlet(t, unit), false)
+	require.NoError(t, err)
+	args := execStart(t, service)
+	assert.Equal(t, "run", args[0])
+	assertArgs(t, args, "--name=web")
+	assertArgs(t, args, "--tz=UTC")
+	// Containers default to --sdnotify=container, which is not carried
+	// over as services usually do not notify.
+	assertArgs(t, args, "--sdnotify=conmon")
+	assertArgs(t, args, "--user", "1000:100")
+	// The fake container maps root to the current user.
+	assertArgs(t, args, fmt.Sprintf("--uidmap=0:%d:1", os.Getuid()), fmt.Sprintf("--gidmap=0:%d:1", os.Getgid()))
+	assertArgs(t, args, "-v", "systemd-data:/data:ro")
+	assertArgs(t, args, "--label", "app=web server")
+	assertArgs(t, args, "--entrypoint", `["/bin/sh","-c"]`)
+	assertArgs(t, args, "--stop-signal", "2")
+	assertArgs(t, args, "--hook-pre-stop", `["/bin/drain","--wait"]`)
+	// The rootfs comes last, followed by the command. Specifiers are
+	// escaped for systemd.
+	assertArgs(t, args, "--rootfs", ctr.config.Rootfs, "sleep", "100%%")
+
+	requires := service.LookupAll(quadlet.UnitGroup, "Requires")
+	assert.Contains(t, requires, "data-volume.service")
+}
+
+func TestGenerateQuadletInfraContainer(t *testing.T) {
+	r, _ := getFakeRuntime(t)
+	ctr := getFakeContainer(t, r)
+	ctr.config.IsInfra = true
+
+	_, err := ctr.GenerateForQuadlet(context.Background())
+	assert.ErrorIs(t, err, define.ErrNotImplemented)
+}
+
+func TestGenerateQuadletVolume(t *testing.T) {
+	r, _ := getFakeRuntime(t)
+	vol, err := r.NewVolume(context.Background(),
+		WithVolumeName("systemd-data"),
+		WithVolumeLabels(map[string]string{"app": "web"}),
+		WithVolumeOptions(map[string]string{"o": "uid=1000,gid=100", "nocopy": ""}),
+	)
+	require.NoError(t, err)
+
+	unit, err := vol.GenerateForQuadlet()
+	require.NoError(t, err)
+	assert.Equal(t, "data.volume", unit.Filename)
+
+	service, err := quadlet.ConvertVolume(reparseQuadlet(t, unit), unit.Filename)
+	require.NoError(t, err)
+	args := execStart(t, service)
+	assert.Equal(t, []string{"volume", "create", "--ignore", "--opt", "nocopy", "--opt", "o=uid=1000,gid=100", "--label", "app=web", "systemd-data"}, args)
+}
+
+func TestGenerateQuadletNetwork(t *testing.T) {
+	_, subnet, err := net.ParseCIDR("10.89.5.0/24")
+	require.NoError(t, err)
+	network := &types.Network{
+		Name:   "systemd-backend",
+		Driver: types.BridgeNetworkDriver,
+		Subnets: []types.Subnet{{
+			Subnet:  types.IPNet{IPNet: *subnet},
+			Gateway: net.ParseIP("10.89.5.1"),
+			LeaseRange: &types.LeaseRange{
+				StartIP: net.ParseIP("10.89.5.129"),
+				EndIP:   net.ParseIP("10.89.5.255"),
+			},
+		}},
+		Internal:    true,
+		IPAMOptions: map[string]string{types.Driver: types.HostLocalIPAMDriver},
+		Options:     map[string]string{"mtu": "1500"},
+		Labels:      map[string]string{"tier": "db"},
+	}
+
+	unit, err := GenerateNetworkForQuadlet(network)
+	require.NoError(t, err)
+	assert.Equal(t, "backend.network", unit.Filename)
+
+	service, err := quadlet.ConvertNetwork(reparseQuadlet(t, unit), unit.Filename)
+	require.NoError(t, err)
+	args := execStart(t, service)
+	assert.Equal(t, []string{
+		"network", "create", "--ignore", "--disable-dns",
+		"--subnet=10.89.5.0/24", "--gateway=10.89.5.1", "--ip-range=10.89.5.128/25",
+		"--internal", "--opt", "mtu=1500", "--label", "tier=db", "systemd-backend",
+	}, args)
+}
+
+func TestLeaseRangeToCIDR(t *testing.T) {
+	tests := []struct {
+		start, end, cidr string
+	}{
+		{"10.0.0.1", "10.0.0.255", "10.0.0.0/24"},
+		{"10.0.0.129", "10.0.0.255", "10.0.0.128/25"},
+		{"10.0.0.7", "10.0.0.7", "10.0.0.7/32"},
+		{"fd00::1", "fd00::ffff", "fd00::/112"},
+	}
+	for _, tt := range tests {
+		cidr, err := leaseRangeToCIDR(&types.LeaseRange{StartIP: net.ParseIP(tt.start), EndIP: net.ParseIP(tt.end)})
+		require.NoError(t, err)
+		assert.Equal(t, tt.cidr, cidr)
+	}
+
+	_, err := leaseRangeToCIDR(&types.LeaseRange{StartIP: net.ParseIP("10.0.0.10"), EndIP: net.ParseIP("10.0.0.20")})
+	assert.Error(t, err)
+}
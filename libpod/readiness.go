@@ -0,0 +1,182 @@
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/systemd/notifyproxy"
+	"github.com/coreos/go-systemd/v22/daemon"
+	"github.com/sirupsen/logrus"
+)
+
+// ReadinessCheck runs the readiness check of the container and returns
+// whether the container is ready afterwards. Unlike health checks, a single
+// failure or success does not change the readiness of a container, see
+// define.ReadinessCheck.
+func (r *Runtime) ReadinessCheck(ctx context.Context, name string) (bool, error) {
+	ctr, err := r.LookupContainer(name)
+	if err != nil {
+		return false, fmt.Errorf("unable to look up %s to perform a readiness check: %w", name, err)
+	}
+
+	state, err := ctr.State()
+	if err != nil {
+		return false, err
+	}
+	if state != define.ContainerStateRunning {
+		return false, fmt.Errorf("container %s is not running: %w", ctr.ID(), define.ErrCtrStateInvalid)
+	}
+	if ctr.config.ReadinessCheckConfig == nil {
+		return false, fmt.Errorf("container %s has no defined readiness check", ctr.ID())
+	}
+
+	passed, err := ctr.runReadinessCheck()
+	if err != nil {
+		return false, err
+	}
+	return ctr.updateReadiness(passed)
+}
+
+// readinessCheckCommand returns the command to execute for the given
+// healthcheck test.
+func readinessCheckCommand(test []string) []string {
+	if len(test) < 1 {
+		return nil
+	}
+	switch test[0] {
+	case "", define.HealthConfigTestNone:
+		return nil
+	case define.HealthConfigTestCmd:
+		return test[1:]
+	case define.HealthConfigTestCmdShell:
+		return []string{"/bin/sh", "-c", strings.Join(test[1:], " ")}
+	default:
+		return test
+	}
+}
+
+// runReadinessCheck executes the readiness check command in the container
+// and returns whether it passed.
+func (c *Container) runReadinessCheck() (bool, error) {
+	config := c.config.ReadinessCheckConfig
+	command := readinessCheckCommand(config.Test)
+	if len(command) < 1 || command[0] == "" {
+		return false, fmt.Errorf("container %s has no defined readiness check", c.ID())
+	}
+
+	rPipe, wPipe, err := os.Pipe()
+	if err != nil {
+		return false, fmt.Errorf("unable to create pipe for readiness check session: %w", err)
+	}
+	defer rPipe.Close()
+	go func() {
+		_, _ = io.Copy(io.Discard, rPipe)
+	}()
+
+	streams := new(define.AttachStreams)
+	streams.OutputStream = wPipe
+	streams.ErrorStream = wPipe
+	streams.AttachOutput = true
+	streams.AttachError = true
+
+	logrus.Debugf("Executing readiness check command %s for %s", strings.Join(command, " "), c.ID())
+	execConfig := new(ExecConfig)
+	execConfig.Command = command
+	timeStart := time.Now()
+	exitCode, err := c.exec(execConfig, streams, nil, true)
+	wPipe.Close()
+	if err != nil {
+		logrus.Debugf("Readiness check of container %s failed: %v", c.ID(), err)
+		return false, nil
+	}
+	if config.Timeout > 0 && time.Since(timeStart) > config.Timeout {
+		logrus.Debugf("Readiness check of container %s exceeded timeout of %s", c.ID(), config.Timeout)
+		return false, nil
+	}
+	return exitCode == 0, nil
+}
+
+// updateReadiness records the result of a readiness check and returns whether
+// the container is ready.
+func (c *Container) updateReadiness(passed bool) (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	// The container may have been stopped while the check was running.
+	if c.state.State != define.ContainerStateRunning {
+		return false, nil
+	}
+
+	config := c.config.ReadinessCheckConfig
+	if passed {
+		c.state.ReadinessCheckFailureCount = 0
+		c.state.ReadinessCheckSuccessCount++
+		if c.state.ReadinessCheckSuccessCount >= config.Successes {
+			c.state.Ready = true
+		}
+	} else {
+		c.state.ReadinessCheckSuccessCount = 0
+		if time.Now().Before(c.state.StartedTime.Add(config.StartPeriod)) {
+			logrus.Debugf("Readiness check of container %s failed in start-period", c.ID())
+		} else {
+			c.state.ReadinessCheckFailureCount++
+			if c.state.ReadinessCheckFailureCount >= config.Retries {
+				c.state.Ready = false
+			}
+		}
+	}
+
+	if err := c.save(); err != nil {
+		return false, err
+	}
+	return c.isReady(), nil
+}
+
+// notifyWhenReady waits for the container to become ready and sends the
+// READY message; only used for --sdnotify=ready.
+// Must be called with the container locked, which is released while waiting.
+func (c *Container) notifyWhenReady() error {
+	if c.config.SdNotifyMode != define.SdNotifyModeReady {
+		return nil
+	}
+
+	if !c.batched {
+		c.lock.Unlock()
+		defer func() {
+			c.lock.Lock()
+			if err := c.syncContainer(); err != nil {
+				logrus.Errorf("Syncing container %s state: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	conditions := []string{define.ReadinessCheckReady, define.ContainerStateStopped.String(), define.ContainerStateExited.String()}
+	if _, err := c.WaitForConditionNamesWithInterval(context.Background(), DefaultWaitInterval, conditions...); err != nil {
+		return err
+	}
+	ready, err := c.Ready()
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return fmt.Errorf("container %s exited before becoming ready", c.ID())
+	}
+
+	if err := notifyproxy.SendMessage(c.config.SdNotifySocket, daemon.SdNotifyReady); err != nil {
+		logrus.Errorf("Sending READY message after turning ready: %s", err.Error())
+	} else {
+		logrus.Debugf("Notify sent successfully")
+	}
+	return nil
+}
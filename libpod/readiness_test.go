@@ -0,0 +1,104 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessCheck(t *testing.T) {
+	// Do not create systemd timers for the readiness check.
+	t.Setenv("DISABLE_HC_SYSTEMD", "true")
+	r, fake := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r, WithReadinessCheck(&define.ReadinessCheck{
+		Schema2HealthConfig: manifest.Schema2HealthConfig{
+			Test:    []string{define.HealthConfigTestCmdShell, "test -e /tmp/ready"},
+			Retries: 2,
+		},
+		Successes: 2,
+	}))
+
+	exitCode := 1
+	var cmd []string
+	fake.ExecFunc = func(ctr *Container, options *ExecOptions) int {
+		cmd = options.Cmd
+		return exitCode
+	}
+
+	_, err := r.ReadinessCheck(ctx, ctr.ID())
+	assert.ErrorIs(t, err, define.ErrCtrStateInvalid)
+
+	require.NoError(t, ctr.Start(ctx, false))
+	ready, err := ctr.Ready()
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	ready, err = r.ReadinessCheck(ctx, ctr.ID())
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, []string{"/bin/sh", "-c", "test -e /tmp/ready"}, cmd)
+
+	// Two consecutive successes are required.
+	exitCode = 0
+	ready, err = r.ReadinessCheck(ctx, ctr.ID())
+	require.NoError(t, err)
+	assert.False(t, ready)
+	ready, err = r.ReadinessCheck(ctx, ctr.ID())
+	require.NoError(t, err)
+	assert.True(t, ready)
+
+	// A single failure does not change the readiness.
+	exitCode = 1
+	ready, err = r.ReadinessCheck(ctx, ctr.ID())
+	require.NoError(t, err)
+	assert.True(t, ready)
+	ready, err = r.ReadinessCheck(ctx, ctr.ID())
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	// A restarted container has to become ready again.
+	exitCode = 0
+	_, err = r.ReadinessCheck(ctx, ctr.ID())
+	require.NoError(t, err)
+	ready, err = r.ReadinessCheck(ctx, ctr.ID())
+	require.NoError(t, err)
+	assert.True(t, ready)
+	require.NoError(t, ctr.RestartWithTimeout(ctx, 0))
+	ready, err = ctr.Ready()
+	require.NoError(t, err)
+	assert.False(t, ready)
+}
+
+func TestReadyWithoutReadinessCheck(t *testing.T) {
+	r, _ := getFakeRuntime(t)
+	ctx := context.Background()
+	ctr := getFakeContainer(t, r)
+
+	ready, err := ctr.Ready()
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	require.NoError(t, ctr.Start(ctx, false))
+	ready, err = ctr.Ready()
+	require.NoError(t, err)
+	assert.True(t, ready)
+
+	_, err = r.ReadinessCheck(ctx, ctr.ID())
+	assert.ErrorContains(t, err, "has no defined readiness check")
+
+	_, err = ctr.WaitForConditionNamesWithInterval(ctx, DefaultWaitInterval, define.ReadinessCheckReady)
+	require.NoError(t, err)
+
+	require.NoError(t, ctr.Stop())
+	ready, err = ctr.Ready()
+	require.NoError(t, err)
+	assert.False(t, ready)
+}
@@ -488,6 +488,18 @@ func makeRuntime(runtime *Runtime) (retErr error) {
 		runtime.ociRuntimes[name] = ociRuntime
 	}
 
+	// The fake runtime simulates containers in-process for testing, it is
+	// only set up when selected as default runtime.
+	if runtime.config.Engine.OCIRuntime == FakeOCIRuntimeName {
+		if _, ok := runtime.ociRuntimes[FakeOCIRuntimeName]; !ok {
+			ociRuntime, err := newFakeOCIRuntime(FakeOCIRuntimeName, runtime)
+			if err != nil {
+				return err
+			}
+			runtime.ociRuntimes[FakeOCIRuntimeName] = ociRuntime
+		}
+	}
+
 	// Do we have a default OCI runtime?
 	if runtime.config.Engine.OCIRuntime != "" {
 		// If the string starts with / it's a path to a runtime
@@ -736,6 +748,9 @@ func (r *Runtime) libimageEvents() {
 				if err := r.eventer.Write(e); err != nil {
 					logrus.Errorf("Unable to write image event: %q", err)
 				}
+				if libimageEvent.Type == libimage.EventTypeImagePull || libimageEvent.Type == libimage.EventTypeImageTag {
+					r.setImageAdded(libimageEvent)
+				}
 			}
 
 			if sawShutdown {
@@ -592,6 +592,10 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		return nil, err
 	}
 
+	if ctr.config.RootfsImageID != "" {
+		r.setImageLastUsed(ctr.config.RootfsImageID)
+	}
+
 	if ctr.runtime.config.Engine.EventsContainerCreateInspectData {
 		if err := ctr.newContainerEventWithInspectData(events.Create, true); err != nil {
 			return nil, err
@@ -1216,8 +1220,9 @@ func (r *Runtime) GetExecSessionContainer(id string) (*Container, error) {
 }
 
 // PruneContainers removes stopped and exited containers from localstorage.  A set of optional filters
-// can be provided to be more granular.
-func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter) ([]*reports.PruneReport, error) {
+// can be provided to be more granular.  If dryRun is set, the containers are
+// only reported and not removed.
+func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter, dryRun bool) ([]*reports.PruneReport, error) {
 	preports := make([]*reports.PruneReport, 0)
 	// We add getting the exited and stopped containers via a filter
 	containerStateFilter := func(c *Container) bool {
@@ -1251,6 +1256,11 @@ func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter) ([]*reports.Pru
 			preports = append(preports, report)
 			continue
 		}
+		if dryRun {
+			report.Size = (uint64)(size)
+			preports = append(preports, report)
+			continue
+		}
 		var time *uint
 		err = r.RemoveContainer(context.Background(), c, false, false, time)
 		if err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	buildahDefine "github.com/containers/buildah/define"
 	"github.com/containers/buildah/imagebuildah"
@@ -99,6 +100,98 @@ func (r *Runtime) IsExternalContainerCallback(_ context.Context) libimage.IsExte
 	}
 }
 
+// imageLastUsedKey is the name of the big data item of an image recording
+// when it was last used to create a container.
+const imageLastUsedKey = "podman-last-used"
+
+// imageAddedKey is the name of the big data item of an image recording when
+// it was last pulled or tagged.
+const imageAddedKey = "podman-added"
+
+// ImageLastUsed returns the time the given image was last used to create a
+// container. The zero time is returned if the image was never used.
+func (r *Runtime) ImageLastUsed(id string) (time.Time, error) {
+	return r.imageTime(id, imageLastUsedKey)
+}
+
+// ImageAdded returns the time the given image was last pulled or tagged. The
+// zero time is returned if this is not known, e.g. for images pulled by older
+// versions.
+func (r *Runtime) ImageAdded(id string) (time.Time, error) {
+	return r.imageTime(id, imageAddedKey)
+}
+
+func (r *Runtime) imageTime(id, key string) (time.Time, error) {
+	data, err := r.store.ImageBigData(id, key)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(data))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing %s time of image %s: %w", key, id, err)
+	}
+	return t, nil
+}
+
+// setImageLastUsed records that the given image was used to create a
+// container now. Failures are only logged as they must not prevent creating
+// containers, e.g. from read-only images in additional stores.
+func (r *Runtime) setImageLastUsed(id string) {
+	r.setImageTime(id, imageLastUsedKey, time.Now())
+}
+
+// setImageAdded records when an image was pulled or tagged. Pull events only
+// carry the pulled name, the image is looked up by it then.
+func (r *Runtime) setImageAdded(e *libimage.Event) {
+	id := e.ID
+	if id == "" {
+		img, _, err := r.libimageRuntime.LookupImage(e.Name, nil)
+		if err != nil {
+			logrus.Debugf("Unable to record pull of image %s: %v", e.Name, err)
+			return
+		}
+		id = img.ID()
+	}
+	r.setImageTime(id, imageAddedKey, e.Time)
+}
+
+func (r *Runtime) setImageTime(id, key string, t time.Time) {
+	data := []byte(t.UTC().Format(time.RFC3339Nano))
+	if err := r.store.SetImageBigData(id, key, data, nil); err != nil {
+		logrus.Debugf("Unable to record %s time of image %s: %v", key, id, err)
+	}
+}
+
+// ImageLayerSizes returns the sizes of the layers of the given image by the
+// IDs of the layers.
+func (r *Runtime) ImageLayerSizes(id string) (map[string]int64, error) {
+	img, err := r.store.Image(id)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64)
+	for layerID := img.TopLayer; layerID != ""; {
+		layer, err := r.store.Layer(layerID)
+		if err != nil {
+			return nil, err
+		}
+		// the uncompressed size is only valid along with its digest
+		size := layer.UncompressedSize
+		if layer.UncompressedDigest == "" {
+			size, err = r.store.DiffSize("", layer.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		sizes[layer.ID] = size
+		layerID = layer.Parent
+	}
+	return sizes, nil
+}
+
 // newBuildEvent creates a new event based on completion of a built image
 func (r *Runtime) newImageBuildCompleteEvent(idOrName string) {
 	e := events.NewEvent(events.Build)
@@ -159,7 +159,8 @@ func (r *Runtime) GetRunningPods() ([]*Pod, error) {
 }
 
 // PrunePods removes unused pods and their containers from local storage.
-func (r *Runtime) PrunePods(ctx context.Context) (map[string]error, error) {
+// If dryRun is set, the pods are only reported and not removed.
+func (r *Runtime) PrunePods(ctx context.Context, dryRun bool) (map[string]error, error) {
 	response := make(map[string]error)
 	states := []string{define.PodStateStopped, define.PodStateExited}
 	filterFunc := func(p *Pod) bool {
@@ -179,6 +180,10 @@ func (r *Runtime) PrunePods(ctx context.Context) (map[string]error, error) {
 		return response, nil
 	}
 	for _, pod := range pods {
+		if dryRun {
+			response[pod.ID()] = nil
+			continue
+		}
 		var timeout *uint
 		err := r.removePod(context.TODO(), pod, true, false, timeout)
 		response[pod.ID()] = err
@@ -66,6 +66,9 @@ func (r *Runtime) NewPod(ctx context.Context, p specgen.PodSpecGenerator, option
 	if pod.HasInfraContainer() && !pod.SharesNamespaces() {
 		logrus.Infof("Pod has an infra container, but shares no namespaces")
 	}
+	if pod.config.NetworkPolicy != nil && (!pod.HasInfraContainer() || !pod.SharesNet()) {
+		return nil, fmt.Errorf("pods must have an infra container sharing the network namespace to use a network policy: %w", define.ErrInvalidArg)
+	}
 
 	// Unless the user has specified a name, use a randomly generated one.
 	// Note that name conflicts may occur (see #11735), so we need to loop.
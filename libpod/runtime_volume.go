@@ -115,8 +115,9 @@ func (r *Runtime) GetAllVolumes() ([]*Volume, error) {
 	return r.state.AllVolumes()
 }
 
-// PruneVolumes removes unused volumes from the system
-func (r *Runtime) PruneVolumes(ctx context.Context, filterFuncs []VolumeFilter) ([]*reports.PruneReport, error) {
+// PruneVolumes removes unused volumes from the system. If dryRun is set, the
+// volumes are only reported and not removed.
+func (r *Runtime) PruneVolumes(ctx context.Context, filterFuncs []VolumeFilter, dryRun bool) ([]*reports.PruneReport, error) {
 	preports := make([]*reports.PruneReport, 0)
 	vols, err := r.Volumes(filterFuncs...)
 	if err != nil {
@@ -131,6 +132,18 @@ func (r *Runtime) PruneVolumes(ctx context.Context, filterFuncs []VolumeFilter)
 		}
 		report.Size = volSize
 		report.Id = vol.Name()
+		if dryRun {
+			// Volumes in use are skipped, as RemoveVolume refuses
+			// to remove them.
+			deps, err := r.state.VolumeInUse(vol)
+			if err != nil {
+				report.Err = err
+			} else if len(deps) > 0 {
+				continue
+			}
+			preports = append(preports, report)
+			continue
+		}
 		var timeout *uint
 		if err := r.RemoveVolume(ctx, vol, false, timeout); err != nil {
 			if !errors.Is(err, define.ErrVolumeBeingUsed) && !errors.Is(err, define.ErrVolumeRemoved) {
@@ -13,10 +13,22 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/entities/reports"
 	"github.com/containers/podman/v4/pkg/domain/filters"
 	"github.com/containers/podman/v4/pkg/util"
+	"github.com/gorilla/schema"
 )
 
 func PruneContainers(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		DryRun bool `schema:"dryRun"`
+	}{}
+	// Only the libpod endpoint supports a dry run
+	if utils.IsLibpodRequest(r) {
+		if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+			utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+			return
+		}
+	}
 	filtersMap, err := util.PrepareFilters(r)
 	if err != nil {
 		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
@@ -33,7 +45,7 @@ func PruneContainers(w http.ResponseWriter, r *http.Request) {
 		filterFuncs = append(filterFuncs, generatedFunc)
 	}
 
-	report, err := PruneContainersHelper(r, filterFuncs)
+	report, err := PruneContainersHelper(r, filterFuncs, query.DryRun)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
@@ -66,10 +78,10 @@ func PruneContainers(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, payload)
 }
 
-func PruneContainersHelper(r *http.Request, filterFuncs []libpod.ContainerFilter) ([]*reports.PruneReport, error) {
+func PruneContainersHelper(r *http.Request, filterFuncs []libpod.ContainerFilter, dryRun bool) ([]*reports.PruneReport, error) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 
-	report, err := runtime.PruneContainers(filterFuncs)
+	report, err := runtime.PruneContainers(filterFuncs, dryRun)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,157 @@
+package compat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/image"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/auth"
+	"github.com/docker/distribution/registry/api/errcode"
+	v2 "github.com/docker/distribution/registry/api/v2"
+	dockerRegistry "github.com/docker/docker/api/types/registry"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// InspectDistribution queries the registry for the manifest of an image and
+// returns its descriptor along with the platforms it supports.
+func InspectDistribution(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	authConf, authfile, err := auth.GetCredentials(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer auth.RemoveAuthfile(authfile)
+
+	name := utils.GetName(r)
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("parsing image name %q: %w", name, err))
+		return
+	}
+	named = reference.TagNameOnly(named)
+	ref, err := docker.NewReference(named)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("parsing image name %q: %w", name, err))
+		return
+	}
+
+	sys := runtime.SystemContext()
+	sys.AuthFilePath = authfile
+	if authConf != nil {
+		sys.DockerAuthConfig = &types.DockerAuthConfig{
+			Username: authConf.Username,
+			Password: authConf.Password,
+		}
+		sys.DockerBearerRegistryToken = authConf.IdentityToken
+	}
+
+	distribution, err := inspectDistribution(r.Context(), sys, ref)
+	if err != nil {
+		utils.Error(w, distributionErrorStatus(err), err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, distribution)
+}
+
+// inspectDistribution fetches the manifest of ref from the registry.  The
+// platforms are read from the manifest list or, for single images, from the
+// image config.
+func inspectDistribution(ctx context.Context, sys *types.SystemContext, ref types.ImageReference) (*dockerRegistry.DistributionInspect, error) {
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, fmt.Errorf("reading image %q: %w", ref.DockerReference(), err)
+	}
+	defer src.Close()
+
+	manifestBytes, manifestType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("loading manifest %q: %w", ref.DockerReference(), err)
+	}
+	manifestDigest, err := manifest.Digest(manifestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	distribution := dockerRegistry.DistributionInspect{
+		Descriptor: imgspecv1.Descriptor{
+			MediaType: manifestType,
+			Digest:    manifestDigest,
+			Size:      int64(len(manifestBytes)),
+		},
+		Platforms: []imgspecv1.Platform{},
+	}
+
+	switch manifestType {
+	case manifest.DockerV2ListMediaType:
+		list, err := manifest.Schema2ListFromManifest(manifestBytes)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range list.Manifests {
+			distribution.Platforms = append(distribution.Platforms, imgspecv1.Platform{
+				Architecture: m.Platform.Architecture,
+				OS:           m.Platform.OS,
+				OSVersion:    m.Platform.OSVersion,
+				OSFeatures:   m.Platform.OSFeatures,
+				Variant:      m.Platform.Variant,
+			})
+		}
+	case imgspecv1.MediaTypeImageIndex:
+		index, err := manifest.OCI1IndexFromManifest(manifestBytes)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range index.Manifests {
+			if m.Platform != nil {
+				distribution.Platforms = append(distribution.Platforms, *m.Platform)
+			}
+		}
+	default:
+		img, err := image.FromUnparsedImage(ctx, sys, image.UnparsedInstance(src, nil))
+		if err != nil {
+			return nil, fmt.Errorf("parsing manifest %q: %w", ref.DockerReference(), err)
+		}
+		config, err := img.OCIConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reading image config %q: %w", ref.DockerReference(), err)
+		}
+		distribution.Platforms = append(distribution.Platforms, imgspecv1.Platform{
+			Architecture: config.Architecture,
+			OS:           config.OS,
+			OSVersion:    config.OSVersion,
+			OSFeatures:   config.OSFeatures,
+			Variant:      config.Variant,
+		})
+	}
+	return &distribution, nil
+}
+
+// distributionErrorStatus maps errors returned by the registry to the HTTP
+// status code Docker uses for the distribution endpoint.
+func distributionErrorStatus(err error) int {
+	var unauthorized docker.ErrUnauthorizedForCredentials
+	if errors.As(err, &unauthorized) {
+		return http.StatusUnauthorized
+	}
+	var registryErr errcode.Error
+	if errors.As(err, &registryErr) {
+		switch registryErr.Code {
+		case errcode.ErrorCodeUnauthorized, errcode.ErrorCodeDenied:
+			return http.StatusUnauthorized
+		case v2.ErrorCodeManifestUnknown, v2.ErrorCodeNameUnknown:
+			return http.StatusNotFound
+		}
+	}
+	return http.StatusInternalServerError
+}
@@ -95,10 +95,8 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 				e.Status = "delete"
 				e.Action = "delete"
 			}
-			if !utils.IsLibpodRequest(r) && e.Status == "died" {
-				e.Status = "die"
-				e.Action = "die"
-				e.Actor.Attributes["exitCode"] = e.Actor.Attributes["containerExitCode"]
+			if !utils.IsLibpodRequest(r) && e.Type == "container" {
+				toDockerContainerEvent(e)
 			}
 
 			if err := coder.Encode(e); err != nil {
@@ -110,3 +108,30 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+// toDockerContainerEvent rewrites the action of a container event to the
+// format used by Docker.  Docker appends the health status to health_status
+// events and the exec command to exec_create and exec_start events.
+func toDockerContainerEvent(e *entities.Event) {
+	var action string
+	switch events.Status(e.Status) {
+	case events.Exited:
+		action = "die"
+		e.Actor.Attributes["exitCode"] = e.Actor.Attributes["containerExitCode"]
+	case events.ExecDied:
+		action = "exec_die"
+		e.Actor.Attributes["exitCode"] = e.Actor.Attributes["containerExitCode"]
+	case events.ExecCreate:
+		action = "exec_create: " + e.Actor.Attributes["execCommand"]
+		delete(e.Actor.Attributes, "execCommand")
+	case events.Exec:
+		action = "exec_start: " + e.Actor.Attributes["execCommand"]
+		delete(e.Actor.Attributes, "execCommand")
+	case events.HealthStatus:
+		action = "health_status: " + e.HealthStatus
+	default:
+		return
+	}
+	e.Status = action
+	e.Action = action
+}
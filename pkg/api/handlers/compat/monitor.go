@@ -0,0 +1,102 @@
+package compat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/containers/common/pkg/cgroups"
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
+)
+
+// Monitor streams the resource usage of all running containers.  Every
+// interval a JSON array holding the stats of each running container is
+// written to the connection.
+func Monitor(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+
+	if rootless.IsRootless() {
+		if isV2, _ := cgroups.IsCgroup2UnifiedMode(); !isV2 {
+			utils.Error(w, http.StatusConflict, errors.New("monitoring resources only available for cgroup v2"))
+			return
+		}
+	}
+
+	query := struct {
+		Stream   bool `schema:"stream"`
+		Interval int  `schema:"interval"`
+	}{
+		Stream:   true,
+		Interval: 5,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Interval < 1 {
+		utils.Error(w, http.StatusBadRequest, errors.New("invalid interval, must be a positive number greater zero"))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	statsOptions := entities.ContainerStatsOptions{
+		Stream:   query.Stream,
+		Interval: query.Interval,
+	}
+
+	// Stats will stop if the connection is closed.
+	statsChan, err := containerEngine.ContainerStats(r.Context(), nil, statsOptions)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	// Wait for the first batch so that errors can still be reported with
+	// a proper status code.
+	report, ok := <-statsChan
+	if !ok {
+		utils.InternalServerError(w, errors.New("stats channel closed unexpectedly"))
+		return
+	}
+	if report.Error != nil {
+		utils.InternalServerError(w, report.Error)
+		return
+	}
+
+	flush := func() {}
+	if flusher, ok := w.(http.Flusher); ok {
+		flush = flusher.Flush
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+
+	for {
+		if err := coder.Encode(report.Stats); err != nil {
+			logrus.Errorf("Unable to encode stats: %v", err)
+			return
+		}
+		flush()
+
+		report, ok = <-statsChan
+		if !ok {
+			return
+		}
+		if report.Error != nil {
+			logrus.Errorf("Unable to get stats: %v", report.Error)
+			return
+		}
+	}
+}
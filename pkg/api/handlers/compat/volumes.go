@@ -276,7 +276,7 @@ func PruneVolumes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pruned, err := runtime.PruneVolumes(r.Context(), filterFuncs)
+	pruned, err := runtime.PruneVolumes(r.Context(), filterFuncs, false)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
@@ -16,6 +16,7 @@ import (
 	api "github.com/containers/podman/v4/pkg/api/types"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/containers/podman/v4/pkg/specgenutil"
 	"github.com/containers/podman/v4/pkg/util"
 	"github.com/gorilla/schema"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -402,12 +403,33 @@ func InitContainer(w http.ResponseWriter, r *http.Request) {
 func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		NetworkRateLimit string `schema:"networkRateLimit"`
+		NetworkDelay     string `schema:"networkDelay"`
+		NetworkLoss      string `schema:"networkLoss"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
 	ctr, err := runtime.LookupContainer(name)
 	if err != nil {
 		utils.ContainerNotFound(w, name, err)
 		return
 	}
 
+	updateShaping := query.NetworkRateLimit != "" || query.NetworkDelay != "" || query.NetworkLoss != ""
+	var shaping *define.NetworkShaping
+	if updateShaping {
+		shaping, err = specgenutil.ParseNetworkShaping(ctr.NetworkShaping(), query.NetworkRateLimit, query.NetworkDelay, query.NetworkLoss)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
 	options := &handlers.UpdateEntities{Resources: &specs.LinuxResources{}}
 	if err := json.NewDecoder(r.Body).Decode(&options.Resources); err != nil {
 		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("decode(): %w", err))
@@ -418,9 +440,81 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 		utils.InternalServerError(w, err)
 		return
 	}
+	if updateShaping {
+		if err := ctr.UpdateNetworkShaping(shaping); err != nil {
+			if errors.Is(err, define.ErrNetworkModeInvalid) || errors.Is(err, define.ErrInvalidArg) {
+				utils.Error(w, http.StatusBadRequest, err)
+				return
+			}
+			utils.InternalServerError(w, err)
+			return
+		}
+	}
 	utils.WriteResponse(w, http.StatusCreated, ctr.ID())
 }
 
+func AddContainerPorts(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Ports []string `schema:"ports"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if len(query.Ports) == 0 {
+		utils.Error(w, http.StatusBadRequest, errors.New("at least one port must be given"))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	name := utils.GetName(r)
+	report, err := containerEngine.ContainerPortAdd(r.Context(), name, entities.ContainerPortAddOptions{Ports: query.Ports})
+	if err != nil {
+		portUpdateError(w, name, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report.Ports)
+}
+
+func RemoveContainerPorts(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Ports []string `schema:"ports"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if len(query.Ports) == 0 {
+		utils.Error(w, http.StatusBadRequest, errors.New("at least one port must be given"))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	name := utils.GetName(r)
+	if err := containerEngine.ContainerPortRm(r.Context(), name, entities.ContainerPortRmOptions{Ports: query.Ports}); err != nil {
+		portUpdateError(w, name, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
+func portUpdateError(w http.ResponseWriter, name string, err error) {
+	switch {
+	case errors.Is(err, define.ErrNoSuchCtr):
+		utils.ContainerNotFound(w, name, err)
+	case errors.Is(err, define.ErrInvalidArg), errors.Is(err, define.ErrNetworkModeInvalid):
+		utils.Error(w, http.StatusBadRequest, err)
+	case errors.Is(err, define.ErrCtrStateInvalid):
+		utils.Error(w, http.StatusConflict, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+}
+
 func ShouldRestart(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	// Now use the ABI implementation to prevent us from having duplicate
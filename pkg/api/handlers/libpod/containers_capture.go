@@ -0,0 +1,81 @@
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/capture"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
+)
+
+// CaptureContainer streams the network traffic of a container in pcap format
+// until the client disconnects or the capture ends.
+func CaptureContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Interface string `schema:"interface"`
+		Filter    string `schema:"filter"`
+		Count     int    `schema:"count"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	stream := &captureStream{w: w}
+	options := entities.ContainerCaptureOptions{
+		Interface: query.Interface,
+		Filter:    query.Filter,
+		Count:     query.Count,
+		Output:    stream,
+	}
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	name := utils.GetName(r)
+	err := containerEngine.ContainerCapture(r.Context(), name, options)
+	if err != nil {
+		if stream.started {
+			logrus.Errorf("Unable to capture network traffic of container %s: %v", name, err)
+			return
+		}
+		switch {
+		case errors.Is(err, define.ErrNoSuchCtr):
+			utils.ContainerNotFound(w, name, err)
+		case errors.Is(err, define.ErrInvalidArg), errors.Is(err, define.ErrNetworkModeInvalid):
+			utils.Error(w, http.StatusBadRequest, err)
+		case errors.Is(err, define.ErrCtrStateInvalid):
+			utils.Error(w, http.StatusConflict, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+	}
+}
+
+// captureStream writes the pcap data to the response. The status is only
+// written with the pcap header so that errors occurring before the capture
+// started can still be reported. Every packet is flushed right away.
+type captureStream struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (c *captureStream) Write(p []byte) (int, error) {
+	if !c.started {
+		c.w.Header().Set("Content-Type", capture.ContentType)
+		c.w.WriteHeader(http.StatusOK)
+		c.started = true
+	}
+	n, err := c.w.Write(p)
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
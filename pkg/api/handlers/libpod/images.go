@@ -23,6 +23,7 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/infra/abi"
 	domainUtils "github.com/containers/podman/v4/pkg/domain/utils"
 	"github.com/containers/podman/v4/pkg/errorhandling"
+	"github.com/containers/podman/v4/pkg/trust"
 	"github.com/containers/podman/v4/pkg/util"
 	utils2 "github.com/containers/podman/v4/utils"
 	"github.com/containers/storage"
@@ -105,8 +106,11 @@ func PruneImages(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	query := struct {
-		All      bool `schema:"all"`
-		External bool `schema:"external"`
+		All         bool   `schema:"all"`
+		External    bool   `schema:"external"`
+		KeepStorage int64  `schema:"keepStorage"`
+		UntilUsed   string `schema:"untilUsed"`
+		DryRun      bool   `schema:"dryRun"`
 	}{
 		// override any golang type defaults
 	}
@@ -144,9 +148,12 @@ func PruneImages(w http.ResponseWriter, r *http.Request) {
 	imageEngine := abi.ImageEngine{Libpod: runtime}
 
 	pruneOptions := entities.ImagePruneOptions{
-		All:      query.All,
-		External: query.External,
-		Filter:   libpodFilters,
+		All:         query.All,
+		External:    query.External,
+		Filter:      libpodFilters,
+		KeepStorage: query.KeepStorage,
+		UntilUsed:   query.UntilUsed,
+		DryRun:      query.DryRun,
 	}
 	imagePruneReports, err := imageEngine.Prune(r.Context(), pruneOptions)
 	if err != nil {
@@ -631,3 +638,71 @@ func ImageScp(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteResponse(w, http.StatusOK, &reports.ScpReport{Id: rep.Names[0]})
 }
+
+// ShowTrust reports the trust policy of the system
+func ShowTrust(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		PolicyPath   string `schema:"policypath"`
+		Raw          bool   `schema:"raw"`
+		RegistryPath string `schema:"registrypath"`
+	}{
+		// This is where you can override the golang default value for one of fields
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	options := entities.ShowTrustOptions{
+		PolicyPath:   query.PolicyPath,
+		Raw:          query.Raw,
+		RegistryPath: query.RegistryPath,
+	}
+	imageEngine := abi.ImageEngine{Libpod: runtime}
+	report, err := imageEngine.ShowTrust(r.Context(), nil, options)
+	if err != nil {
+		utils.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// SetTrust sets the default trust policy or adds a trust policy for a registry
+func SetTrust(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		PolicyPath  string   `schema:"policypath"`
+		PubKeysFile []string `schema:"pubkeysfile"`
+		Scope       string   `schema:"scope"`
+		Type        string   `schema:"type"`
+	}{
+		Type: "signedBy",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if len(query.Scope) == 0 {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("scope must be set: %w", define.ErrInvalidArg))
+		return
+	}
+	if !trust.IsValidType(query.Type) {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("unknown trust type %q: %w", query.Type, define.ErrInvalidArg))
+		return
+	}
+
+	options := entities.SetTrustOptions{
+		PolicyPath:  query.PolicyPath,
+		PubKeysFile: query.PubKeysFile,
+		Type:        query.Type,
+	}
+	imageEngine := abi.ImageEngine{Libpod: runtime}
+	if err := imageEngine.SetTrust(r.Context(), []string{query.Scope}, options); err != nil {
+		utils.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
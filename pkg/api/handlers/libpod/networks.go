@@ -10,6 +10,7 @@ import (
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/api/handlers"
 	"github.com/containers/podman/v4/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v4/pkg/api/types"
 	"github.com/containers/podman/v4/pkg/domain/entities"
@@ -226,8 +227,18 @@ func Prune(w http.ResponseWriter, r *http.Request) {
 	}
 
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	ic := abi.ContainerEngine{Libpod: runtime}
 
+	query := struct {
+		DryRun bool `schema:"dryRun"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest,
+			fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
 	filterMap, err := util.PrepareFilters(r)
 	if err != nil {
 		utils.Error(w, http.StatusInternalServerError, err)
@@ -236,6 +247,7 @@ func Prune(w http.ResponseWriter, r *http.Request) {
 
 	pruneOptions := entities.NetworkPruneOptions{
 		Filters: *filterMap,
+		DryRun:  query.DryRun,
 	}
 	pruneReports, err := ic.NetworkPrune(r.Context(), pruneOptions)
 	if err != nil {
@@ -247,3 +259,45 @@ func Prune(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusOK, pruneReports)
 }
+
+// Reload recreates the network configuration and firewall rules of containers
+func Reload(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Containers []string `schema:"containers"`
+		All        bool     `schema:"all"`
+		Latest     bool     `schema:"latest"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest,
+			fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	options := entities.NetworkReloadOptions{
+		All:    query.All,
+		Latest: query.Latest,
+	}
+	ic := abi.ContainerEngine{Libpod: runtime}
+	reports, err := ic.NetworkReload(r.Context(), query.Containers, options)
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) {
+			utils.Error(w, http.StatusNotFound, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	reloadReports := make([]handlers.LibpodNetworkReloadReport, 0, len(reports))
+	for _, report := range reports {
+		reloadReport := handlers.LibpodNetworkReloadReport{ID: report.Id}
+		if report.Err != nil {
+			reloadReport.ReloadError = report.Err.Error()
+		}
+		reloadReports = append(reloadReports, reloadReport)
+	}
+	utils.WriteResponse(w, http.StatusOK, reloadReports)
+}
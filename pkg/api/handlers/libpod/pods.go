@@ -291,7 +291,14 @@ func PodPrune(w http.ResponseWriter, r *http.Request) {
 
 func PodPruneHelper(r *http.Request) ([]*entities.PodPruneReport, error) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
-	responses, err := runtime.PrunePods(r.Context())
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		DryRun bool `schema:"dryRun"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		return nil, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err)
+	}
+	responses, err := runtime.PrunePods(r.Context(), query.DryRun)
 	if err != nil {
 		return nil, err
 	}
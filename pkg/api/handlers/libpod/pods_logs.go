@@ -0,0 +1,139 @@
+package libpod
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/containers/podman/v4/pkg/util"
+	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
+)
+
+// PodLogs writes the merged logs of all containers in a pod.  Every line is
+// sent as a frame of the multiplexed stdout/stderr stream also used for
+// container logs.
+func PodLogs(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+
+	query := struct {
+		Container  string `schema:"container"`
+		Follow     bool   `schema:"follow"`
+		Since      string `schema:"since"`
+		Until      string `schema:"until"`
+		Tail       string `schema:"tail"`
+		Timestamps bool   `schema:"timestamps"`
+		Names      bool   `schema:"names"`
+		Color      bool   `schema:"color"`
+	}{
+		Tail: "all",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	options := entities.PodLogsOptions{ContainerName: query.Container}
+	options.Follow = query.Follow
+	options.Timestamps = query.Timestamps
+	options.Names = query.Names
+	options.Colors = query.Color
+	options.Tail = -1
+	if query.Tail != "all" {
+		tail, err := strconv.ParseInt(query.Tail, 0, 64)
+		if err != nil {
+			utils.BadRequest(w, "tail", query.Tail, err)
+			return
+		}
+		options.Tail = tail
+	}
+	if query.Since != "" {
+		since, err := util.ParseInputTime(query.Since, true)
+		if err != nil {
+			utils.BadRequest(w, "since", query.Since, err)
+			return
+		}
+		options.Since = since
+	}
+	if query.Until != "" {
+		until, err := util.ParseInputTime(query.Until, false)
+		if err != nil {
+			utils.BadRequest(w, "until", query.Until, err)
+			return
+		}
+		options.Until = until
+	}
+
+	stream := &logStream{w: w}
+	options.StdoutWriter = &logStreamWriter{stream: stream, fd: 1}
+	options.StderrWriter = &logStreamWriter{stream: stream, fd: 2}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	err := containerEngine.PodLogs(r.Context(), utils.GetName(r), options)
+
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+	if err != nil {
+		if stream.started {
+			logrus.Errorf("Unable to read logs of pod %s: %v", utils.GetName(r), err)
+			return
+		}
+		switch {
+		case errors.Is(err, define.ErrNoSuchPod), errors.Is(err, define.ErrNoSuchCtr):
+			utils.Error(w, http.StatusNotFound, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	if !stream.started {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// logStream multiplexes log lines onto the response.  The status is only
+// written with the first line so that errors occurring before any output
+// can still be reported.
+type logStream struct {
+	lock    sync.Mutex
+	w       http.ResponseWriter
+	started bool
+}
+
+// logStreamWriter writes each line as one frame of the stream given by fd.
+type logStreamWriter struct {
+	stream *logStream
+	fd     byte
+}
+
+func (l *logStreamWriter) Write(p []byte) (int, error) {
+	l.stream.lock.Lock()
+	defer l.stream.lock.Unlock()
+
+	if !l.stream.started {
+		l.stream.w.WriteHeader(http.StatusOK)
+		l.stream.started = true
+	}
+
+	header := make([]byte, 8)
+	header[0] = l.fd
+	binary.BigEndian.PutUint32(header[4:], uint32(len(p)))
+	if _, err := l.stream.w.Write(header); err != nil {
+		return 0, err
+	}
+	n, err := l.stream.w.Write(p)
+	if flusher, ok := l.stream.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
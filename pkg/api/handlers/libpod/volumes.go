@@ -156,6 +156,13 @@ func PruneVolumes(w http.ResponseWriter, r *http.Request) {
 
 func pruneVolumesHelper(r *http.Request) ([]*reports.PruneReport, error) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		DryRun bool `schema:"dryRun"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		return nil, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err)
+	}
 	filterMap, err := util.PrepareFilters(r)
 	if err != nil {
 		return nil, err
@@ -167,7 +174,7 @@ func pruneVolumesHelper(r *http.Request) ([]*reports.PruneReport, error) {
 		return nil, err
 	}
 
-	reports, err := runtime.PruneVolumes(r.Context(), filterFuncs)
+	reports, err := runtime.PruneVolumes(r.Context(), filterFuncs, query.DryRun)
 	if err != nil {
 		return nil, err
 	}
@@ -225,3 +232,43 @@ func ExistsVolume(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
+
+// ExportVolume streams the contents of a volume as tarball
+func ExportVolume(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+
+	// set the correct header
+	w.Header().Set("Content-Type", "application/x-tar")
+	// NOTE: As described in w.Write() it automatically sets the http code to
+	// 200 on first write if no other code was set.
+
+	ic := abi.ContainerEngine{Libpod: runtime}
+	if err := ic.VolumeExport(r.Context(), vol.Name(), entities.VolumeExportOptions{Output: w}); err != nil {
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to export volume: %w", err))
+		return
+	}
+}
+
+// ImportVolume extracts the tarball in the request body into a volume
+func ImportVolume(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+
+	ic := abi.ContainerEngine{Libpod: runtime}
+	if err := ic.VolumeImport(r.Context(), vol.Name(), entities.VolumeImportOptions{Input: r.Body}); err != nil {
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to import volume: %w", err))
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/entities/reports"
 	"github.com/containers/podman/v4/pkg/inspect"
 	dockerAPI "github.com/docker/docker/api/types"
+	dockerRegistry "github.com/docker/docker/api/types/registry"
 	dockerVolume "github.com/docker/docker/api/types/volume"
 )
 
@@ -34,6 +35,13 @@ type imageInspect struct {
 	Body handlers.ImageInspect
 }
 
+// Image Distribution Inspect
+// swagger:response
+type distributionInspect struct {
+	// in:body
+	Body dockerRegistry.DistributionInspect
+}
+
 // Image Load
 // swagger:response
 type imagesLoadResponseLibpod struct {
@@ -48,6 +56,13 @@ type imagesScpResponseLibpod struct {
 	Body reports.ScpReport
 }
 
+// Image Trust
+// swagger:response
+type imagesTrustResponseLibpod struct {
+	// in:body
+	Body entities.ShowTrustReport
+}
+
 // Image Import
 // swagger:response
 type imagesImportResponseLibpod struct {
@@ -318,6 +333,13 @@ type containerUpdateResponse struct {
 	ID string
 }
 
+// Port add
+// swagger:response
+type containerPortAddResponse struct {
+	// in:body
+	Body []types.PortMapping
+}
+
 // Wait container
 // swagger:response
 type containerWaitResponse struct {
@@ -463,3 +485,10 @@ type networkPruneResponse struct {
 	// in:body
 	Body []entities.NetworkPruneReport
 }
+
+// Network reload
+// swagger:response
+type networkReloadResponse struct {
+	// in:body
+	Body []handlers.LibpodNetworkReloadReport
+}
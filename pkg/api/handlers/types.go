@@ -65,6 +65,17 @@ type LibpodContainersRmReport struct {
 	RmError string `json:"Err,omitempty"`
 }
 
+type LibpodNetworkReloadReport struct {
+	ID string `json:"Id"`
+	// Error which occurred while reloading the network (if any).
+	// This field is optional and may be omitted if no error occurred.
+	//
+	// Extensions:
+	// x-omitempty: true
+	// x-nullable: true
+	ReloadError string `json:"Err,omitempty"`
+}
+
 // UpdateEntities used to wrap the oci resource spec in a swagger model
 // swagger:model
 type UpdateEntities struct {
@@ -27,8 +27,8 @@ type waitQueryDocker struct {
 }
 
 type waitQueryLibpod struct {
-	Interval  string                   `schema:"interval"`
-	Condition []define.ContainerStatus `schema:"condition"`
+	Interval  string   `schema:"interval"`
+	Condition []string `schema:"condition"`
 }
 
 func WaitContainerDocker(w http.ResponseWriter, r *http.Request) {
@@ -132,13 +132,13 @@ func WaitContainerLibpod(w http.ResponseWriter, r *http.Request) {
 	WriteResponse(w, http.StatusOK, strconv.Itoa(int(exitCode)))
 }
 
-type containerWaitFn func(conditions ...define.ContainerStatus) (int32, error)
+type containerWaitFn func(conditions ...string) (int32, error)
 
 func createContainerWaitFn(ctx context.Context, containerName string, interval time.Duration) containerWaitFn {
 	runtime := ctx.Value(api.RuntimeKey).(*libpod.Runtime)
 	var containerEngine entities.ContainerEngine = &abi.ContainerEngine{Libpod: runtime}
 
-	return func(conditions ...define.ContainerStatus) (int32, error) {
+	return func(conditions ...string) (int32, error) {
 		opts := entities.WaitOptions{
 			Condition: conditions,
 			Interval:  interval,
@@ -180,15 +180,15 @@ func waitDockerCondition(ctx context.Context, containerName string, interval tim
 	return code, err
 }
 
-var notRunningStates = []define.ContainerStatus{
-	define.ContainerStateCreated,
-	define.ContainerStateRemoving,
-	define.ContainerStateExited,
-	define.ContainerStateConfigured,
+var notRunningStates = []string{
+	define.ContainerStateCreated.String(),
+	define.ContainerStateRemoving.String(),
+	define.ContainerStateExited.String(),
+	define.ContainerStateConfigured.String(),
 }
 
 func waitRemoved(ctrWait containerWaitFn) (int32, error) {
-	code, err := ctrWait(define.ContainerStateUnknown)
+	code, err := ctrWait(define.ContainerStateUnknown.String())
 	if err != nil && errors.Is(err, define.ErrNoSuchCtr) {
 		return code, nil
 	}
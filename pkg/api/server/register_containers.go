@@ -781,6 +781,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//      Filters to process on the prune list, encoded as JSON (a `map[string][]string`).  Available filters:
 	//       - `until=<timestamp>` Prune containers created before this timestamp. The `<timestamp>` can be Unix timestamps, date formatted timestamps, or Go duration strings (e.g. `10m`, `1h30m`) computed relative to the daemon machine’s time.
 	//       - `label` (`label=<key>`, `label=<key>=<value>`, `label!=<key>`, or `label!=<key>=<value>`) Prune containers with (or without, in case `label!=...` is used) the specified labels.
+	//  - in: query
+	//    name: dryRun
+	//    type: boolean
+	//    default: false
+	//    description: report the containers that would be removed without removing them
 	// produces:
 	// - application/json
 	// responses:
@@ -1235,7 +1240,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//       - exited
 	//       - removing
 	//       - stopping
-	//    description: "Conditions to wait for. If no condition provided the 'exited' condition is assumed."
+	//       - ready
+	//    description: "Conditions to wait for. If no condition provided the 'exited' condition is assumed. The 'ready' condition waits until the readiness check of the container passed."
 	//  - in: query
 	//    name: interval
 	//    type: string
@@ -1423,6 +1429,48 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/export"), s.APIHandler(compat.ExportContainer)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/capture libpod ContainerCaptureLibpod
+	// ---
+	// tags:
+	//   - containers
+	// summary: Capture network traffic
+	// description: |
+	//   Capture the network traffic on an interface of a running container. The packets are streamed in pcap format
+	//   until the client disconnects, the requested number of packets was captured or the container stops.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: interface
+	//    type: string
+	//    description: the network interface in the container to capture on, defaults to the first interface which is up and not a loopback interface
+	//  - in: query
+	//    name: filter
+	//    type: string
+	//    description: |
+	//      only capture packets matching the filter, supports the host, net, port and portrange primitives and the
+	//      ip, ip6, arp, tcp, udp, sctp, icmp and icmp6 protocols of the pcap-filter syntax
+	//  - in: query
+	//    name: count
+	//    type: integer
+	//    description: stop after capturing the given number of packets
+	// produces:
+	// - application/vnd.tcpdump.pcap
+	// responses:
+	//   200:
+	//     description: pcap stream is returned in body
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/capture"), s.APIHandler(libpod.CaptureContainer)).Methods(http.MethodGet)
 	// swagger:operation POST /libpod/containers/{name}/checkpoint libpod ContainerCheckpointLibpod
 	// ---
 	// tags:
@@ -1616,6 +1664,76 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/init"), s.APIHandler(libpod.InitContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/port/add libpod ContainerPortAddLibpod
+	// ---
+	// tags:
+	//   - containers
+	// summary: Publish ports
+	// description: |
+	//   Publish additional ports of a container. If the container is running, the port forwarding is updated right away.
+	//   Only containers using bridge, slirp4netns or pasta networking can publish ports.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: ports
+	//    type: array
+	//    items:
+	//      type: string
+	//    required: true
+	//    description: ports to publish in the format [[ip:][hostPort]:]containerPort[/protocol]
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/containerPortAddResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/port/add"), s.APIHandler(libpod.AddContainerPorts)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/port/remove libpod ContainerPortRemoveLibpod
+	// ---
+	// tags:
+	//   - containers
+	// summary: Unpublish ports
+	// description: |
+	//   Unpublish ports of a container. A port without host port unpublishes the container port on all host ports.
+	//   If the container is running, the port forwarding is updated right away.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: ports
+	//    type: array
+	//    items:
+	//      type: string
+	//    required: true
+	//    description: ports to unpublish in the format [[ip:][hostPort]:]containerPort[/protocol]
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/port/remove"), s.APIHandler(libpod.RemoveContainerPorts)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/containers/{name}/rename libpod ContainerRenameLibpod
 	// ---
 	// tags:
@@ -1657,6 +1775,20 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: string
 	//    required: true
 	//    description: Full or partial ID or full name of the container to update
+	//  - in: query
+	//    name: networkRateLimit
+	//    type: string
+	//    description: |
+	//      Limit the network bandwidth of the container, e.g. ingress=10mbit,egress=5mbit.
+	//      Only the given directions are changed, a rate of 0 removes the limit.
+	//  - in: query
+	//    name: networkDelay
+	//    type: string
+	//    description: Delay packets sent by the container, e.g. 100ms. 0 removes the delay.
+	//  - in: query
+	//    name: networkLoss
+	//    type: string
+	//    description: Percentage of packets sent by the container that are dropped, e.g. 1%. 0 removes the loss.
 	//  - in: body
 	//    name: resources
 	//    description: attributes for updating the container
@@ -1668,6 +1800,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   responses:
 	//     201:
 	//       $ref: "#/responses/containerUpdateResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   404:
 	//     $ref: "#/responses/containerNotFound"
 	//   500:
@@ -844,6 +844,7 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//        - `reference`=(`<image-name>[:<tag>]`)
 	//        - `id`=(`<image-id>`)
 	//        - `since`=(`<image-name>[:<tag>]`,  `<image id>` or `<image@digest>`)
+	//        - `until-used=<string>` Images not used to create a container since this timestamp. The `<timestamp>` can be Unix timestamps, date formatted timestamps, or Go duration strings, durations may also be given in days (e.g. `30d`).
 	//     type: string
 	// produces:
 	// - application/json
@@ -1085,6 +1086,22 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//           (or `0`), all unused images are pruned.
 	//        - `until=<string>` Prune images created before this timestamp. The `<timestamp>` can be Unix timestamps, date formatted timestamps, or Go duration strings (e.g. `10m`, `1h30m`) computed relative to the daemon machine’s time.
 	//        - `label` (`label=<key>`, `label=<key>=<value>`, `label!=<key>`, or `label!=<key>=<value>`) Prune images with (or without, in case `label!=...` is used) the specified labels.
+	//  - in: query
+	//    name: keepStorage
+	//    type: integer
+	//    format: int64
+	//    description: |
+	//      Remove the least recently used unused images until the images use at most this many bytes
+	//  - in: query
+	//    name: untilUsed
+	//    type: string
+	//    description: |
+	//      Remove only unused images not used to create a container since this timestamp. The `<timestamp>` can be Unix timestamps, date formatted timestamps, or Go duration strings, durations may also be given in days (e.g. `30d`).
+	//  - in: query
+	//    name: dryRun
+	//    type: boolean
+	//    default: false
+	//    description: report the images that would be removed without removing them
 	// produces:
 	// - application/json
 	// responses:
@@ -1664,5 +1681,74 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: '#/responses/internalError'
 	r.Handle(VersionedPath("/libpod/images/scp/{name:.*}"), s.APIHandler(libpod.ImageScp)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/images/trust libpod ImageShowTrustLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Show image trust policy
+	// description: Show the trust policy configured in policy.json and registries.d on the server
+	// parameters:
+	//   - in: query
+	//     name: raw
+	//     description: only return the raw content of policy.json
+	//     type: boolean
+	//     default: false
+	//   - in: query
+	//     name: policypath
+	//     description: path of the policy.json file on the server
+	//     type: string
+	//   - in: query
+	//     name: registrypath
+	//     description: path of the registries.d directory on the server
+	//     type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/imagesTrustResponseLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/images/trust"), s.APIHandler(libpod.ShowTrust)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/images/trust libpod ImageSetTrustLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Set image trust policy
+	// description: Set the default trust policy or add a trust policy for a registry or repository
+	// parameters:
+	//   - in: query
+	//     name: scope
+	//     required: true
+	//     description: registry or repository the policy applies to, or "default" for the default policy
+	//     type: string
+	//   - in: query
+	//     name: type
+	//     description: trust type, one of accept, insecureAcceptAnything, reject, signedBy or sigstoreSigned
+	//     type: string
+	//     default: signedBy
+	//   - in: query
+	//     name: pubkeysfile
+	//     description: |
+	//       paths of public keys on the server to trust for the scope.
+	//       GPG keys are expected for signedBy and sigstore public keys for sigstoreSigned.
+	//     type: array
+	//     items:
+	//       type: string
+	//   - in: query
+	//     name: policypath
+	//     description: path of the policy.json file on the server
+	//     type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/images/trust"), s.APIHandler(libpod.SetTrust)).Methods(http.MethodPost)
 	return nil
 }
@@ -1,13 +1,45 @@
 package server
 
 import (
+	"net/http"
+
 	"github.com/containers/podman/v4/pkg/api/handlers/compat"
 	"github.com/gorilla/mux"
 )
 
 func (s *APIServer) registerMonitorHandlers(r *mux.Router) error {
-	r.Handle(VersionedPath("/monitor"), s.APIHandler(compat.UnsupportedHandler))
+	// swagger:operation GET /monitor system SystemMonitor
+	// ---
+	// tags:
+	//   - system (compat)
+	// summary: Monitor resource usage
+	// description: |
+	//   Streams the resource usage of all running containers. Each interval a JSON array
+	//   with the stats of every running container is written.
+	// produces:
+	// - application/json
+	// parameters:
+	// - in: query
+	//   name: stream
+	//   type: boolean
+	//   default: true
+	//   description: Stream the output
+	// - in: query
+	//   name: interval
+	//   type: integer
+	//   default: 5
+	//   description: Time in seconds between stats reports
+	// responses:
+	//   200:
+	//     description: returns a JSON array of container stats per interval
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/monitor"), s.StreamBufferedAPIHandler(compat.Monitor)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/monitor", s.APIHandler(compat.UnsupportedHandler))
+	r.Handle("/monitor", s.StreamBufferedAPIHandler(compat.Monitor)).Methods(http.MethodGet)
 	return nil
 }
@@ -429,11 +429,49 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//      Available filters:
 	//        - `until=<timestamp>` Prune networks created before this timestamp. The `<timestamp>` can be Unix timestamps, date formatted timestamps, or Go duration strings (e.g. `10m`, `1h30m`) computed relative to the daemon machine’s time.
 	//        - `label` (`label=<key>`, `label=<key>=<value>`, `label!=<key>`, or `label!=<key>=<value>`) Prune networks with (or without, in case `label!=...` is used) the specified labels.
+	//  - in: query
+	//    name: dryRun
+	//    type: boolean
+	//    default: false
+	//    description: report the networks that would be removed without removing them
 	// responses:
 	//   200:
 	//     $ref: "#/responses/networkPruneResponse"
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/networks/prune"), s.APIHandler(libpod.Prune)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/networks/reload libpod NetworkReloadLibpod
+	// ---
+	// tags:
+	//  - networks
+	// summary: Reload container networks
+	// description: Reload the network configuration of containers, recreating their firewall rules
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: query
+	//    name: containers
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: names or IDs of the containers to reload
+	//  - in: query
+	//    name: all
+	//    type: boolean
+	//    default: false
+	//    description: reload the network configuration of all containers
+	//  - in: query
+	//    name: latest
+	//    type: boolean
+	//    default: false
+	//    description: reload the network configuration of the latest created container
+	// responses:
+	//   200:
+	//     $ref: "#/responses/networkReloadResponse"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/networks/reload"), s.APIHandler(libpod.Reload)).Methods(http.MethodPost)
 	return nil
 }
@@ -67,6 +67,12 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	// summary: Prune unused pods
 	// produces:
 	// - application/json
+	// parameters:
+	//  - in: query
+	//    name: dryRun
+	//    type: boolean
+	//    default: false
+	//    description: report the pods that would be removed without removing them
 	// responses:
 	//   200:
 	//     $ref: '#/responses/podPruneResponse'
@@ -321,6 +327,64 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/top"), s.APIHandler(libpod.PodTop)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/pods/{name}/logs pods PodLogsLibpod
+	// ---
+	// summary: Get pod logs
+	// description: |
+	//   Get the logs of all containers in a pod, merged in timestamp order. Each log line is sent
+	//   as a frame of the multiplexed stdout/stderr stream used for container logs.
+	// produces:
+	// - application/octet-stream
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: container
+	//    type: string
+	//    description: only get the logs of this container of the pod
+	//  - in: query
+	//    name: follow
+	//    type: boolean
+	//    description: keep connection after returning logs
+	//  - in: query
+	//    name: since
+	//    type: string
+	//    description: only return logs since this time, as a UNIX timestamp
+	//  - in: query
+	//    name: until
+	//    type: string
+	//    description: only return logs before this time, as a UNIX timestamp
+	//  - in: query
+	//    name: tail
+	//    type: string
+	//    description: only return this number of log lines from the end of the logs of each container
+	//    default: all
+	//  - in: query
+	//    name: timestamps
+	//    type: boolean
+	//    default: false
+	//    description: add timestamps to every log line
+	//  - in: query
+	//    name: names
+	//    type: boolean
+	//    default: false
+	//    description: prefix log lines with container names instead of IDs
+	//  - in: query
+	//    name: color
+	//    type: boolean
+	//    default: false
+	//    description: show the log lines of every container in a different color
+	// responses:
+	//   200:
+	//     description: logs returned as a stream in response body.
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/logs"), s.StreamBufferedAPIHandler(libpod.PodLogs)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/pods/stats pods PodStatsAllLibpod
 	// ---
 	// tags:
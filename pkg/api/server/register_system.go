@@ -32,6 +32,12 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	// summary: Prune unused data
 	// produces:
 	// - application/json
+	// parameters:
+	//  - in: query
+	//    name: dryRun
+	//    type: boolean
+	//    default: false
+	//    description: report the containers, pods, images, networks and volumes that would be removed without removing them
 	// responses:
 	//   200:
 	//     $ref: '#/responses/systemPruneResponse'
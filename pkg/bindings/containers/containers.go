@@ -429,6 +429,33 @@ func Export(ctx context.Context, nameOrID string, w io.Writer, options *ExportOp
 	return response.Process(nil)
 }
 
+// Capture records the network traffic of a container in pcap format to w. It
+// returns when the capture ends on the server side or ctx is cancelled.
+func Capture(ctx context.Context, nameOrID string, w io.Writer, options *CaptureOptions) error {
+	if options == nil {
+		options = new(CaptureOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/capture", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 == 2 {
+		_, err = io.Copy(w, response.Body)
+		return err
+	}
+	return response.Process(nil)
+}
+
 // ContainerInit takes a created container and executes all of the
 // preparations to run the container except it will not start
 // or attach to the container
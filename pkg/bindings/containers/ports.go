@@ -0,0 +1,55 @@
+package containers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/pkg/bindings"
+)
+
+// AddPorts publishes additional ports of a container.  The new port
+// mappings, including the host ports chosen for them, are returned.
+func AddPorts(ctx context.Context, nameOrID string, options *PortAddOptions) ([]types.PortMapping, error) {
+	if options == nil {
+		options = new(PortAddOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/%s/port/add", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var ports []types.PortMapping
+	return ports, response.Process(&ports)
+}
+
+// RemovePorts unpublishes ports of a container.
+func RemovePorts(ctx context.Context, nameOrID string, options *PortRemoveOptions) error {
+	if options == nil {
+		options = new(PortRemoveOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/%s/port/remove", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
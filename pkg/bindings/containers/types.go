@@ -157,6 +157,8 @@ type ListOptions struct {
 //go:generate go run ../generator/generator.go PruneOptions
 type PruneOptions struct {
 	Filters map[string][]string
+	// Only report the containers that would be removed
+	DryRun *bool
 }
 
 // RemoveOptions are optional options for removing containers
@@ -229,8 +231,13 @@ type UnpauseOptions struct{}
 //
 //go:generate go run ../generator/generator.go WaitOptions
 type WaitOptions struct {
+	// Conditions to wait on. Includes container statuses such as
+	// "running" or "stopped" and "ready" for the readiness check.
+	Conditions []string `schema:"condition"`
+	Interval   *string
+	// Container status to wait on.
+	// Deprecated: use Conditions instead.
 	Condition []define.ContainerStatus
-	Interval  *string
 }
 
 // StopOptions are optional options for stopping containers
@@ -246,6 +253,16 @@ type StopOptions struct {
 //go:generate go run ../generator/generator.go ExportOptions
 type ExportOptions struct{}
 
+// CaptureOptions are optional options for capturing the network traffic of
+// containers
+//
+//go:generate go run ../generator/generator.go CaptureOptions
+type CaptureOptions struct {
+	Interface *string
+	Filter    *string
+	Count     *int
+}
+
 // InitOptions are optional options for initing containers
 //
 //go:generate go run ../generator/generator.go InitOptions
@@ -264,6 +281,22 @@ type RenameOptions struct {
 	Name *string
 }
 
+// PortAddOptions are options for publishing additional ports of a
+// container. The Ports field is required.
+//
+//go:generate go run ../generator/generator.go PortAddOptions
+type PortAddOptions struct {
+	Ports []string
+}
+
+// PortRemoveOptions are options for unpublishing ports of a container.
+// The Ports field is required.
+//
+//go:generate go run ../generator/generator.go PortRemoveOptions
+type PortRemoveOptions struct {
+	Ports []string
+}
+
 // ResizeTTYOptions are optional options for resizing
 // container TTYs
 //
@@ -3,6 +3,7 @@ package containers
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/containers/podman/v4/pkg/bindings"
@@ -20,8 +21,18 @@ func Update(ctx context.Context, options *entities.ContainerUpdateOptions) (stri
 	if err != nil {
 		return "", err
 	}
+	params := url.Values{}
+	if options.NetworkRateLimit != "" {
+		params.Set("networkRateLimit", options.NetworkRateLimit)
+	}
+	if options.NetworkDelay != "" {
+		params.Set("networkDelay", options.NetworkDelay)
+	}
+	if options.NetworkLoss != "" {
+		params.Set("networkLoss", options.NetworkLoss)
+	}
 	stringReader := strings.NewReader(resources)
-	response, err := conn.DoRequest(ctx, stringReader, http.MethodPost, "/containers/%s/update", nil, nil, options.NameOrID)
+	response, err := conn.DoRequest(ctx, stringReader, http.MethodPost, "/containers/%s/update", params, nil, options.NameOrID)
 	if err != nil {
 		return "", err
 	}
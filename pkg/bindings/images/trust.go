@@ -0,0 +1,56 @@
+package images
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+)
+
+// ShowTrust returns the trust policy configured on the server.
+func ShowTrust(ctx context.Context, options *ShowTrustOptions) (*entities.ShowTrustReport, error) {
+	var report entities.ShowTrustReport
+	if options == nil {
+		options = new(ShowTrustOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/images/trust", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// SetTrust sets the default trust policy, when scope is "default", or the
+// trust policy of the given registry or repository scope on the server.
+func SetTrust(ctx context.Context, scope string, options *SetTrustOptions) error {
+	if options == nil {
+		options = new(SetTrustOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	params.Set("scope", scope)
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/images/trust", params, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
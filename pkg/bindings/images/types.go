@@ -95,6 +95,13 @@ type PruneOptions struct {
 	External *bool
 	// Filters to apply when pruning images
 	Filters map[string][]string
+	// Remove least recently used images until the images use at most this
+	// many bytes
+	KeepStorage *int64
+	// Remove only images not used since this timestamp or duration
+	UntilUsed *string
+	// Only report the images that would be removed
+	DryRun *bool
 }
 
 // TagOptions are optional options for tagging images
@@ -225,3 +232,29 @@ type ScpOptions struct {
 	Quiet       *bool
 	Destination *string
 }
+
+// ShowTrustOptions are optional options for showing the image trust policy
+//
+//go:generate go run ../generator/generator.go ShowTrustOptions
+type ShowTrustOptions struct {
+	// PolicyPath is the path of the policy.json file on the server.
+	PolicyPath *string
+	// Raw only returns the raw content of the policy.json file
+	Raw *bool
+	// RegistryPath is the path of the registries.d directory on the server.
+	RegistryPath *string
+}
+
+// SetTrustOptions are optional options for setting the image trust policy
+//
+//go:generate go run ../generator/generator.go SetTrustOptions
+type SetTrustOptions struct {
+	// PolicyPath is the path of the policy.json file on the server.
+	PolicyPath *string
+	// PubKeysFile are the paths of public keys on the server to trust
+	// for the scope.
+	PubKeysFile []string
+	// Type of the trust policy, e.g. accept, reject, signedBy or
+	// sigstoreSigned.
+	Type *string
+}
@@ -61,3 +61,48 @@ func (o *PruneOptions) GetFilters() map[string][]string {
 	}
 	return o.Filters
 }
+
+// WithKeepStorage set field KeepStorage to given value
+func (o *PruneOptions) WithKeepStorage(value int64) *PruneOptions {
+	o.KeepStorage = &value
+	return o
+}
+
+// GetKeepStorage returns value of field KeepStorage
+func (o *PruneOptions) GetKeepStorage() int64 {
+	if o.KeepStorage == nil {
+		var z int64
+		return z
+	}
+	return *o.KeepStorage
+}
+
+// WithUntilUsed set field UntilUsed to given value
+func (o *PruneOptions) WithUntilUsed(value string) *PruneOptions {
+	o.UntilUsed = &value
+	return o
+}
+
+// GetUntilUsed returns value of field UntilUsed
+func (o *PruneOptions) GetUntilUsed() string {
+	if o.UntilUsed == nil {
+		var z string
+		return z
+	}
+	return *o.UntilUsed
+}
+
+// WithDryRun set field DryRun to given value
+func (o *PruneOptions) WithDryRun(value bool) *PruneOptions {
+	o.DryRun = &value
+	return o
+}
+
+// GetDryRun returns value of field DryRun
+func (o *PruneOptions) GetDryRun() bool {
+	if o.DryRun == nil {
+		var z bool
+		return z
+	}
+	return *o.DryRun
+}
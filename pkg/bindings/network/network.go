@@ -2,11 +2,13 @@ package network
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/pkg/api/handlers"
 	"github.com/containers/podman/v4/pkg/bindings"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	jsoniter "github.com/json-iterator/go"
@@ -236,3 +238,41 @@ func Prune(ctx context.Context, options *PruneOptions) ([]*entities.NetworkPrune
 
 	return prunedNetworks, response.Process(&prunedNetworks)
 }
+
+// Reload recreates the network configuration and firewall rules of the given
+// containers.  A slice of NetworkReloadReports is returned.
+func Reload(ctx context.Context, namesOrIDs []string, options *ReloadOptions) ([]*entities.NetworkReloadReport, error) {
+	var reloadReports []handlers.LibpodNetworkReloadReport
+	if options == nil {
+		options = new(ReloadOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	for _, nameOrID := range namesOrIDs {
+		params.Add("containers", nameOrID)
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/networks/reload", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if err := response.Process(&reloadReports); err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.NetworkReloadReport, 0, len(reloadReports))
+	for _, r := range reloadReports {
+		report := &entities.NetworkReloadReport{Id: r.ID}
+		if r.ReloadError != "" {
+			report.Err = errors.New(r.ReloadError)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
@@ -91,6 +91,8 @@ type PruneOptions struct {
 	// Filters are applied to the prune of networks to be more
 	// specific on choosing
 	Filters map[string][]string
+	// Only report the networks that would be removed
+	DryRun *bool
 }
 
 // ExtraCreateOptions are optional additional configuration flags for creating Networks
@@ -101,3 +103,13 @@ type ExtraCreateOptions struct {
 	// IgnoreIfExists if true, do not fail if the network already exists
 	IgnoreIfExists *bool `schema:"ignoreIfExists"`
 }
+
+// ReloadOptions are optional options for reloading container networks
+//
+//go:generate go run ../generator/generator.go ReloadOptions
+type ReloadOptions struct {
+	// All reloads the network configuration of all containers
+	All *bool
+	// Latest reloads the network configuration of the latest container
+	Latest *bool
+}
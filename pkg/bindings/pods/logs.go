@@ -0,0 +1,61 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/bindings/containers"
+)
+
+// Logs obtains the logs of the containers of a pod, merged by the server, given the options
+// provided.  The lines are sent to the stdout|stderr channels as strings.
+func Logs(ctx context.Context, nameOrID string, options *LogOptions, stdoutChan, stderrChan chan string) error {
+	if options == nil {
+		options = new(LogOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/pods/%s/logs", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if !response.IsSuccess() {
+		return response.Process(nil)
+	}
+
+	buffer := make([]byte, 1024)
+	for {
+		fd, l, err := containers.DemuxHeader(response.Body, buffer)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		frame, err := containers.DemuxFrame(response.Body, buffer, l)
+		if err != nil {
+			return err
+		}
+
+		switch fd {
+		case 1:
+			stdoutChan <- string(frame)
+		case 2:
+			stderrChan <- string(frame)
+		default:
+			return fmt.Errorf("unrecognized input header: %d", fd)
+		}
+	}
+}
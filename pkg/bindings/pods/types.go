@@ -29,6 +29,8 @@ type PauseOptions struct {
 //
 //go:generate go run ../generator/generator.go PruneOptions
 type PruneOptions struct {
+	// Only report the pods that would be removed
+	DryRun *bool
 }
 
 // ListOptions are optional options for listing pods
@@ -90,3 +92,17 @@ type RemoveOptions struct {
 //go:generate go run ../generator/generator.go ExistsOptions
 type ExistsOptions struct {
 }
+
+// LogOptions are optional options for getting the logs of a pod
+//
+//go:generate go run ../generator/generator.go LogOptions
+type LogOptions struct {
+	Color      *bool
+	Container  *string
+	Follow     *bool
+	Names      *bool
+	Since      *string
+	Tail       *string
+	Timestamps *bool
+	Until      *string
+}
@@ -16,3 +16,18 @@ func (o *PruneOptions) Changed(fieldName string) bool {
 func (o *PruneOptions) ToParams() (url.Values, error) {
 	return util.ToParams(o)
 }
+
+// WithDryRun set field DryRun to given value
+func (o *PruneOptions) WithDryRun(value bool) *PruneOptions {
+	o.DryRun = &value
+	return o
+}
+
+// GetDryRun returns value of field DryRun
+func (o *PruneOptions) GetDryRun() bool {
+	if o.DryRun == nil {
+		var z bool
+		return z
+	}
+	return *o.DryRun
+}
@@ -1,9 +1,12 @@
 package bindings_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/containers/podman/v4/pkg/bindings"
@@ -200,4 +203,28 @@ var _ = Describe("Podman volumes", func() {
 		Expect(vols).To(HaveLen(2))
 	})
 
+	It("export and import volume", func() {
+		// exporting a bogus volume should result in 404
+		var buf bytes.Buffer
+		err := volumes.Export(connText, "foobar", &buf, nil)
+		code, _ := bindings.CheckResponseCode(err)
+		Expect(code).To(BeNumerically("==", http.StatusNotFound))
+
+		vol, err := volumes.Create(connText, entities.VolumeCreateOptions{}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		err = os.WriteFile(filepath.Join(vol.Mountpoint, "test"), []byte("hello"), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = volumes.Export(connText, vol.Name, &buf, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.Len()).To(BeNumerically(">", 0))
+
+		vol2, err := volumes.Create(connText, entities.VolumeCreateOptions{}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		err = volumes.Import(connText, vol2.Name, &buf, nil)
+		Expect(err).ToNot(HaveOccurred())
+		content, err := os.ReadFile(filepath.Join(vol2.Mountpoint, "test"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("hello"))
+	})
 })
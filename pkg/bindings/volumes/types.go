@@ -26,6 +26,8 @@ type ListOptions struct {
 type PruneOptions struct {
 	// Filters applied to the pruning of volumes
 	Filters map[string][]string
+	// Only report the volumes that would be removed
+	DryRun *bool
 }
 
 // RemoveOptions are optional options for removing volumes
@@ -43,3 +45,17 @@ type RemoveOptions struct {
 //go:generate go run ../generator/generator.go ExistsOptions
 type ExistsOptions struct {
 }
+
+// ExportOptions are optional options for exporting
+// the contents of a volume
+//
+//go:generate go run ../generator/generator.go ExportOptions
+type ExportOptions struct {
+}
+
+// ImportOptions are optional options for importing
+// a tarball into a volume
+//
+//go:generate go run ../generator/generator.go ImportOptions
+type ImportOptions struct {
+}
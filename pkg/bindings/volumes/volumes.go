@@ -2,6 +2,7 @@ package volumes
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"strings"
 
@@ -139,3 +140,45 @@ func Exists(ctx context.Context, nameOrID string, options *ExistsOptions) (bool,
 
 	return response.IsSuccess(), nil
 }
+
+// Export writes the contents of the given volume as tarball to w.
+func Export(ctx context.Context, nameOrID string, w io.Writer, options *ExportOptions) error {
+	if options == nil {
+		options = new(ExportOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/volumes/%s/export", nil, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.IsSuccess() || response.IsRedirection() {
+		_, err = io.Copy(w, response.Body)
+		return err
+	}
+	return response.Process(nil)
+}
+
+// Import extracts the tarball read from r into the given volume.
+func Import(ctx context.Context, nameOrID string, r io.Reader, options *ImportOptions) error {
+	if options == nil {
+		options = new(ImportOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, r, http.MethodPost, "/volumes/%s/import", nil, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
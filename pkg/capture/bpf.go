@@ -0,0 +1,254 @@
+package capture
+
+import (
+	"fmt"
+)
+
+// Instruction is a classic BPF instruction in the layout of the Linux
+// struct sock_filter, see https://www.kernel.org/doc/html/latest/networking/filter.html.
+type Instruction struct {
+	Op uint16
+	Jt uint8
+	Jf uint8
+	K  uint32
+}
+
+const (
+	bpfLD  = 0x00
+	bpfLDX = 0x01
+	bpfALU = 0x04
+	bpfJMP = 0x05
+	bpfRET = 0x06
+
+	bpfW = 0x00
+	bpfH = 0x08
+	bpfB = 0x10
+
+	bpfABS = 0x20
+	bpfIND = 0x40
+	bpfMSH = 0xa0
+
+	bpfAND = 0x50
+
+	bpfJA   = 0x00
+	bpfJEQ  = 0x10
+	bpfJGT  = 0x20
+	bpfJGE  = 0x30
+	bpfJSET = 0x40
+
+	bpfK = 0x00
+
+	// bpfMaxInstructions is the maximum length of a program accepted by
+	// the kernel.
+	bpfMaxInstructions = 4096
+)
+
+// Program compiles the filter to a classic BPF program for packets with the
+// given link layer header type. The program accepts up to snaplen bytes of
+// the matching packets and drops all others. It returns nil if the filter
+// matches all packets.
+func (f *Filter) Program(linkType LinkType, snaplen uint32) ([]Instruction, error) {
+	if f == nil || f.root == nil {
+		return nil, nil
+	}
+	switch linkType {
+	case LinkTypeEthernet, LinkTypeRaw:
+	default:
+		return nil, fmt.Errorf("filtering packets with link type %d is not supported", linkType)
+	}
+	c := &compiler{linkType: linkType}
+	accept, drop := c.newLabel(), c.newLabel()
+	f.root.compile(c, accept, drop)
+	c.place(accept)
+	c.emit(Instruction{Op: bpfRET | bpfK, K: snaplen})
+	c.place(drop)
+	c.emit(Instruction{Op: bpfRET | bpfK, K: 0})
+	return c.resolve()
+}
+
+// label is a position in a program which is jumped to before it is known.
+type label int
+
+// jump is an instruction whose offsets are set once the labels are placed.
+type jump struct {
+	pos  int
+	t, f label
+}
+
+type compiler struct {
+	linkType LinkType
+	prog     []Instruction
+	// labels holds the position of every label, -1 until it is placed
+	labels []int
+	jumps  []jump
+}
+
+func (c *compiler) newLabel() label {
+	c.labels = append(c.labels, -1)
+	return label(len(c.labels) - 1)
+}
+
+// place sets the label to the position of the next instruction.
+func (c *compiler) place(l label) {
+	c.labels[l] = len(c.prog)
+}
+
+func (c *compiler) emit(ins Instruction) {
+	c.prog = append(c.prog, ins)
+}
+
+// emitJump emits a jump to t if the condition is true and to f otherwise.
+// Unconditional jumps only use t.
+func (c *compiler) emitJump(op uint16, k uint32, t, f label) {
+	c.jumps = append(c.jumps, jump{pos: len(c.prog), t: t, f: f})
+	c.emit(Instruction{Op: bpfJMP | op | bpfK, K: k})
+}
+
+// resolve sets the offsets of the jumps. All labels are placed after the
+// jumps to them, classic BPF only allows jumping forward.
+func (c *compiler) resolve() ([]Instruction, error) {
+	// Conditional jumps only reach the next 255 instructions. A target
+	// farther away is reached through an unconditional jump inserted right
+	// after the conditional one, which may move other targets out of reach.
+	for changed := true; changed; {
+		changed = false
+		for i := range c.jumps {
+			j := c.jumps[i]
+			if c.prog[j.pos].Op == bpfJMP|bpfJA|bpfK {
+				continue
+			}
+			for _, target := range []*label{&j.t, &j.f} {
+				if c.labels[*target]-j.pos-1 > 0xff {
+					*target = c.insertJump(j.pos+1, *target)
+					changed = true
+				}
+			}
+			c.jumps[i].t, c.jumps[i].f = j.t, j.f
+		}
+	}
+	if len(c.prog) > bpfMaxInstructions {
+		return nil, fmt.Errorf("filter needs %d BPF instructions, at most %d are supported", len(c.prog), bpfMaxInstructions)
+	}
+	for _, j := range c.jumps {
+		ins := &c.prog[j.pos]
+		if ins.Op == bpfJMP|bpfJA|bpfK {
+			ins.K = uint32(c.labels[j.t] - j.pos - 1)
+			continue
+		}
+		ins.Jt = uint8(c.labels[j.t] - j.pos - 1)
+		ins.Jf = uint8(c.labels[j.f] - j.pos - 1)
+	}
+	return c.prog, nil
+}
+
+// insertJump inserts an unconditional jump to target at pos and returns a
+// label for it.
+func (c *compiler) insertJump(pos int, target label) label {
+	for i := range c.labels {
+		if c.labels[i] >= pos {
+			c.labels[i]++
+		}
+	}
+	for i := range c.jumps {
+		if c.jumps[i].pos >= pos {
+			c.jumps[i].pos++
+		}
+	}
+	c.prog = append(c.prog[:pos], append([]Instruction{{Op: bpfJMP | bpfJA | bpfK}}, c.prog[pos:]...)...)
+	c.jumps = append(c.jumps, jump{pos: pos, t: target, f: target})
+	l := c.newLabel()
+	c.labels[l] = pos
+	return l
+}
+
+// networkOffset returns the offset of the network layer header.
+func (c *compiler) networkOffset() uint32 {
+	if c.linkType == LinkTypeEthernet {
+		return 14
+	}
+	return 0
+}
+
+// loadNetwork loads the value at offset in the network layer header.
+func (c *compiler) loadNetwork(size uint16, offset uint32) Instruction {
+	return Instruction{Op: bpfLD | size | bpfABS, K: c.networkOffset() + offset}
+}
+
+// isEtherType matches the network layer protocol. Without Ethernet header,
+// the IP version is taken from the first byte of the packet.
+func (c *compiler) isEtherType(etherType uint16) node {
+	if c.linkType == LinkTypeEthernet {
+		return &testNode{load: []Instruction{{Op: bpfLD | bpfH | bpfABS, K: 12}}, jump: bpfJEQ, k: uint32(etherType)}
+	}
+	load := []Instruction{
+		{Op: bpfLD | bpfB | bpfABS, K: 0},
+		{Op: bpfALU | bpfAND | bpfK, K: 0xf0},
+	}
+	switch etherType {
+	case etherTypeIPv4:
+		return &testNode{load: load, jump: bpfJEQ, k: 0x40}
+	case etherTypeIPv6:
+		return &testNode{load: load, jump: bpfJEQ, k: 0x60}
+	}
+	return &constNode{}
+}
+
+// isIPProto matches the protocol field of the IPv4 header or the next header
+// field of the IPv6 header. IPv6 extension headers are not skipped.
+func (c *compiler) isIPProto(etherType uint16, proto uint8) node {
+	offset := uint32(9)
+	if etherType == etherTypeIPv6 {
+		offset = 6
+	}
+	return &testNode{load: []Instruction{c.loadNetwork(bpfB, offset)}, jump: bpfJEQ, k: uint32(proto)}
+}
+
+// testNode loads a value into the accumulator and compares it with k.
+type testNode struct {
+	load []Instruction
+	jump uint16
+	k    uint32
+}
+
+func (n *testNode) compile(c *compiler, t, f label) {
+	for _, ins := range n.load {
+		c.emit(ins)
+	}
+	c.emitJump(n.jump, n.k, t, f)
+}
+
+// constNode matches all packets or none.
+type constNode struct{ value bool }
+
+func (n *constNode) compile(c *compiler, t, f label) {
+	if n.value {
+		c.emitJump(bpfJA, 0, t, t)
+		return
+	}
+	c.emitJump(bpfJA, 0, f, f)
+}
+
+// andAll matches if all nodes match, it matches all packets without nodes.
+func andAll(nodes ...node) node {
+	if len(nodes) == 0 {
+		return &constNode{value: true}
+	}
+	n := nodes[0]
+	for _, right := range nodes[1:] {
+		n = &andNode{left: n, right: right}
+	}
+	return n
+}
+
+// orAll matches if any of the nodes matches, it matches no packets without
+// nodes.
+func orAll(nodes ...node) node {
+	if len(nodes) == 0 {
+		return &constNode{}
+	}
+	n := nodes[0]
+	for _, right := range nodes[1:] {
+		n = &orNode{left: n, right: right}
+	}
+	return n
+}
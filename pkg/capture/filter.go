@@ -0,0 +1,423 @@
+package capture
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Filter selects the packets to capture. It supports a subset of the
+// pcap-filter(7) syntax:
+//
+//	[src|dst] host ADDRESS
+//	[src|dst] net ADDRESS/PREFIX
+//	[tcp|udp|sctp] [src|dst] port PORT
+//	[tcp|udp|sctp] [src|dst] portrange FIRST-LAST
+//	ip, ip6, arp, tcp, udp, sctp, icmp, icmp6
+//
+// Primitives can be combined with and (&&), or (||), not (!) and
+// parentheses. Host names are not resolved. The filter is compiled to a
+// classic BPF program with Program, so that the kernel drops the packets
+// which do not match.
+type Filter struct {
+	root node
+}
+
+// ParseFilter parses a filter expression. An empty expression matches all
+// packets.
+func ParseFilter(expr string) (*Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return &Filter{}, nil
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+	}
+	if tok := p.peek(); tok != "" {
+		return nil, fmt.Errorf("invalid filter %q: unexpected %q", expr, tok)
+	}
+	return &Filter{root: root}, nil
+}
+
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeARP  = 0x0806
+	etherTypeIPv6 = 0x86dd
+
+	protoICMP   = 1
+	protoTCP    = 6
+	protoUDP    = 17
+	protoICMPv6 = 58
+	protoSCTP   = 132
+)
+
+type direction int
+
+const (
+	dirAny direction = iota
+	dirSrc
+	dirDst
+)
+
+// node is an element of a parsed filter expression, which emits the BPF
+// instructions jumping to label t if the packet matches and to f otherwise.
+type node interface {
+	compile(c *compiler, t, f label)
+}
+
+type andNode struct{ left, right node }
+
+func (n *andNode) compile(c *compiler, t, f label) {
+	right := c.newLabel()
+	n.left.compile(c, right, f)
+	c.place(right)
+	n.right.compile(c, t, f)
+}
+
+type orNode struct{ left, right node }
+
+func (n *orNode) compile(c *compiler, t, f label) {
+	right := c.newLabel()
+	n.left.compile(c, t, right)
+	c.place(right)
+	n.right.compile(c, t, f)
+}
+
+type notNode struct{ node node }
+
+func (n *notNode) compile(c *compiler, t, f label) {
+	n.node.compile(c, f, t)
+}
+
+// etherTypeNode matches the network layer protocol.
+type etherTypeNode struct{ etherType uint16 }
+
+func (n *etherTypeNode) compile(c *compiler, t, f label) {
+	c.isEtherType(n.etherType).compile(c, t, f)
+}
+
+// protoNode matches the protocol of the IP payload.
+type protoNode struct {
+	// etherType restricts the match to one IP version if set
+	etherType uint16
+	proto     uint8
+}
+
+func (n *protoNode) compile(c *compiler, t, f label) {
+	var versions []node
+	for _, etherType := range []uint16{etherTypeIPv4, etherTypeIPv6} {
+		if n.etherType == 0 || n.etherType == etherType {
+			versions = append(versions, andAll(c.isEtherType(etherType), c.isIPProto(etherType, n.proto)))
+		}
+	}
+	orAll(versions...).compile(c, t, f)
+}
+
+// netNode matches the source or destination address of IP packets and of
+// ARP packets for IPv4 networks. A host is a network with a full prefix.
+type netNode struct {
+	dir    direction
+	prefix netip.Prefix
+}
+
+func (n *netNode) compile(c *compiler, t, f label) {
+	if n.prefix.Addr().Is6() {
+		andAll(c.isEtherType(etherTypeIPv6), n.addresses(c, 8, 24)).compile(c, t, f)
+		return
+	}
+	orAll(
+		andAll(c.isEtherType(etherTypeIPv4), n.addresses(c, 12, 16)),
+		andAll(c.isEtherType(etherTypeARP), n.addresses(c, 14, 24)),
+	).compile(c, t, f)
+}
+
+// addresses matches the addresses at the given offsets in the network layer
+// header against the prefix.
+func (n *netNode) addresses(c *compiler, src, dst uint32) node {
+	return byDirection(n.dir, n.address(c, src), n.address(c, dst))
+}
+
+func (n *netNode) address(c *compiler, offset uint32) node {
+	addr := n.prefix.Addr().AsSlice()
+	var words []node
+	for i := 0; i*32 < n.prefix.Bits(); i++ {
+		value := uint32(addr[i*4])<<24 | uint32(addr[i*4+1])<<16 | uint32(addr[i*4+2])<<8 | uint32(addr[i*4+3])
+		load := []Instruction{c.loadNetwork(bpfW, offset+uint32(i*4))}
+		if bits := n.prefix.Bits() - i*32; bits < 32 {
+			mask := ^uint32(0) << (32 - bits)
+			load = append(load, Instruction{Op: bpfALU | bpfAND | bpfK, K: mask})
+			value &= mask
+		}
+		words = append(words, &testNode{load: load, jump: bpfJEQ, k: value})
+	}
+	return andAll(words...)
+}
+
+// portNode matches the source or destination port of TCP, UDP and SCTP
+// packets. Only the first fragment of an IPv4 packet contains the ports.
+type portNode struct {
+	dir direction
+	// proto restricts the match to one transport protocol if set
+	proto  uint8
+	lo, hi uint16
+}
+
+func (n *portNode) compile(c *compiler, t, f label) {
+	protos := []uint8{protoTCP, protoUDP, protoSCTP}
+	if n.proto != 0 {
+		protos = []uint8{n.proto}
+	}
+	var versions []node
+	for _, etherType := range []uint16{etherTypeIPv4, etherTypeIPv6} {
+		var protoTests []node
+		for _, proto := range protos {
+			protoTests = append(protoTests, c.isIPProto(etherType, proto))
+		}
+		tests := []node{c.isEtherType(etherType), orAll(protoTests...)}
+		if etherType == etherTypeIPv4 {
+			fragment := &testNode{load: []Instruction{c.loadNetwork(bpfH, 6)}, jump: bpfJSET, k: 0x1fff}
+			tests = append(tests, &notNode{node: fragment})
+		}
+		tests = append(tests, byDirection(n.dir, n.port(c, etherType, 0), n.port(c, etherType, 2)))
+		versions = append(versions, andAll(tests...))
+	}
+	orAll(versions...).compile(c, t, f)
+}
+
+// port matches the port at the given offset in the transport layer header.
+func (n *portNode) port(c *compiler, etherType uint16, offset uint32) node {
+	load := []Instruction{c.loadNetwork(bpfH, 40+offset)}
+	if etherType == etherTypeIPv4 {
+		// the length of the IPv4 header is loaded into the X register
+		load = []Instruction{
+			{Op: bpfLDX | bpfB | bpfMSH, K: c.networkOffset()},
+			{Op: bpfLD | bpfH | bpfIND, K: c.networkOffset() + offset},
+		}
+	}
+	if n.lo == n.hi {
+		return &testNode{load: load, jump: bpfJEQ, k: uint32(n.lo)}
+	}
+	return andAll(
+		&testNode{load: load, jump: bpfJGE, k: uint32(n.lo)},
+		&notNode{node: &testNode{load: load, jump: bpfJGT, k: uint32(n.hi)}},
+	)
+}
+
+// byDirection combines the matches of the source and destination.
+func byDirection(dir direction, src, dst node) node {
+	switch dir {
+	case dirSrc:
+		return src
+	case dirDst:
+		return dst
+	}
+	return orAll(src, dst)
+}
+
+func tokenize(expr string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')' || c == '!':
+			tokens = append(tokens, string(c))
+			i++
+		case strings.HasPrefix(expr[i:], "&&") || strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+		case c == '&' || c == '|':
+			return nil, fmt.Errorf("invalid filter %q: unexpected %q at offset %d", expr, c, i)
+		default:
+			end := strings.IndexAny(expr[i:], " \t\n()!&|")
+			if end < 0 {
+				end = len(expr) - i
+			}
+			tokens = append(tokens, expr[i:i+end])
+			i += end
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	tok := p.peek()
+	if tok != "" {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok == "or" || tok == "||"; tok = p.peek() {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok == "and" || tok == "&&"; tok = p.peek() {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	switch p.peek() {
+	case "not", "!":
+		p.next()
+		n, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{node: n}, nil
+	case "(":
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok != ")" {
+			return nil, fmt.Errorf("expected \")\", got %q", tok)
+		}
+		return n, nil
+	}
+	return p.parsePrimitive()
+}
+
+func (p *parser) parsePrimitive() (node, error) {
+	tok := p.next()
+	var proto uint8
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case "ip":
+		return &etherTypeNode{etherType: etherTypeIPv4}, nil
+	case "ip6":
+		return &etherTypeNode{etherType: etherTypeIPv6}, nil
+	case "arp":
+		return &etherTypeNode{etherType: etherTypeARP}, nil
+	case "icmp":
+		return &protoNode{etherType: etherTypeIPv4, proto: protoICMP}, nil
+	case "icmp6":
+		return &protoNode{etherType: etherTypeIPv6, proto: protoICMPv6}, nil
+	case "tcp", "udp", "sctp":
+		proto = map[string]uint8{"tcp": protoTCP, "udp": protoUDP, "sctp": protoSCTP}[tok]
+		// a transport protocol may be followed by a port qualifier
+		switch p.peek() {
+		case "src", "dst", "port", "portrange":
+			tok = p.next()
+		default:
+			return &protoNode{proto: proto}, nil
+		}
+	}
+
+	dir := dirAny
+	switch tok {
+	case "src":
+		dir = dirSrc
+		tok = p.next()
+	case "dst":
+		dir = dirDst
+		tok = p.next()
+	}
+
+	arg := p.next()
+	switch tok {
+	case "host", "net", "port", "portrange":
+		if arg == "" {
+			return nil, fmt.Errorf("missing argument for %q", tok)
+		}
+	default:
+		if tok == "" {
+			return nil, fmt.Errorf("unexpected end of expression")
+		}
+		return nil, fmt.Errorf("unsupported primitive %q", tok)
+	}
+	if proto != 0 && (tok == "host" || tok == "net") {
+		return nil, fmt.Errorf("unsupported primitive %q after protocol", tok)
+	}
+
+	switch tok {
+	case "host":
+		addr, err := netip.ParseAddr(arg)
+		if err != nil {
+			return nil, fmt.Errorf("host %q is not an IP address", arg)
+		}
+		addr = addr.Unmap()
+		return &netNode{dir: dir, prefix: netip.PrefixFrom(addr, addr.BitLen())}, nil
+	case "net":
+		prefix, err := netip.ParsePrefix(arg)
+		if err != nil {
+			return nil, fmt.Errorf("net %q is not in CIDR notation", arg)
+		}
+		return &netNode{dir: dir, prefix: prefix.Masked()}, nil
+	case "port":
+		port, err := parsePort(arg)
+		if err != nil {
+			return nil, err
+		}
+		return &portNode{dir: dir, proto: proto, lo: port, hi: port}, nil
+	default:
+		first, last, ok := strings.Cut(arg, "-")
+		if !ok {
+			return nil, fmt.Errorf("port range %q must be in the form FIRST-LAST", arg)
+		}
+		lo, err := parsePort(first)
+		if err != nil {
+			return nil, err
+		}
+		hi, err := parsePort(last)
+		if err != nil {
+			return nil, err
+		}
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		return &portNode{dir: dir, proto: proto, lo: lo, hi: hi}, nil
+	}
+}
+
+func parsePort(s string) (uint16, error) {
+	port, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %q", s)
+	}
+	return uint16(port), nil
+}
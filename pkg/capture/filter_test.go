@@ -0,0 +1,262 @@
+package capture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ipv4Frame returns an Ethernet frame with an IPv4 header followed by the
+// ports of a transport header.
+func ipv4Frame(proto uint8, src, dst string, srcPort, dstPort uint16) []byte {
+	frame := make([]byte, 14+20+4)
+	binary.BigEndian.PutUint16(frame[12:14], etherTypeIPv4)
+	ip := frame[14:]
+	ip[0] = 0x45
+	ip[9] = proto
+	s := netip.MustParseAddr(src).As4()
+	d := netip.MustParseAddr(dst).As4()
+	copy(ip[12:16], s[:])
+	copy(ip[16:20], d[:])
+	binary.BigEndian.PutUint16(ip[20:22], srcPort)
+	binary.BigEndian.PutUint16(ip[22:24], dstPort)
+	return frame
+}
+
+// ipv6Packet returns a raw IPv6 packet followed by the ports of a transport
+// header.
+func ipv6Packet(proto uint8, src, dst string, srcPort, dstPort uint16) []byte {
+	packet := make([]byte, 40+4)
+	packet[0] = 0x60
+	packet[6] = proto
+	s := netip.MustParseAddr(src).As16()
+	d := netip.MustParseAddr(dst).As16()
+	copy(packet[8:24], s[:])
+	copy(packet[24:40], d[:])
+	binary.BigEndian.PutUint16(packet[40:42], srcPort)
+	binary.BigEndian.PutUint16(packet[42:44], dstPort)
+	return packet
+}
+
+// run executes the classic BPF instructions emitted by the filter on packet
+// the way the kernel does and returns the number of bytes to accept.
+func run(t *testing.T, prog []Instruction, packet []byte) uint32 {
+	var a, x uint32
+	load := func(size uint16, offset uint32) (uint32, bool) {
+		n := map[uint16]uint32{bpfW: 4, bpfH: 2, bpfB: 1}[size]
+		if uint64(offset)+uint64(n) > uint64(len(packet)) {
+			return 0, false
+		}
+		var v uint32
+		for _, b := range packet[offset : offset+n] {
+			v = v<<8 | uint32(b)
+		}
+		return v, true
+	}
+	for pc := 0; pc < len(prog); pc++ {
+		ins := prog[pc]
+		var ok bool
+		switch op := ins.Op; {
+		case op&0x07 == bpfLD && op&0xe0 == bpfABS:
+			if a, ok = load(op&0x18, ins.K); !ok {
+				return 0
+			}
+		case op&0x07 == bpfLD && op&0xe0 == bpfIND:
+			if a, ok = load(op&0x18, x+ins.K); !ok {
+				return 0
+			}
+		case op == bpfLDX|bpfB|bpfMSH:
+			if x, ok = load(bpfB, ins.K); !ok {
+				return 0
+			}
+			x = (x & 0x0f) * 4
+		case op == bpfALU|bpfAND|bpfK:
+			a &= ins.K
+		case op == bpfJMP|bpfJA|bpfK:
+			pc += int(ins.K)
+		case op&0x07 == bpfJMP:
+			var cond bool
+			switch op & 0xf0 {
+			case bpfJEQ:
+				cond = a == ins.K
+			case bpfJGT:
+				cond = a > ins.K
+			case bpfJGE:
+				cond = a >= ins.K
+			case bpfJSET:
+				cond = a&ins.K != 0
+			}
+			if cond {
+				pc += int(ins.Jt)
+			} else {
+				pc += int(ins.Jf)
+			}
+		case op == bpfRET|bpfK:
+			return ins.K
+		default:
+			t.Fatalf("unexpected instruction %#v at %d", ins, pc)
+		}
+	}
+	t.Fatal("program does not return")
+	return 0
+}
+
+// match reports whether the program of the filter accepts the packet.
+func match(t *testing.T, f *Filter, linkType LinkType, packet []byte) bool {
+	prog, err := f.Program(linkType, DefaultSnaplen)
+	require.NoError(t, err)
+	if prog == nil {
+		return true
+	}
+	return run(t, prog, packet) == DefaultSnaplen
+}
+
+func TestFilterMatch(t *testing.T) {
+	tcp := ipv4Frame(protoTCP, "10.88.0.2", "1.1.1.1", 40000, 443)
+	udp := ipv4Frame(protoUDP, "10.88.0.1", "10.88.0.2", 53, 50000)
+	icmp := ipv4Frame(protoICMP, "10.88.0.2", "10.88.0.1", 0, 0)
+	arp := make([]byte, 14+28)
+	binary.BigEndian.PutUint16(arp[12:14], etherTypeARP)
+	arp[14+4], arp[14+5] = 6, 4
+	copy(arp[14+14:], []byte{10, 88, 0, 1})
+	copy(arp[14+24:], []byte{10, 88, 0, 2})
+
+	tests := []struct {
+		filter string
+		match  []bool // tcp, udp, icmp, arp
+	}{
+		{"", []bool{true, true, true, true}},
+		{"ip", []bool{true, true, true, false}},
+		{"ip6", []bool{false, false, false, false}},
+		{"arp", []bool{false, false, false, true}},
+		{"tcp", []bool{true, false, false, false}},
+		{"icmp", []bool{false, false, true, false}},
+		{"icmp6", []bool{false, false, false, false}},
+		{"sctp", []bool{false, false, false, false}},
+		{"port 53", []bool{false, true, false, false}},
+		{"tcp port 53", []bool{false, false, false, false}},
+		{"udp src port 53", []bool{false, true, false, false}},
+		{"dst port 53", []bool{false, false, false, false}},
+		{"tcp dst port 443", []bool{true, false, false, false}},
+		{"portrange 400-500", []bool{true, false, false, false}},
+		{"portrange 500-400", []bool{true, false, false, false}},
+		{"src portrange 1-1024", []bool{false, true, false, false}},
+		{"host 10.88.0.1", []bool{false, true, true, true}},
+		{"src host 10.88.0.1", []bool{false, true, false, true}},
+		{"dst host 10.88.0.2", []bool{false, true, false, true}},
+		{"net 10.88.0.0/16", []bool{true, true, true, true}},
+		{"dst net 10.0.0.0/8", []bool{false, true, true, true}},
+		{"net 0.0.0.0/0", []bool{true, true, true, true}},
+		{"not arp and not icmp", []bool{true, true, false, false}},
+		{"tcp or udp and port 53", []bool{true, true, false, false}},
+		{"tcp or (udp && port 53)", []bool{true, true, false, false}},
+		{"!(host 1.1.1.1 || arp)", []bool{false, true, true, false}},
+		{"not not tcp", []bool{true, false, false, false}},
+		{"tcp port 443 and (host 1.1.1.1 or host 8.8.8.8)", []bool{true, false, false, false}},
+		{"(port 53 or port 443) and not dst net 1.0.0.0/8", []bool{false, true, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			require.NoError(t, err)
+			for i, frame := range [][]byte{tcp, udp, icmp, arp} {
+				assert.Equal(t, tt.match[i], match(t, f, LinkTypeEthernet, frame), "packet %d", i)
+			}
+		})
+	}
+}
+
+func TestFilterMatchIPv6(t *testing.T) {
+	packet := ipv6Packet(protoTCP, "fd00::2", "2001:db8::1", 40000, 80)
+	for filter, want := range map[string]bool{
+		"ip6":                 true,
+		"ip":                  false,
+		"arp":                 false,
+		"tcp dst port 80":     true,
+		"tcp src port 80":     false,
+		"udp":                 false,
+		"host 2001:db8::1":    true,
+		"host 10.0.0.1":       false,
+		"src host fd00::2":    true,
+		"dst host fd00::2":    false,
+		"src net fd00::/8":    true,
+		"dst net fd00::/8":    false,
+		"net 2001:db8::/33":   true,
+		"ip6 and not port 22": true,
+	} {
+		f, err := ParseFilter(filter)
+		require.NoError(t, err, filter)
+		assert.Equal(t, want, match(t, f, LinkTypeRaw, packet), filter)
+	}
+	f, err := ParseFilter("tcp")
+	require.NoError(t, err)
+	// truncated packets never match
+	assert.False(t, match(t, f, LinkTypeRaw, packet[:5]))
+	assert.False(t, match(t, f, LinkTypeEthernet, nil))
+}
+
+func TestFilterProgram(t *testing.T) {
+	f, err := ParseFilter("ip")
+	require.NoError(t, err)
+	prog, err := f.Program(LinkTypeEthernet, 1500)
+	require.NoError(t, err)
+	// the same program as "tcpdump -d ip"
+	assert.Equal(t, []Instruction{
+		{Op: 0x28, K: 12},
+		{Op: 0x15, Jt: 0, Jf: 1, K: 0x0800},
+		{Op: 0x06, K: 1500},
+		{Op: 0x06, K: 0},
+	}, prog)
+
+	f, err = ParseFilter("")
+	require.NoError(t, err)
+	prog, err = f.Program(LinkTypeEthernet, 1500)
+	require.NoError(t, err)
+	assert.Nil(t, prog)
+
+	// the accept and drop instructions are out of reach of conditional jumps
+	ports := make([]string, 0, 40)
+	for port := 1000; port < 1040; port++ {
+		ports = append(ports, fmt.Sprintf("port %d", port))
+	}
+	f, err = ParseFilter(strings.Join(ports, " or "))
+	require.NoError(t, err)
+	prog, err = f.Program(LinkTypeEthernet, DefaultSnaplen)
+	require.NoError(t, err)
+	assert.Greater(t, len(prog), 255)
+	for port, want := range map[uint16]bool{999: false, 1000: true, 1039: true, 1040: false} {
+		frame := ipv4Frame(protoUDP, "10.88.0.1", "10.88.0.2", 50000, port)
+		assert.Equal(t, want, match(t, f, LinkTypeEthernet, frame), "port %d", port)
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for filter, msg := range map[string]string{
+		"foo":                  `unsupported primitive "foo"`,
+		"host":                 `missing argument for "host"`,
+		"host example.com":     `host "example.com" is not an IP address`,
+		"net 10.0.0.1":         `net "10.0.0.1" is not in CIDR notation`,
+		"src":                  "unexpected end of expression",
+		"src tcp":              `unsupported primitive "tcp"`,
+		"port 70000":           `invalid port "70000"`,
+		"port http":            `invalid port "http"`,
+		"portrange 10":         `port range "10" must be in the form FIRST-LAST`,
+		"tcp src host 1.1.1.1": `unsupported primitive "host" after protocol`,
+		"tcp host 10.0.0.1":    `unexpected "host"`,
+		"tcp and":              "unexpected end of expression",
+		"not":                  "unexpected end of expression",
+		"(tcp":                 `expected ")", got ""`,
+		"tcp)":                 `unexpected ")"`,
+		"tcp udp":              `unexpected "udp"`,
+	} {
+		_, err := ParseFilter(filter)
+		assert.EqualError(t, err, fmt.Sprintf("invalid filter %q: %s", filter, msg), filter)
+	}
+	_, err := ParseFilter("tcp & udp")
+	assert.EqualError(t, err, `invalid filter "tcp & udp": unexpected '&' at offset 4`)
+}
@@ -0,0 +1,88 @@
+// Package capture implements the pcap file format and a packet filter for
+// recording the network traffic of containers.
+package capture
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+const (
+	// ContentType is the media type of a pcap stream.
+	ContentType = "application/vnd.tcpdump.pcap"
+	// DefaultSnaplen is the maximum number of bytes captured per packet
+	// if no other limit is configured, the same as tcpdump uses.
+	DefaultSnaplen = 262144
+
+	pcapMagic        = 0xa1b23c4d // nanosecond resolution timestamps
+	pcapVersionMajor = 2
+	pcapVersionMinor = 4
+	pcapHeaderLen    = 24
+	pcapRecordLen    = 16
+)
+
+// LinkType is the type of the link layer header of captured packets as
+// defined on https://www.tcpdump.org/linktypes.html.
+type LinkType uint32
+
+const (
+	// LinkTypeEthernet is used for packets with an Ethernet header.
+	LinkTypeEthernet LinkType = 1
+	// LinkTypeRaw is used for packets which start with the IPv4 or IPv6
+	// header.
+	LinkTypeRaw LinkType = 101
+)
+
+// Writer writes packets in the pcap format.
+type Writer struct {
+	w       io.Writer
+	snaplen uint32
+	buf     []byte
+}
+
+// NewWriter writes the pcap file header to w and returns a Writer for
+// appending packets. Packets longer than snaplen are truncated.
+func NewWriter(w io.Writer, snaplen uint32, linkType LinkType) (*Writer, error) {
+	if snaplen == 0 {
+		return nil, errors.New("snaplen must be greater than 0")
+	}
+	header := make([]byte, pcapHeaderLen)
+	binary.LittleEndian.PutUint32(header[0:4], pcapMagic)
+	binary.LittleEndian.PutUint16(header[4:6], pcapVersionMajor)
+	binary.LittleEndian.PutUint16(header[6:8], pcapVersionMinor)
+	// bytes 8-16 are the unused timezone offset and timestamp accuracy
+	binary.LittleEndian.PutUint32(header[16:20], snaplen)
+	binary.LittleEndian.PutUint32(header[20:24], uint32(linkType))
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, snaplen: snaplen}, nil
+}
+
+// WritePacket appends a packet captured at ts. data holds the captured bytes
+// and length is the length of the packet on the wire, which is larger than
+// len(data) if the packet was truncated while capturing.
+func (w *Writer) WritePacket(ts time.Time, data []byte, length int) error {
+	if length < len(data) {
+		length = len(data)
+	}
+	if uint32(len(data)) > w.snaplen {
+		data = data[:w.snaplen]
+	}
+	// write the record header and data at once so that every write to the
+	// underlying writer contains a complete record
+	size := pcapRecordLen + len(data)
+	if cap(w.buf) < size {
+		w.buf = make([]byte, size)
+	}
+	buf := w.buf[:size]
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(ts.Nanosecond()))
+	binary.LittleEndian.PutUint32(buf[8:12], uint32(len(data)))
+	binary.LittleEndian.PutUint32(buf[12:16], uint32(length))
+	copy(buf[pcapRecordLen:], data)
+	_, err := w.w.Write(buf)
+	return err
+}
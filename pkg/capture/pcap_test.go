@@ -0,0 +1,47 @@
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 4, LinkTypeEthernet)
+	require.NoError(t, err)
+
+	header := buf.Bytes()
+	require.Len(t, header, pcapHeaderLen)
+	assert.Equal(t, []byte{0x4d, 0x3c, 0xb2, 0xa1}, header[0:4])
+	assert.Equal(t, uint16(2), binary.LittleEndian.Uint16(header[4:6]))
+	assert.Equal(t, uint16(4), binary.LittleEndian.Uint16(header[6:8]))
+	assert.Equal(t, uint32(4), binary.LittleEndian.Uint32(header[16:20]))
+	assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(header[20:24]))
+
+	ts := time.Unix(1700000000, 123456789)
+	require.NoError(t, w.WritePacket(ts, []byte{1, 2, 3, 4, 5, 6}, 100))
+	require.NoError(t, w.WritePacket(ts, []byte{7, 8}, 0))
+
+	records := buf.Bytes()[pcapHeaderLen:]
+	require.Len(t, records, 2*pcapRecordLen+4+2)
+	assert.Equal(t, uint32(1700000000), binary.LittleEndian.Uint32(records[0:4]))
+	assert.Equal(t, uint32(123456789), binary.LittleEndian.Uint32(records[4:8]))
+	// truncated to the snaplen
+	assert.Equal(t, uint32(4), binary.LittleEndian.Uint32(records[8:12]))
+	assert.Equal(t, uint32(100), binary.LittleEndian.Uint32(records[12:16]))
+	assert.Equal(t, []byte{1, 2, 3, 4}, records[16:20])
+
+	second := records[20:]
+	assert.Equal(t, uint32(2), binary.LittleEndian.Uint32(second[8:12]))
+	// the original length is never smaller than the captured length
+	assert.Equal(t, uint32(2), binary.LittleEndian.Uint32(second[12:16]))
+	assert.Equal(t, []byte{7, 8}, second[16:])
+
+	_, err = NewWriter(&buf, 0, LinkTypeEthernet)
+	assert.Error(t, err)
+}
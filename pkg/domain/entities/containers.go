@@ -50,7 +50,7 @@ type ContainerRunlabelOptions struct {
 type ContainerRunlabelReport struct{}
 
 type WaitOptions struct {
-	Condition []define.ContainerStatus
+	Condition []string
 	Interval  time.Duration
 	Ignore    bool
 	Latest    bool
@@ -184,6 +184,15 @@ type ContainerExportOptions struct {
 	Output io.Writer
 }
 
+// ContainerCaptureOptions describes the input for capturing the network
+// traffic of a container
+type ContainerCaptureOptions struct {
+	Interface string
+	Filter    string
+	Count     int
+	Output    io.Writer
+}
+
 type CheckpointOptions struct {
 	All            bool
 	Export         string
@@ -432,6 +441,7 @@ type ContainerUnmountReport struct {
 // to prune a container from the CLI
 type ContainerPruneOptions struct {
 	Filters url.Values `json:"filters" schema:"filters"`
+	DryRun  bool       `json:"dryRun" schema:"dryRun"`
 }
 
 // ContainerPortOptions describes the options to obtain
@@ -448,6 +458,20 @@ type ContainerPortReport struct {
 	Ports []nettypes.PortMapping
 }
 
+// ContainerPortAddOptions describes the options to publish
+// additional ports of a container
+type ContainerPortAddOptions struct {
+	// Ports to publish in the --publish format
+	Ports []string
+}
+
+// ContainerPortRmOptions describes the options to unpublish
+// ports of a container
+type ContainerPortRmOptions struct {
+	// Ports to unpublish in the --publish format
+	Ports []string
+}
+
 // ContainerCpOptions describes input options for cp.
 type ContainerCpOptions struct {
 	// Pause the container while copying.
@@ -500,4 +524,10 @@ type ContainerCloneOptions struct {
 type ContainerUpdateOptions struct {
 	NameOrID string
 	Specgen  *specgen.SpecGenerator
+	// NetworkRateLimit, NetworkDelay and NetworkLoss change the traffic
+	// control of the container's network interfaces. Empty values leave
+	// the current settings unchanged.
+	NetworkRateLimit string
+	NetworkDelay     string
+	NetworkLoss      string
 }
@@ -27,6 +27,17 @@ type GenerateSystemdReport struct {
 	Units map[string]string
 }
 
+// GenerateQuadletOptions control the generation of quadlet unit files.
+type GenerateQuadletOptions struct {
+	NoHeader bool
+}
+
+// GenerateQuadletReport
+type GenerateQuadletReport struct {
+	// Units of the generate process. key = file name -> value = file content
+	Units map[string]string
+}
+
 // GenerateKubeOptions control the generation of Kubernetes YAML files.
 type GenerateKubeOptions struct {
 	// Service - generate YAML for a Kubernetes _service_ object.
@@ -1,3 +1,6 @@
 package entities
 
-type HealthCheckOptions struct{}
+type HealthCheckOptions struct {
+	// Readiness runs the readiness check instead of the healthcheck.
+	Readiness bool
+}
@@ -72,6 +72,9 @@ type ImageSummary struct {
 	Names   []string `json:",omitempty"`
 	Digest  string   `json:",omitempty"`
 	History []string `json:",omitempty"`
+	// LastUsed is the time the image was last used to create a container,
+	// zero if it was never used.
+	LastUsed int64 `json:",omitempty"`
 }
 
 func (i *ImageSummary) Id() string { //nolint:revive,stylecheck
@@ -299,6 +302,13 @@ type ImagePruneOptions struct {
 	All      bool     `json:"all" schema:"all"`
 	External bool     `json:"external" schema:"external"`
 	Filter   []string `json:"filter" schema:"filter"`
+	// KeepStorage removes the least recently used unused images until
+	// the images use at most this many bytes.
+	KeepStorage int64 `json:"keepStorage" schema:"keepStorage"`
+	// UntilUsed limits pruning to unused images not used since the given
+	// timestamp or duration.
+	UntilUsed string `json:"untilUsed" schema:"untilUsed"`
+	DryRun    bool   `json:"dryRun" schema:"dryRun"`
 }
 
 type ImageTagOptions struct{}
@@ -93,4 +93,5 @@ type NetworkPruneReport struct {
 // NetworkPruneOptions describes options for pruning unused networks
 type NetworkPruneOptions struct {
 	Filters map[string][]string
+	DryRun  bool
 }
@@ -34,6 +34,7 @@ type ListPodsReport struct {
 	// Network names connected to infra container
 	Networks []string
 	Status   string
+	Ready    bool
 	Labels   map[string]string
 }
 
@@ -41,6 +42,7 @@ type ListPodContainer struct {
 	Id     string //nolint:revive,stylecheck
 	Names  string
 	Status string
+	Ready  bool
 }
 
 type PodPauseOptions struct {
@@ -214,6 +216,8 @@ type ContainerCreateOptions struct {
 	HealthStartPeriod  string
 	HealthTimeout      string
 	HealthOnFailure    string
+	HookPostStart      string
+	HookPreStop        string
 	Hostname           string `json:"hostname,omitempty"`
 	HTTPProxy          bool
 	HostUsers          []string
@@ -232,6 +236,9 @@ type ContainerCreateOptions struct {
 	MemorySwap         string
 	MemorySwappiness   int64
 	Name               string `json:"container_name"`
+	NetworkDelay       string
+	NetworkLoss        string
+	NetworkRateLimit   string
 	NoHealthCheck      bool
 	OOMKillDisable     bool
 	OOMScoreAdj        *int
@@ -249,6 +256,11 @@ type ContainerCreateOptions struct {
 	PublishAll         bool
 	Pull               string
 	Quiet              bool
+	ReadinessCmd       string
+	ReadinessInterval  string
+	ReadinessRetries   uint
+	ReadinessSuccesses uint
+	ReadinessTimeout   string
 	ReadOnly           bool
 	ReadWriteTmpFS     bool
 	Restart            string
@@ -421,7 +433,8 @@ func ToPodSpecGen(s specgen.PodSpecGenerator, p *PodCreateOptions) (*specgen.Pod
 }
 
 type PodPruneOptions struct {
-	Force bool `json:"force" schema:"force"`
+	Force  bool `json:"force" schema:"force"`
+	DryRun bool `json:"dryRun" schema:"dryRun"`
 }
 
 type PodPruneReport struct {
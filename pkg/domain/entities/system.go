@@ -22,6 +22,7 @@ type SystemPruneOptions struct {
 	Volume   bool
 	Filters  map[string][]string `json:"filters" schema:"filters"`
 	External bool
+	DryRun   bool
 }
 
 // SystemPruneReport provides report after system prune is executed.
@@ -100,6 +101,11 @@ type SystemUnshareOptions struct {
 	RootlessNetNS bool
 }
 
+// SystemRootlessNetNSInspectReport describes the rootless network namespace
+type SystemRootlessNetNSInspectReport struct {
+	define.RootlessNetNSInfo
+}
+
 type ComponentVersion struct {
 	types.Version
 }
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"io"
 	"net/url"
 
 	"github.com/containers/podman/v4/libpod/define"
@@ -46,6 +47,7 @@ type VolumeInspectReport struct {
 // to prune a volume from the CLI
 type VolumePruneOptions struct {
 	Filters url.Values `json:"filters" schema:"filters"`
+	DryRun  bool       `json:"dryRun" schema:"dryRun"`
 }
 
 type VolumeListOptions struct {
@@ -61,6 +63,18 @@ type VolumeReloadReport struct {
 	define.VolumeReload
 }
 
+// VolumeExportOptions describes the options required to export a volume.
+type VolumeExportOptions struct {
+	// Output is where the tarball of the volume contents is written to.
+	Output io.Writer
+}
+
+// VolumeImportOptions describes the options required to import a volume.
+type VolumeImportOptions struct {
+	// Input is the tarball that is extracted into the volume.
+	Input io.Reader
+}
+
 /*
  * Docker API compatibility types
  */
@@ -167,9 +167,9 @@ func (ic *ContainerEngine) ContainerWait(ctx context.Context, namesOrIds []strin
 
 		response := entities.WaitReport{}
 		if options.Condition == nil {
-			options.Condition = []define.ContainerStatus{define.ContainerStateStopped, define.ContainerStateExited}
+			options.Condition = []string{define.ContainerStateStopped.String(), define.ContainerStateExited.String()}
 		}
-		exitCode, err := c.WaitForConditionWithInterval(ctx, options.Interval, options.Condition...)
+		exitCode, err := c.WaitForConditionNamesWithInterval(ctx, options.Interval, options.Condition...)
 		if err != nil {
 			response.Error = err
 		} else {
@@ -301,7 +301,7 @@ func (ic *ContainerEngine) ContainerPrune(ctx context.Context, options entities.
 
 		filterFuncs = append(filterFuncs, generatedFunc)
 	}
-	return ic.Libpod.PruneContainers(filterFuncs)
+	return ic.Libpod.PruneContainers(filterFuncs, options.DryRun)
 }
 
 func (ic *ContainerEngine) ContainerKill(ctx context.Context, namesOrIds []string, options entities.KillOptions) ([]*entities.KillReport, error) {
@@ -596,6 +596,19 @@ func (ic *ContainerEngine) ContainerExport(ctx context.Context, nameOrID string,
 	return ctr.Export(options.Output)
 }
 
+func (ic *ContainerEngine) ContainerCapture(ctx context.Context, nameOrID string, options entities.ContainerCaptureOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	captureOpts := libpod.ContainerCaptureOptions{
+		Interface: options.Interface,
+		Filter:    options.Filter,
+		Count:     options.Count,
+	}
+	return ctr.Capture(ctx, captureOpts, options.Output)
+}
+
 func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds []string, options entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
 	checkOpts := libpod.ContainerCheckpointOptions{
 		Keep:           options.Keep,
@@ -1214,17 +1227,23 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 		WaitGroup:  &wg,
 	}
 
+	libpodContainers := make([]*libpod.Container, len(containers))
+	for i := range containers {
+		libpodContainers[i] = containers[i].Container
+	}
+
+	// The logs of multiple containers are read concurrently.  Unless
+	// following, merge them in timestamp order before writing.
+	if logOpts.Multi && !options.Follow {
+		return mergeContainerLogs(ctx, libpodContainers, logOpts, options)
+	}
+
 	chSize := len(containers) * int(options.Tail)
 	if chSize <= 0 {
 		chSize = 1
 	}
 	logChannel := make(chan *logs.LogLine, chSize)
 
-	libpodContainers := make([]*libpod.Container, len(containers))
-	for i := range containers {
-		libpodContainers[i] = containers[i].Container
-	}
-
 	if err := ic.Libpod.Log(ctx, libpodContainers, logOpts, logChannel); err != nil {
 		return err
 	}
@@ -1241,6 +1260,52 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 	return nil
 }
 
+// mergeContainerLogs writes the logs of the containers in timestamp order.
+// The log of each container is read in order on its own channel, so the logs
+// are merged by repeatedly writing the oldest of the next lines of all
+// containers without reading all of them into memory.
+func mergeContainerLogs(ctx context.Context, containers []*libpod.Container, logOpts *logs.LogOptions, options entities.ContainerLogsOptions) error {
+	channels := make([]chan *logs.LogLine, len(containers))
+	for i, ctr := range containers {
+		ctrOpts := *logOpts
+		ctrOpts.WaitGroup = new(sync.WaitGroup)
+		channels[i] = make(chan *logs.LogLine, 1)
+		if err := ctr.ReadLog(ctx, &ctrOpts, channels[i], int64(i)); err != nil {
+			// Do not block the readers which were started already.
+			for _, ch := range channels[:i] {
+				go func(ch chan *logs.LogLine) {
+					for range ch {
+					}
+				}(ch)
+			}
+			return err
+		}
+		go func(wg *sync.WaitGroup, ch chan *logs.LogLine) {
+			wg.Wait()
+			close(ch)
+		}(ctrOpts.WaitGroup, channels[i])
+	}
+
+	// The next line of each container, nil once its log is exhausted.
+	next := make([]*logs.LogLine, len(channels))
+	for i, ch := range channels {
+		next[i] = <-ch
+	}
+	for {
+		oldest := -1
+		for i, line := range next {
+			if line != nil && (oldest < 0 || line.Time.Before(next[oldest].Time)) {
+				oldest = i
+			}
+		}
+		if oldest < 0 {
+			return nil
+		}
+		next[oldest].Write(options.StdoutWriter, options.StderrWriter, logOpts)
+		next[oldest] = <-channels[oldest]
+	}
+}
+
 func (ic *ContainerEngine) ContainerCleanup(ctx context.Context, namesOrIds []string, options entities.ContainerCleanupOptions) ([]*entities.ContainerCleanupReport, error) {
 	containers, err := getContainers(ic.Libpod, getContainersOptions{all: options.All, latest: options.Latest, names: namesOrIds})
 	if err != nil {
@@ -1489,6 +1554,48 @@ func (ic *ContainerEngine) ContainerPort(ctx context.Context, nameOrID string, o
 	return reports, nil
 }
 
+// ContainerPortAdd publishes additional ports of a container and returns the
+// new port mappings with their host ports.
+func (ic *ContainerEngine) ContainerPortAdd(ctx context.Context, nameOrID string, options entities.ContainerPortAddOptions) (*entities.ContainerPortReport, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	ports, err := specgenutil.CreatePortBindings(options.Ports)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+	}
+	ports, err = generate.ParsePortMapping(ports, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+	}
+	if err := ctr.AddPortMappings(ports); err != nil {
+		return nil, err
+	}
+	return &entities.ContainerPortReport{
+		Id:    ctr.ID(),
+		Ports: ports,
+	}, nil
+}
+
+// ContainerPortRm unpublishes ports of a container.
+func (ic *ContainerEngine) ContainerPortRm(ctx context.Context, nameOrID string, options entities.ContainerPortRmOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	ports, err := specgenutil.CreatePortBindings(options.Ports)
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+	}
+	for i := range ports {
+		if ports[i].Protocol == "" {
+			ports[i].Protocol = "tcp"
+		}
+	}
+	return ctr.RemovePortMappings(ports)
+}
+
 // Shutdown Libpod engine
 func (ic *ContainerEngine) Shutdown(_ context.Context) {
 	shutdownSync.Do(func() {
@@ -1735,5 +1842,14 @@ func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *e
 	if err = containers[0].Update(updateOptions.Specgen.ResourceLimits); err != nil {
 		return "", err
 	}
+	if updateOptions.NetworkRateLimit != "" || updateOptions.NetworkDelay != "" || updateOptions.NetworkLoss != "" {
+		shaping, err := specgenutil.ParseNetworkShaping(containers[0].NetworkShaping(), updateOptions.NetworkRateLimit, updateOptions.NetworkDelay, updateOptions.NetworkLoss)
+		if err != nil {
+			return "", fmt.Errorf("%v: %w", err, define.ErrInvalidArg)
+		}
+		if err := containers[0].UpdateNetworkShaping(shaping); err != nil {
+			return "", err
+		}
+	}
 	return containers[0].ID(), nil
 }
@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
@@ -14,6 +16,8 @@ import (
 	"github.com/containers/podman/v4/pkg/specgen"
 	generateUtils "github.com/containers/podman/v4/pkg/specgen/generate"
 	"github.com/containers/podman/v4/pkg/systemd/generate"
+	"github.com/containers/podman/v4/pkg/systemd/parser"
+	"github.com/containers/podman/v4/version"
 	"github.com/ghodss/yaml"
 )
 
@@ -44,6 +48,59 @@ func (ic *ContainerEngine) GenerateSystemd(ctx context.Context, nameOrID string,
 	return &entities.GenerateSystemdReport{Units: units}, nil
 }
 
+func (ic *ContainerEngine) GenerateQuadlet(ctx context.Context, nameOrID string, options entities.GenerateQuadletOptions) (*entities.GenerateQuadletReport, error) {
+	units := make(map[string]string)
+	var unit *parser.UnitFile
+
+	// Containers take precedence over pods, volumes and networks of the
+	// same name.
+	if ctr, err := ic.Libpod.LookupContainer(nameOrID); err == nil {
+		unit, err = ctr.GenerateForQuadlet(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else if pod, err := ic.Libpod.LookupPod(nameOrID); err == nil {
+		// Pods are run through kube play, the .kube unit references the
+		// generated YAML next to it.
+		report, err := ic.GenerateKube(ctx, []string{pod.ID()}, entities.GenerateKubeOptions{})
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(report.Reader)
+		if err != nil {
+			return nil, err
+		}
+		yamlFile := pod.Name() + ".yaml"
+		units[yamlFile] = string(content)
+		unit, err = pod.GenerateForQuadlet(yamlFile)
+		if err != nil {
+			return nil, err
+		}
+	} else if vol, err := ic.Libpod.LookupVolume(nameOrID); err == nil {
+		unit, err = vol.GenerateForQuadlet()
+		if err != nil {
+			return nil, err
+		}
+	} else if network, err := ic.Libpod.Network().NetworkInspect(nameOrID); err == nil {
+		unit, err = libpod.GenerateNetworkForQuadlet(&network)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("%s does not refer to a container, pod, volume or network: %w", nameOrID, define.ErrNoSuchCtr)
+	}
+
+	if !options.NoHeader {
+		unit.PrependComment("", unit.Filename, "autogenerated by Podman "+version.Version.String(), time.Now().Format(time.UnixDate))
+	}
+	content, err := unit.ToString()
+	if err != nil {
+		return nil, err
+	}
+	units[unit.Filename] = content
+	return &entities.GenerateQuadletReport{Units: units}, nil
+}
+
 func (ic *ContainerEngine) GenerateSpec(ctx context.Context, opts *entities.GenerateSpecOptions) (*entities.GenerateSpecReport, error) {
 	var spec *specgen.SpecGenerator
 	var pspec *specgen.PodSpecGenerator
@@ -8,6 +8,19 @@ import (
 )
 
 func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string, options entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
+	if options.Readiness {
+		ready, err := ic.Libpod.ReadinessCheck(ctx, nameOrID)
+		if err != nil {
+			return nil, err
+		}
+		report := define.HealthCheckResults{
+			Status: define.ReadinessCheckNotReady,
+		}
+		if ready {
+			report.Status = define.ReadinessCheckReady
+		}
+		return &report, nil
+	}
 	status, err := ic.Libpod.HealthCheck(ctx, nameOrID)
 	if err != nil {
 		return nil, err
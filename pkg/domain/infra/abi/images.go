@@ -11,9 +11,11 @@ import (
 	"os/user"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/containers/common/libimage"
 	"github.com/containers/common/pkg/config"
@@ -46,6 +48,16 @@ func (ir *ImageEngine) Exists(_ context.Context, nameOrID string) (*entities.Boo
 }
 
 func (ir *ImageEngine) Prune(ctx context.Context, opts entities.ImagePruneOptions) ([]*reports.PruneReport, error) {
+	if opts.KeepStorage < 0 {
+		return nil, fmt.Errorf("storage to keep must not be negative: %w", define.ErrInvalidArg)
+	}
+	if opts.KeepStorage > 0 || opts.UntilUsed != "" {
+		return ir.pruneLeastRecentlyUsed(ctx, opts)
+	}
+	if opts.DryRun {
+		return ir.pruneDryRun(ctx, opts, nil)
+	}
+
 	pruneOptions := &libimage.RemoveImagesOptions{
 		RemoveContainerFunc:     ir.Libpod.RemoveContainersForImageCallback(ctx),
 		IsExternalContainerFunc: ir.Libpod.IsExternalContainerCallback(ctx),
@@ -91,6 +103,326 @@ func (ir *ImageEngine) Prune(ctx context.Context, opts entities.ImagePruneOption
 	return pruneReports, nil
 }
 
+// pruneLeastRecentlyUsed removes unused images in the order they were last
+// used to create a container, images never used are ordered by the time they
+// were last pulled or tagged and, if that is not known, by their creation
+// time. Only images not used since opts.UntilUsed are removed and, if
+// opts.KeepStorage is set, only until the images use at most that much
+// storage.
+func (ir *ImageEngine) pruneLeastRecentlyUsed(ctx context.Context, opts entities.ImagePruneOptions) ([]*reports.PruneReport, error) {
+	untilUsed := time.Now()
+	if opts.UntilUsed != "" {
+		var err error
+		untilUsed, err = untilUsedTimestamp(opts.UntilUsed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filters := append([]string{"readonly=false"}, opts.Filter...)
+	if opts.External {
+		filters = append(filters, "containers=external")
+	} else {
+		filters = append(filters, "containers=false")
+	}
+	images, err := ir.Libpod.LibimageRuntime().ListImages(ctx, nil, &libimage.ListImagesOptions{
+		Filters:                 filters,
+		IsExternalContainerFunc: ir.Libpod.IsExternalContainerCallback(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		img      *libimage.Image
+		lastUsed time.Time
+	}
+	candidates := make([]candidate, 0, len(images))
+	for _, img := range images {
+		lastUsed, err := ir.Libpod.ImageLastUsed(img.ID())
+		if err != nil {
+			return nil, fmt.Errorf("retrieving last use of image %q: %w", img.ID(), err)
+		}
+		if lastUsed.IsZero() {
+			lastUsed, err = ir.Libpod.ImageAdded(img.ID())
+			if err != nil {
+				return nil, fmt.Errorf("retrieving pull time of image %q: %w", img.ID(), err)
+			}
+		}
+		if lastUsed.IsZero() {
+			lastUsed = img.Created()
+		}
+		if lastUsed.Before(untilUsed) {
+			candidates = append(candidates, candidate{img: img, lastUsed: lastUsed})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].lastUsed.Before(candidates[j].lastUsed)
+	})
+
+	allImages, err := ir.Libpod.LibimageRuntime().ListImages(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The storage in use is tracked by the layers of the images, which are
+	// shared among images, rather than computed again after every removal.
+	var usage *storageUsage
+	if opts.KeepStorage > 0 {
+		usage, err = ir.newStorageUsage(allImages)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var graph *imageGraph
+	if opts.DryRun {
+		graph = newImageGraph(ctx, allImages)
+	}
+
+	pruneReports := make([]*reports.PruneReport, 0)
+	for _, c := range candidates {
+		if usage != nil && usage.total <= opts.KeepStorage {
+			break
+		}
+
+		if opts.DryRun {
+			if graph.removed[c.img.ID()] {
+				continue
+			}
+			removed, err := graph.remove(c.img)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range removed {
+				pruneReports = append(pruneReports, r)
+				usage.remove(r.Id)
+			}
+			continue
+		}
+
+		// Images may have been removed already as dangling parents
+		// of previously removed images, a filter does not fail then.
+		removedImages, rmErrors := ir.Libpod.LibimageRuntime().RemoveImages(ctx, nil, &libimage.RemoveImagesOptions{
+			RemoveContainerFunc:     ir.Libpod.RemoveContainersForImageCallback(ctx),
+			IsExternalContainerFunc: ir.Libpod.IsExternalContainerCallback(ctx),
+			ExternalContainers:      opts.External,
+			Filters:                 append(filters, "id="+c.img.ID()),
+			WithSize:                true,
+		})
+		for _, rmReport := range removedImages {
+			pruneReports = append(pruneReports, &reports.PruneReport{
+				Id:   rmReport.ID,
+				Size: uint64(rmReport.Size),
+			})
+			usage.remove(rmReport.ID)
+		}
+		if rmErrors != nil {
+			// Report the images removed so far along with the error.
+			return pruneReports, errorhandling.JoinErrors(rmErrors)
+		}
+	}
+
+	return pruneReports, nil
+}
+
+// storageUsage tracks the storage used by images while removing them. Layers
+// shared by several images are only freed along with the last of them.
+type storageUsage struct {
+	total int64
+	// layers holds the sizes of the layers of each image
+	layers map[string]map[string]int64
+	// data holds the size of the data of each image besides its layers
+	data map[string]int64
+	// refs holds the number of images using each layer
+	refs map[string]int
+}
+
+func (ir *ImageEngine) newStorageUsage(images []*libimage.Image) (*storageUsage, error) {
+	u := &storageUsage{
+		layers: make(map[string]map[string]int64, len(images)),
+		data:   make(map[string]int64, len(images)),
+		refs:   make(map[string]int),
+	}
+	for _, img := range images {
+		if _, ok := u.layers[img.ID()]; ok {
+			continue
+		}
+		layers, err := ir.Libpod.ImageLayerSizes(img.ID())
+		if err != nil {
+			return nil, fmt.Errorf("retrieving layers of image %q: %w", img.ID(), err)
+		}
+		size, err := img.Size()
+		if err != nil {
+			return nil, err
+		}
+		u.layers[img.ID()] = layers
+		for layer, layerSize := range layers {
+			if u.refs[layer] == 0 {
+				u.total += layerSize
+			}
+			u.refs[layer]++
+			size -= layerSize
+		}
+		u.data[img.ID()] = size
+		u.total += size
+	}
+	return u, nil
+}
+
+// remove frees the storage of the image. It does nothing on a nil
+// storageUsage or for an unknown image.
+func (u *storageUsage) remove(id string) {
+	if u == nil {
+		return
+	}
+	layers, ok := u.layers[id]
+	if !ok {
+		return
+	}
+	delete(u.layers, id)
+	u.total -= u.data[id]
+	for layer, size := range layers {
+		u.refs[layer]--
+		if u.refs[layer] == 0 {
+			u.total -= size
+		}
+	}
+}
+
+// imageGraph simulates removing images for dry runs. Like libimage, removing
+// an image removes its parent too if the parent is left dangling, that is
+// without names and children.
+type imageGraph struct {
+	parents     map[string]*libimage.Image
+	numChildren map[string]int
+	removed     map[string]bool
+}
+
+func newImageGraph(ctx context.Context, images []*libimage.Image) *imageGraph {
+	g := &imageGraph{
+		parents:     make(map[string]*libimage.Image, len(images)),
+		numChildren: make(map[string]int, len(images)),
+		removed:     make(map[string]bool),
+	}
+	for _, img := range images {
+		parent, err := img.Parent(ctx)
+		if err != nil {
+			// Image removal is tolerant toward corrupted images as well.
+			logrus.Warnf("Failed to determine parent of image %s: %v, ignoring the error", img.ID(), err)
+			continue
+		}
+		if parent != nil {
+			g.parents[img.ID()] = parent
+			g.numChildren[parent.ID()]++
+		}
+	}
+	return g
+}
+
+func (g *imageGraph) dangling(img *libimage.Image) bool {
+	return len(img.Names()) == 0 && g.numChildren[img.ID()] == 0
+}
+
+// remove marks the image as removed along with its dangling parents and
+// reports them.
+func (g *imageGraph) remove(img *libimage.Image) ([]*reports.PruneReport, error) {
+	var pruneReports []*reports.PruneReport
+	for img != nil {
+		size, err := img.Size()
+		if err != nil {
+			return nil, err
+		}
+		g.removed[img.ID()] = true
+		pruneReports = append(pruneReports, &reports.PruneReport{
+			Id:   img.ID(),
+			Size: uint64(size),
+		})
+		parent, ok := g.parents[img.ID()]
+		if !ok {
+			break
+		}
+		g.numChildren[parent.ID()]--
+		img = nil
+		if !g.removed[parent.ID()] && g.dangling(parent) {
+			img = parent
+		}
+	}
+	return pruneReports, nil
+}
+
+// pruneDryRun reports the images a prune with the given options would remove.
+// The prune is simulated until no more images would be removed, so images
+// which only become dangling once their children are removed and the
+// dangling parents removed along with an image are reported as well. The
+// containers in removedContainers do not keep their images in use, a dry run
+// of system prune uses them for the containers it would remove.
+func (ir *ImageEngine) pruneDryRun(ctx context.Context, opts entities.ImagePruneOptions, removedContainers map[string]bool) ([]*reports.PruneReport, error) {
+	// the filters which do not depend on other images and containers
+	candidates, err := ir.Libpod.LibimageRuntime().ListImages(ctx, nil, &libimage.ListImagesOptions{
+		Filters: append([]string{"readonly=false"}, opts.Filter...),
+	})
+	if err != nil {
+		return nil, err
+	}
+	allImages, err := ir.Libpod.LibimageRuntime().ListImages(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	graph := newImageGraph(ctx, allImages)
+	isExternal := ir.Libpod.IsExternalContainerCallback(ctx)
+
+	pruneReports := make([]*reports.PruneReport, 0)
+	for {
+		numRemoved := len(graph.removed)
+		for _, img := range candidates {
+			if graph.removed[img.ID()] || (!opts.All && !graph.dangling(img)) {
+				continue
+			}
+			unused, err := imageUnused(img, opts.External, removedContainers, isExternal)
+			if err != nil {
+				return nil, err
+			}
+			if !unused {
+				continue
+			}
+			removed, err := graph.remove(img)
+			if err != nil {
+				return nil, err
+			}
+			pruneReports = append(pruneReports, removed...)
+		}
+		if len(graph.removed) == numRemoved {
+			break
+		}
+	}
+	return pruneReports, nil
+}
+
+// imageUnused returns true if a prune may remove the image, that is if it is
+// not used by any container or, with external set, only by external
+// containers. The containers in removedContainers are ignored.
+func imageUnused(img *libimage.Image, external bool, removedContainers map[string]bool, isExternal libimage.IsExternalContainerFunc) (bool, error) {
+	ctrs, err := img.Containers()
+	if err != nil {
+		return false, err
+	}
+	for _, ctr := range ctrs {
+		if removedContainers[ctr] {
+			continue
+		}
+		if !external {
+			return false, nil
+		}
+		external, err := isExternal(ctr)
+		if err != nil {
+			return false, fmt.Errorf("checking if %s is an external container: %w", ctr, err)
+		}
+		if !external {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func toDomainHistoryLayer(layer *libimage.ImageHistory) entities.ImageHistoryLayer {
 	l := entities.ImageHistoryLayer{}
 	l.ID = layer.ID
@@ -2,15 +2,40 @@ package abi
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/containers/common/libimage"
+	"github.com/containers/common/pkg/filters"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 )
 
 func (ir *ImageEngine) List(ctx context.Context, opts entities.ImageListOptions) ([]*entities.ImageSummary, error) {
+	// The until-used filter is not known to libimage, the last use of
+	// images is recorded by libpod.
+	var untilUsed *time.Time
+	filters := make([]string, 0, len(opts.Filter))
+	for _, filter := range opts.Filter {
+		key, value, _ := strings.Cut(filter, "=")
+		if key != "until-used" {
+			filters = append(filters, filter)
+			continue
+		}
+		if untilUsed != nil {
+			return nil, errors.New("specify exactly one timestamp for until-used")
+		}
+		ts, err := untilUsedTimestamp(value)
+		if err != nil {
+			return nil, err
+		}
+		untilUsed = &ts
+	}
+
 	listImagesOptions := &libimage.ListImagesOptions{
-		Filters: opts.Filter,
+		Filters: filters,
 	}
 	if !opts.All {
 		// Filter intermediate images unless we want to list *all*.
@@ -26,6 +51,14 @@ func (ir *ImageEngine) List(ctx context.Context, opts entities.ImageListOptions)
 
 	summaries := []*entities.ImageSummary{}
 	for _, img := range images {
+		lastUsed, err := ir.Libpod.ImageLastUsed(img.ID())
+		if err != nil {
+			return nil, fmt.Errorf("retrieving last use of image %q: %w", img.ID(), err)
+		}
+		if untilUsed != nil && !lastUsed.Before(*untilUsed) {
+			continue
+		}
+
 		repoDigests, err := img.RepoDigests()
 		if err != nil {
 			return nil, fmt.Errorf("getting repoDigests from image %q: %w", img.ID(), err)
@@ -47,6 +80,9 @@ func (ir *ImageEngine) List(ctx context.Context, opts entities.ImageListOptions)
 			SharedSize:  0,
 			RepoTags:    img.Names(), // may include tags and digests
 		}
+		if !lastUsed.IsZero() {
+			e.LastUsed = lastUsed.Unix()
+		}
 		e.Labels, err = img.Labels(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("retrieving label for image %q: you may need to remove the image to resolve the error: %w", img.ID(), err)
@@ -79,3 +115,17 @@ func (ir *ImageEngine) List(ctx context.Context, opts entities.ImageListOptions)
 	}
 	return summaries, nil
 }
+
+// untilUsedTimestamp parses the value of the until-used filter and the
+// --until-used prune option. Like the until filter it accepts timestamps and
+// durations, durations may also be given in days, e.g. "30d".
+func untilUsedTimestamp(value string) (time.Time, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.ParseUint(strings.TrimSuffix(value, "d"), 10, 16)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid number of days %q: %w", value, err)
+		}
+		return time.Now().Add(-time.Duration(days) * 24 * time.Hour), nil
+	}
+	return filters.ComputeUntilTimestamp([]string{value})
+}
@@ -2,9 +2,11 @@ package abi
 
 import (
 	"testing"
+	"time"
 
 	"github.com/containers/common/libimage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // This is really intended to verify what happens with a
@@ -16,3 +18,52 @@ func TestToDomainHistoryLayer(t *testing.T) {
 	newLayer := toDomainHistoryLayer(&layer)
 	assert.Equal(t, layer.Size, newLayer.Size)
 }
+
+func TestUntilUsedTimestamp(t *testing.T) {
+	now := time.Now()
+
+	ts, err := untilUsedTimestamp("30d")
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.Add(-30*24*time.Hour), ts, time.Minute)
+
+	ts, err = untilUsedTimestamp("1h30m")
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.Add(-90*time.Minute), ts, time.Minute)
+
+	ts, err = untilUsedTimestamp("2022-01-02T15:04:05Z")
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)))
+
+	for _, invalid := range []string{"d", "-1d", "1.5d", "soon"} {
+		_, err = untilUsedTimestamp(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestStorageUsageRemove(t *testing.T) {
+	// Two images sharing a base layer.
+	u := &storageUsage{
+		total: 132,
+		layers: map[string]map[string]int64{
+			"a": {"base": 100, "layer-a": 10},
+			"b": {"base": 100, "layer-b": 20},
+		},
+		data: map[string]int64{"a": 1, "b": 1},
+		refs: map[string]int{"base": 2, "layer-a": 1, "layer-b": 1},
+	}
+
+	// The shared layer is kept for the other image.
+	u.remove("a")
+	assert.Equal(t, int64(121), u.total)
+
+	// An image is only freed once.
+	u.remove("a")
+	u.remove("unknown")
+	assert.Equal(t, int64(121), u.total)
+
+	u.remove("b")
+	assert.Equal(t, int64(0), u.total)
+
+	var nilUsage *storageUsage
+	nilUsage.remove("a")
+}
@@ -51,7 +51,7 @@ func (ic *ContainerEngine) NetworkList(ctx context.Context, options entities.Net
 	}
 
 	if filterDangling {
-		danglingFilterFunc, err := ic.createDanglingFilterFunc(wantDangling)
+		danglingFilterFunc, err := ic.createDanglingFilterFunc(wantDangling, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -187,12 +187,19 @@ func (ic *ContainerEngine) NetworkExists(ctx context.Context, networkname string
 
 // Network prune removes unused networks
 func (ic *ContainerEngine) NetworkPrune(ctx context.Context, options entities.NetworkPruneOptions) ([]*entities.NetworkPruneReport, error) {
+	return ic.networkPrune(options, nil)
+}
+
+// networkPrune removes the networks not used by any container. The containers
+// in removedContainers are ignored, a dry run of system prune uses them for
+// the containers it would remove.
+func (ic *ContainerEngine) networkPrune(options entities.NetworkPruneOptions, removedContainers map[string]bool) ([]*entities.NetworkPruneReport, error) {
 	// get all filters
 	filters, err := netutil.GenerateNetworkPruneFilters(options.Filters)
 	if err != nil {
 		return nil, err
 	}
-	danglingFilterFunc, err := ic.createDanglingFilterFunc(true)
+	danglingFilterFunc, err := ic.createDanglingFilterFunc(true, removedContainers)
 	if err != nil {
 		return nil, err
 	}
@@ -204,16 +211,18 @@ func (ic *ContainerEngine) NetworkPrune(ctx context.Context, options entities.Ne
 
 	pruneReport := make([]*entities.NetworkPruneReport, 0, len(nets))
 	for _, net := range nets {
-		pruneReport = append(pruneReport, &entities.NetworkPruneReport{
-			Name:  net.Name,
-			Error: ic.Libpod.Network().NetworkRemove(net.Name),
-		})
+		report := &entities.NetworkPruneReport{Name: net.Name}
+		if !options.DryRun {
+			report.Error = ic.Libpod.Network().NetworkRemove(net.Name)
+		}
+		pruneReport = append(pruneReport, report)
 	}
 	return pruneReport, nil
 }
 
-// danglingFilter function is special and not implemented in libnetwork filters
-func (ic *ContainerEngine) createDanglingFilterFunc(wantDangling bool) (types.FilterFunc, error) {
+// danglingFilter function is special and not implemented in libnetwork filters.
+// The networks of the containers in ignoredContainers are not kept.
+func (ic *ContainerEngine) createDanglingFilterFunc(wantDangling bool, ignoredContainers map[string]bool) (types.FilterFunc, error) {
 	cons, err := ic.Libpod.GetAllContainers()
 	if err != nil {
 		return nil, err
@@ -222,6 +231,9 @@ func (ic *ContainerEngine) createDanglingFilterFunc(wantDangling bool) (types.Fi
 	// containers want
 	networksToKeep := make(map[string]bool)
 	for _, c := range cons {
+		if ignoredContainers[c.ID()] {
+			continue
+		}
 		nets, err := c.Networks()
 		if err != nil {
 			return nil, err
@@ -24,6 +24,9 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	v1apps "github.com/containers/podman/v4/pkg/k8s.io/api/apps/v1"
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	v1net "github.com/containers/podman/v4/pkg/k8s.io/api/networking/v1"
+	v12 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/types"
 	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/containers/podman/v4/pkg/specgen/generate"
 	"github.com/containers/podman/v4/pkg/specgen/generate/kube"
@@ -159,6 +162,9 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	ipIndex := 0
 
 	var configMaps []v1.ConfigMap
+	var networkPolicies []v1net.NetworkPolicy
+	var services []v1.Service
+	var ingresses []v1net.Ingress
 
 	ranContainers := false
 	// FIXME: both, the service container and the proxies, should ideally
@@ -229,7 +235,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				podYAML.Annotations[name] = val
 			}
 
-			r, proxies, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, options, &ipIndex, podYAML.Annotations, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, options, &ipIndex, podYAML.Annotations, configMaps, networkPolicies, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -245,7 +251,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube Deployment: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeDeployment(ctx, &deploymentYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeDeployment(ctx, &deploymentYAML, options, &ipIndex, configMaps, networkPolicies, services, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -288,6 +294,27 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube ConfigMap: %w", err)
 			}
 			configMaps = append(configMaps, configMap)
+		case "NetworkPolicy":
+			var networkPolicy v1net.NetworkPolicy
+
+			if err := yaml.Unmarshal(document, &networkPolicy); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube NetworkPolicy: %w", err)
+			}
+			networkPolicies = append(networkPolicies, networkPolicy)
+		case "Service":
+			var service v1.Service
+
+			if err := yaml.Unmarshal(document, &service); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Service: %w", err)
+			}
+			services = append(services, service)
+		case "Ingress":
+			var ingress v1net.Ingress
+
+			if err := yaml.Unmarshal(document, &ingress); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Ingress: %w", err)
+			}
+			ingresses = append(ingresses, ingress)
 		case "Secret":
 			var secret v1.Secret
 
@@ -307,13 +334,41 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 		}
 	}
 
+	// The proxies of the Ingresses route to the Services of the pods, so
+	// they are created once all pods exist.
+	if len(ingresses) > 0 && !ranContainers {
+		return nil, fmt.Errorf("Ingresses in podman are not a standalone object and must be used with a pod")
+	}
+	for i := range ingresses {
+		r, proxies, err := ic.playKubeIngress(ctx, &ingresses[i], options, configMaps, services, serviceContainer)
+		if err != nil {
+			return nil, err
+		}
+		notifyProxies = append(notifyProxies, proxies...)
+
+		report.Pods = append(report.Pods, r.Pods...)
+	}
+
 	if validKinds == 0 {
 		if len(configMaps) > 0 {
 			return nil, fmt.Errorf("ConfigMaps in podman are not a standalone object and must be used in a container")
 		}
+		if len(networkPolicies) > 0 {
+			return nil, fmt.Errorf("NetworkPolicies in podman are not a standalone object and must be used with a pod")
+		}
+		if len(services) > 0 {
+			return nil, fmt.Errorf("Services in podman are not a standalone object and must be used with a pod")
+		}
 		return nil, fmt.Errorf("YAML document does not contain any supported kube kind")
 	}
 
+	// The network policies of the pods were applied when the pods were
+	// started. Apply them again now that all pods run, so that pods can
+	// select pods created later as peers.
+	if ranContainers && options.Start != types.OptionalBoolFalse {
+		ic.refreshNetworkPolicies()
+	}
+
 	// If we started containers along with a service container, we are
 	// running inside a systemd unit and need to set the main PID.
 	if options.ServiceContainer && ranContainers {
@@ -351,7 +406,25 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	return report, nil
 }
 
-func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+// playKubeIngress creates the pod running the reverse proxy of an Ingress.
+func (ic *ContainerEngine) playKubeIngress(ctx context.Context, ingress *v1net.Ingress, options entities.PlayKubeOptions, configMaps []v1.ConfigMap, services []v1.Service, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+	pod, configMap, err := kube.ToIngressPod(ingress, services)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The proxy publishes its own ports, the static addresses and
+	// published ports of the options are meant for the pods of the YAML.
+	options.StaticIPs = nil
+	options.StaticMACs = nil
+	options.PublishPorts = nil
+
+	podTemplateSpec := v1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec}
+	ipIndex := 0
+	return ic.playKubePod(ctx, pod.Name, &podTemplateSpec, options, &ipIndex, pod.Annotations, append([]v1.ConfigMap{*configMap}, configMaps...), nil, nil, serviceContainer)
+}
+
+func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, networkPolicies []v1net.NetworkPolicy, services []v1.Service, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		deploymentName string
 		podSpec        v1.PodTemplateSpec
@@ -373,7 +446,7 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	podSpec = deploymentYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", deploymentName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, networkPolicies, services, serviceContainer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
@@ -382,7 +455,7 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, networkPolicies []v1net.NetworkPolicy, services []v1.Service, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		writer      io.Writer
 		playKubePod entities.PlayKubePod
@@ -505,6 +578,42 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	}
 	podSpec := entities.PodSpec{PodSpecGen: *p}
 
+	networkPolicy, err := kube.ToNetworkPolicy(networkPolicies, podSpec.PodSpecGen.Labels, &podYAML.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if networkPolicy != nil {
+		if podSpec.PodSpecGen.NetNS.IsBridge() {
+			podSpec.PodSpecGen.NetworkPolicy = networkPolicy
+		} else {
+			logrus.Warnf("NetworkPolicies %s are not enforced for pod %s, they require a bridge network", strings.Join(networkPolicy.Names, ", "), podName)
+		}
+	}
+
+	serviceAliases, servicePorts, err := kube.ToServices(services, podSpec.PodSpecGen.Labels, &podYAML.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(serviceAliases) > 0 {
+		if podSpec.PodSpecGen.NetNS.IsBridge() {
+			// The aliases are registered on every network of the pod,
+			// "default" stands for the default network of the runtime.
+			if len(podSpec.PodSpecGen.Networks) == 0 {
+				podSpec.PodSpecGen.Networks = map[string]nettypes.PerNetworkOptions{"default": {}}
+			}
+			for name, netOpts := range podSpec.PodSpecGen.Networks {
+				netOpts.Aliases = append(netOpts.Aliases, serviceAliases...)
+				podSpec.PodSpecGen.Networks[name] = netOpts
+			}
+			podSpec.PodSpecGen.ServicePorts = servicePorts
+		} else {
+			logrus.Warnf("Service names of pod %s are not registered, they require a bridge network", podName)
+		}
+	}
+
+	qosClass := kube.QOSClass(&podYAML.Spec)
+	podSpec.PodSpecGen.QOSClass = string(qosClass)
+
 	configMapIndex := make(map[string]struct{})
 	for _, configMap := range configMaps {
 		configMapIndex[configMap.Name] = struct{}{}
@@ -528,7 +637,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		configMaps = append(configMaps, cm)
 	}
 
-	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes, configMaps, secretsManager)
+	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes, configMaps, secretsManager, podName)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -537,35 +646,8 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	// defined by a configmap or secret
 	for _, v := range volumes {
 		if (v.Type == kube.KubeVolumeTypeConfigMap || v.Type == kube.KubeVolumeTypeSecret) && !v.Optional {
-			vol, err := ic.Libpod.NewVolume(ctx, libpod.WithVolumeName(v.Source))
-			if err != nil {
-				if errors.Is(err, define.ErrVolumeExists) {
-					// Volume for this configmap already exists do not
-					// error out instead reuse the current volume.
-					vol, err = ic.Libpod.GetVolume(v.Source)
-					if err != nil {
-						return nil, nil, fmt.Errorf("cannot re-use local volume for volume from configmap %q: %w", v.Source, err)
-					}
-				} else {
-					return nil, nil, fmt.Errorf("cannot create a local volume for volume from configmap %q: %w", v.Source, err)
-				}
-			}
-			mountPoint, err := vol.MountPoint()
-			if err != nil || mountPoint == "" {
-				return nil, nil, fmt.Errorf("unable to get mountpoint of volume %q: %w", vol.Name(), err)
-			}
-			// Create files and add data to the volume mountpoint based on the Items in the volume
-			for k, v := range v.Items {
-				dataPath := filepath.Join(mountPoint, k)
-				f, err := os.Create(dataPath)
-				if err != nil {
-					return nil, nil, fmt.Errorf("cannot create file %q at volume mountpoint %q: %w", k, mountPoint, err)
-				}
-				defer f.Close()
-				_, err = f.Write(v)
-				if err != nil {
-					return nil, nil, err
-				}
+			if err := ic.createKubeVolumeWithItems(ctx, v.Source, v.Items); err != nil {
+				return nil, nil, err
 			}
 		}
 	}
@@ -629,6 +711,22 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		return nil, nil, err
 	}
 
+	// downwardAPI and projected volumes can only be populated once the
+	// pod exists as they may contain its ID
+	podMeta := v12.ObjectMeta{
+		Name:        podName,
+		UID:         ktypes.UID(pod.ID()),
+		Labels:      podYAML.ObjectMeta.Labels,
+		Annotations: podYAML.ObjectMeta.Annotations,
+	}
+	for _, v := range volumes {
+		if v.Type == kube.KubeVolumeTypeProjected {
+			if err := ic.createKubeVolumeWithItems(ctx, v.Source, v.ProjectedItems(&podMeta)); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	podInfraID, err := pod.InfraContainerID()
 	if err != nil {
 		return nil, nil, err
@@ -638,6 +736,15 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		writer = os.Stderr
 	}
 
+	// Pull the images of image volumes, they are mounted by reference
+	for _, v := range volumes {
+		if v.Type == kube.KubeVolumeTypeImage {
+			if _, err := ic.pullKubeImage(ctx, writer, v.Source, v.PullPolicy, options); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	containers := make([]*libpod.Container, 0, len(podYAML.Spec.Containers))
 	initContainers := make([]*libpod.Container, 0, len(podYAML.Spec.InitContainers))
 
@@ -699,6 +806,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 			PodInfraID:         podInfraID,
 			PodName:            podName,
 			PodSecurityContext: podYAML.Spec.SecurityContext,
+			QOSClass:           qosClass,
 			ReadOnly:           readOnly,
 			RestartPolicy:      define.RestartPolicyNo,
 			SeccompPaths:       seccompPaths,
@@ -757,6 +865,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 			PodInfraID:         podInfraID,
 			PodName:            podName,
 			PodSecurityContext: podYAML.Spec.SecurityContext,
+			QOSClass:           qosClass,
 			ReadOnly:           readOnly,
 			RestartPolicy:      ctrRestartPolicy,
 			SeccompPaths:       seccompPaths,
@@ -876,6 +985,42 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	return &report, sdNotifyProxies, nil
 }
 
+// pullKubeImage makes sure the image is in the image store, honoring the
+// Kubernetes pull policy of a container or an image volume.
+func (ic *ContainerEngine) pullKubeImage(ctx context.Context, writer io.Writer, image string, policy v1.PullPolicy, options entities.PlayKubeOptions) (*libimage.Image, error) {
+	// NOTE: set the pull policy to "newer".  This will cover cases
+	// where the "latest" tag requires a pull and will also
+	// transparently handle "localhost/" prefixed files which *may*
+	// refer to a locally built image OR an image running a
+	// registry on localhost.
+	pullPolicy := config.PullPolicyNewer
+	if len(policy) > 0 {
+		// Make sure to lower the strings since K8s pull policy
+		// may be capitalized (see bugzilla.redhat.com/show_bug.cgi?id=1985905).
+		rawPolicy := string(policy)
+		var err error
+		pullPolicy, err = config.ParsePullPolicy(strings.ToLower(rawPolicy))
+		if err != nil {
+			return nil, err
+		}
+	}
+	// This ensures the image is the image store
+	pullOptions := &libimage.PullOptions{}
+	pullOptions.AuthFilePath = options.Authfile
+	pullOptions.CertDirPath = options.CertDir
+	pullOptions.SignaturePolicyPath = options.SignaturePolicy
+	pullOptions.Writer = writer
+	pullOptions.Username = options.Username
+	pullOptions.Password = options.Password
+	pullOptions.InsecureSkipTLSVerify = options.SkipTLSVerify
+
+	pulledImages, err := ic.Libpod.LibimageRuntime().Pull(ctx, image, pullPolicy, pullOptions)
+	if err != nil {
+		return nil, err
+	}
+	return pulledImages[0], nil
+}
+
 // getImageAndLabelInfo returns the image information and how the image should be pulled plus as well as labels to be used for the container in the pod.
 // Moved this to a separate function so that it can be used for both init and regular containers when playing a kube yaml.
 func (ic *ContainerEngine) getImageAndLabelInfo(ctx context.Context, cwd string, annotations map[string]string, writer io.Writer, container v1.Container, options entities.PlayKubeOptions) (*libimage.Image, map[string]string, error) {
@@ -907,36 +1052,10 @@ func (ic *ContainerEngine) getImageAndLabelInfo(ctx context.Context, cwd string,
 		}
 		pulledImage = i
 	} else {
-		// NOTE: set the pull policy to "newer".  This will cover cases
-		// where the "latest" tag requires a pull and will also
-		// transparently handle "localhost/" prefixed files which *may*
-		// refer to a locally built image OR an image running a
-		// registry on localhost.
-		pullPolicy := config.PullPolicyNewer
-		if len(container.ImagePullPolicy) > 0 {
-			// Make sure to lower the strings since K8s pull policy
-			// may be capitalized (see bugzilla.redhat.com/show_bug.cgi?id=1985905).
-			rawPolicy := string(container.ImagePullPolicy)
-			pullPolicy, err = config.ParsePullPolicy(strings.ToLower(rawPolicy))
-			if err != nil {
-				return nil, nil, err
-			}
-		}
-		// This ensures the image is the image store
-		pullOptions := &libimage.PullOptions{}
-		pullOptions.AuthFilePath = options.Authfile
-		pullOptions.CertDirPath = options.CertDir
-		pullOptions.SignaturePolicyPath = options.SignaturePolicy
-		pullOptions.Writer = writer
-		pullOptions.Username = options.Username
-		pullOptions.Password = options.Password
-		pullOptions.InsecureSkipTLSVerify = options.SkipTLSVerify
-
-		pulledImages, err := ic.Libpod.LibimageRuntime().Pull(ctx, container.Image, pullPolicy, pullOptions)
+		pulledImage, err = ic.pullKubeImage(ctx, writer, container.Image, container.ImagePullPolicy, options)
 		if err != nil {
 			return nil, nil, err
 		}
-		pulledImage = pulledImages[0]
 	}
 
 	// Handle kube annotations
@@ -1291,6 +1410,7 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 				return nil, fmt.Errorf("unable to read YAML as Kube Pod: %w", err)
 			}
 			podNames = append(podNames, podYAML.ObjectMeta.Name)
+			volumeNames = append(volumeNames, projectedVolumeNames(podYAML.ObjectMeta.Name, podYAML.Spec.Volumes)...)
 		case "Deployment":
 			var deploymentYAML v1apps.Deployment
 
@@ -1307,12 +1427,20 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 			}
 			podName := fmt.Sprintf("%s-pod", deploymentName)
 			podNames = append(podNames, podName)
+			volumeNames = append(volumeNames, projectedVolumeNames(podName, deploymentYAML.Spec.Template.Spec.Volumes)...)
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 			if err := yaml.Unmarshal(document, &pvcYAML); err != nil {
 				return nil, fmt.Errorf("unable to read YAML as Kube PersistentVolumeClaim: %w", err)
 			}
 			volumeNames = append(volumeNames, pvcYAML.Name)
+		case "Ingress":
+			var ingress v1net.Ingress
+			if err := yaml.Unmarshal(document, &ingress); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Ingress: %w", err)
+			}
+			podNames = append(podNames, kube.IngressPodName(&ingress))
+			volumeNames = append(volumeNames, kube.IngressConfigName(&ingress))
 		default:
 			continue
 		}
@@ -1329,6 +1457,10 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 		return nil, err
 	}
 
+	// remove the addresses of the removed pods from the network policies
+	// of the remaining pods
+	ic.refreshNetworkPolicies()
+
 	if options.Force {
 		reports.VolumeRmReport, err = ic.VolumeRm(ctx, volumeNames, entities.VolumeRmOptions{})
 		if err != nil {
@@ -1339,6 +1471,67 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 	return reports, nil
 }
 
+// projectedVolumeNames returns the names of the volumes created for the
+// downwardAPI and projected volumes of the pod.
+func projectedVolumeNames(podName string, volumes []v1.Volume) []string {
+	var names []string
+	for _, v := range volumes {
+		if v.DownwardAPI != nil || v.Projected != nil {
+			names = append(names, podName+"-"+v.Name)
+		}
+	}
+	return names
+}
+
+// createKubeVolumeWithItems creates the named volume, or reuses an existing
+// one, and writes the items as files into it.
+func (ic *ContainerEngine) createKubeVolumeWithItems(ctx context.Context, name string, items map[string][]byte) error {
+	vol, err := ic.Libpod.NewVolume(ctx, libpod.WithVolumeName(name))
+	if err != nil {
+		if errors.Is(err, define.ErrVolumeExists) {
+			// Volume for this configmap, secret or pod already
+			// exists do not error out instead reuse the current volume.
+			vol, err = ic.Libpod.GetVolume(name)
+			if err != nil {
+				return fmt.Errorf("cannot re-use local volume %q: %w", name, err)
+			}
+		} else {
+			return fmt.Errorf("cannot create a local volume %q: %w", name, err)
+		}
+	}
+	mountPoint, err := vol.MountPoint()
+	if err != nil || mountPoint == "" {
+		return fmt.Errorf("unable to get mountpoint of volume %q: %w", vol.Name(), err)
+	}
+	// Create files and add data to the volume mountpoint based on the Items in the volume
+	for k, v := range items {
+		dataPath := filepath.Join(mountPoint, k)
+		// projected volumes may place items in subdirectories
+		if err := os.MkdirAll(filepath.Dir(dataPath), 0o755); err != nil {
+			return fmt.Errorf("cannot create directory for file %q at volume mountpoint %q: %w", k, mountPoint, err)
+		}
+		if err := os.WriteFile(dataPath, v, 0o644); err != nil {
+			return fmt.Errorf("cannot create file %q at volume mountpoint %q: %w", k, mountPoint, err)
+		}
+	}
+	return nil
+}
+
+// refreshNetworkPolicies applies the network policies of all pods again to
+// pick up the addresses of pods which were started or removed.
+func (ic *ContainerEngine) refreshNetworkPolicies() {
+	pods, err := ic.Libpod.GetAllPods()
+	if err != nil {
+		logrus.Errorf("Listing pods to refresh network policies: %v", err)
+		return
+	}
+	for _, pod := range pods {
+		if err := pod.RefreshNetworkPolicy(); err != nil && !errors.Is(err, define.ErrNoSuchPod) && !errors.Is(err, define.ErrPodRemoved) {
+			logrus.Errorf("Refreshing network policy of pod %s: %v", pod.Name(), err)
+		}
+	}
+}
+
 // playKubeSecret allows users to create and store a kubernetes secret as a podman secret
 func (ic *ContainerEngine) playKubeSecret(secret *v1.Secret) (*entities.SecretCreateReport, error) {
 	r := &entities.SecretCreateReport{}
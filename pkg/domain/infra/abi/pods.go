@@ -284,11 +284,11 @@ func (ic *ContainerEngine) PodRm(ctx context.Context, namesOrIds []string, optio
 }
 
 func (ic *ContainerEngine) PodPrune(ctx context.Context, options entities.PodPruneOptions) ([]*entities.PodPruneReport, error) {
-	return ic.prunePodHelper(ctx)
+	return ic.prunePodHelper(ctx, options.DryRun)
 }
 
-func (ic *ContainerEngine) prunePodHelper(ctx context.Context) ([]*entities.PodPruneReport, error) {
-	response, err := ic.Libpod.PrunePods(ctx)
+func (ic *ContainerEngine) prunePodHelper(ctx context.Context, dryRun bool) ([]*entities.PodPruneReport, error) {
+	response, err := ic.Libpod.PrunePods(ctx, dryRun)
 	if err != nil {
 		return nil, err
 	}
@@ -429,12 +429,21 @@ func (ic *ContainerEngine) listPodReportFromPod(p *libpod.Pod) (*entities.ListPo
 		if err != nil {
 			return nil, err
 		}
+		ready, err := c.Ready()
+		if err != nil {
+			return nil, err
+		}
 		lpcs[i] = &entities.ListPodContainer{
 			Id:     c.ID(),
 			Names:  c.Name(),
 			Status: state.String(),
+			Ready:  ready,
 		}
 	}
+	podReady, err := p.Ready()
+	if err != nil {
+		return nil, err
+	}
 	infraID, err := p.InfraContainerID()
 	if err != nil {
 		return nil, err
@@ -460,6 +469,7 @@ func (ic *ContainerEngine) listPodReportFromPod(p *libpod.Pod) (*entities.ListPo
 		Namespace:  p.Namespace(),
 		Networks:   networks,
 		Status:     status,
+		Ready:      podReady,
 		Labels:     p.Labels(),
 	}, nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/domain/entities/reports"
+	"github.com/containers/podman/v4/pkg/domain/filters"
 	"github.com/containers/podman/v4/pkg/rootless"
 	"github.com/containers/podman/v4/pkg/util"
 	"github.com/containers/podman/v4/utils"
@@ -162,7 +163,7 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 	var systemPruneReport = new(entities.SystemPruneReport)
 
 	if options.External {
-		if options.All || options.Volume || len(options.Filters) > 0 {
+		if options.All || options.Volume || len(options.Filters) > 0 || options.DryRun {
 			return nil, fmt.Errorf("system prune --external cannot be combined with other options")
 		}
 		err := ic.Libpod.GarbageCollect()
@@ -176,6 +177,10 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 	for k, v := range options.Filters {
 		filters = append(filters, fmt.Sprintf("%s=%s", k, v[0]))
 	}
+	if options.DryRun {
+		return ic.systemPruneDryRun(ctx, options, filters)
+	}
+
 	reclaimedSpace := (uint64)(0)
 	found := true
 	for found {
@@ -183,7 +188,7 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 
 		// TODO: Figure out cleaner way to handle all of the different PruneOptions
 		// Remove all unused pods.
-		podPruneReports, err := ic.prunePodHelper(ctx)
+		podPruneReports, err := ic.prunePodHelper(ctx, options.DryRun)
 		if err != nil {
 			return nil, err
 		}
@@ -194,7 +199,7 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 		systemPruneReport.PodPruneReport = append(systemPruneReport.PodPruneReport, podPruneReports...)
 
 		// Remove all unused containers.
-		containerPruneOptions := entities.ContainerPruneOptions{}
+		containerPruneOptions := entities.ContainerPruneOptions{DryRun: options.DryRun}
 		containerPruneOptions.Filters = (url.Values)(options.Filters)
 
 		containerPruneReports, err := ic.ContainerPrune(ctx, containerPruneOptions)
@@ -209,6 +214,7 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 		imagePruneOptions := entities.ImagePruneOptions{
 			All:    options.All,
 			Filter: filters,
+			DryRun: options.DryRun,
 		}
 
 		imageEngine := ImageEngine{Libpod: ic.Libpod}
@@ -224,7 +230,7 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 		systemPruneReport.ImagePruneReports = append(systemPruneReport.ImagePruneReports, imagePruneReports...)
 
 		// Remove all unused networks.
-		networkPruneOptions := entities.NetworkPruneOptions{}
+		networkPruneOptions := entities.NetworkPruneOptions{DryRun: options.DryRun}
 		networkPruneOptions.Filters = options.Filters
 
 		networkPruneReports, err := ic.NetworkPrune(ctx, networkPruneOptions)
@@ -240,7 +246,7 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 
 		// Remove unused volume data.
 		if options.Volume {
-			volumePruneOptions := entities.VolumePruneOptions{}
+			volumePruneOptions := entities.VolumePruneOptions{DryRun: options.DryRun}
 			volumePruneOptions.Filters = (url.Values)(options.Filters)
 
 			volumePruneReports, err := ic.VolumePrune(ctx, volumePruneOptions)
@@ -260,6 +266,85 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 	return systemPruneReport, nil
 }
 
+// systemPruneDryRun reports what SystemPrune would remove. Nothing is removed,
+// so pruning again until nothing is left would report the same again.
+// Instead, the containers of the pruned pods and the pruned containers are
+// treated as removed when looking for unused images, networks and volumes,
+// and the image prune is simulated until no more images would be removed.
+func (ic *ContainerEngine) systemPruneDryRun(ctx context.Context, options entities.SystemPruneOptions, imageFilters []string) (*entities.SystemPruneReport, error) {
+	systemPruneReport := new(entities.SystemPruneReport)
+	removedContainers := make(map[string]bool)
+
+	podPruneReports, err := ic.prunePodHelper(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range podPruneReports {
+		pod, err := ic.Libpod.LookupPod(report.Id)
+		if err != nil {
+			return nil, err
+		}
+		ctrs, err := pod.AllContainersByID()
+		if err != nil {
+			return nil, err
+		}
+		for _, ctr := range ctrs {
+			removedContainers[ctr] = true
+		}
+	}
+	systemPruneReport.PodPruneReport = podPruneReports
+
+	containerPruneReports, err := ic.ContainerPrune(ctx, entities.ContainerPruneOptions{
+		Filters: (url.Values)(options.Filters),
+		DryRun:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range containerPruneReports {
+		if report.Err == nil {
+			removedContainers[report.Id] = true
+		}
+	}
+	systemPruneReport.ContainerPruneReports = containerPruneReports
+
+	imageEngine := ImageEngine{Libpod: ic.Libpod}
+	imagePruneReports, err := imageEngine.pruneDryRun(ctx, entities.ImagePruneOptions{
+		All:    options.All,
+		Filter: imageFilters,
+	}, removedContainers)
+	if err != nil {
+		return nil, err
+	}
+	systemPruneReport.ImagePruneReports = imagePruneReports
+
+	networkPruneReports, err := ic.networkPrune(entities.NetworkPruneOptions{
+		Filters: options.Filters,
+		DryRun:  true,
+	}, removedContainers)
+	if err != nil {
+		return nil, err
+	}
+	systemPruneReport.NetworkPruneReports = networkPruneReports
+
+	if options.Volume {
+		volumeFilters, err := filters.GenerateVolumeFilters((url.Values)(options.Filters))
+		if err != nil {
+			return nil, err
+		}
+		volumePruneReports, err := ic.pruneVolumesDryRun(volumeFilters, removedContainers)
+		if err != nil {
+			return nil, err
+		}
+		systemPruneReport.VolumePruneReports = volumePruneReports
+	}
+
+	systemPruneReport.ReclaimedSpace = reports.PruneReportsSize(containerPruneReports) +
+		reports.PruneReportsSize(imagePruneReports) +
+		reports.PruneReportsSize(systemPruneReport.VolumePruneReports)
+	return systemPruneReport, nil
+}
+
 func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.SystemDfOptions) (*entities.SystemDfReport, error) {
 	var (
 		dfImages = []*entities.SystemDfImageReport{}
@@ -423,6 +508,29 @@ func (ic *ContainerEngine) Unshare(ctx context.Context, args []string, options e
 	return unshare()
 }
 
+func (ic *ContainerEngine) SystemRootlessNetNSInspect(ctx context.Context) (*entities.SystemRootlessNetNSInspectReport, error) {
+	info, err := ic.Libpod.RootlessNetNSInspect()
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SystemRootlessNetNSInspectReport{RootlessNetNSInfo: *info}, nil
+}
+
+func (ic *ContainerEngine) SystemRootlessNetNSExec(ctx context.Context, args []string) error {
+	return ic.Libpod.RootlessNetNSExec(func() error {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = unshareEnv(ic.Libpod.StorageConfig().GraphRoot, ic.Libpod.StorageConfig().RunRoot)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	})
+}
+
+func (ic *ContainerEngine) SystemRootlessNetNSCleanup(ctx context.Context) error {
+	return ic.Libpod.CleanupRootlessNetNS()
+}
+
 func (ic ContainerEngine) Version(ctx context.Context) (*entities.SystemVersionReport, error) {
 	var report entities.SystemVersionReport
 	v, err := define.GetVersion()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
@@ -11,6 +12,9 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/entities/reports"
 	"github.com/containers/podman/v4/pkg/domain/filters"
 	"github.com/containers/podman/v4/pkg/domain/infra/abi/parse"
+	"github.com/containers/podman/v4/utils"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/sirupsen/logrus"
 )
 
 func (ic *ContainerEngine) VolumeCreate(ctx context.Context, opts entities.VolumeCreateOptions) (*entities.IDOrNameResponse, error) {
@@ -126,17 +130,49 @@ func (ic *ContainerEngine) VolumePrune(ctx context.Context, options entities.Vol
 	if err != nil {
 		return nil, err
 	}
-	return ic.pruneVolumesHelper(ctx, filterFuncs)
+	return ic.pruneVolumesHelper(ctx, filterFuncs, options.DryRun)
 }
 
-func (ic *ContainerEngine) pruneVolumesHelper(ctx context.Context, filterFuncs []libpod.VolumeFilter) ([]*reports.PruneReport, error) {
-	pruned, err := ic.Libpod.PruneVolumes(ctx, filterFuncs)
+func (ic *ContainerEngine) pruneVolumesHelper(ctx context.Context, filterFuncs []libpod.VolumeFilter, dryRun bool) ([]*reports.PruneReport, error) {
+	pruned, err := ic.Libpod.PruneVolumes(ctx, filterFuncs, dryRun)
 	if err != nil {
 		return nil, err
 	}
 	return pruned, nil
 }
 
+// pruneVolumesDryRun reports the volumes a prune would remove if the
+// containers in removedContainers were removed before.
+func (ic *ContainerEngine) pruneVolumesDryRun(filterFuncs []libpod.VolumeFilter, removedContainers map[string]bool) ([]*reports.PruneReport, error) {
+	vols, err := ic.Libpod.Volumes(filterFuncs...)
+	if err != nil {
+		return nil, err
+	}
+	pruneReports := make([]*reports.PruneReport, 0, len(vols))
+	for _, vol := range vols {
+		report := &reports.PruneReport{Id: vol.Name()}
+		ctrs, err := vol.VolumeInUse()
+		if err != nil {
+			report.Err = err
+		}
+		inUse := false
+		for _, ctr := range ctrs {
+			if !removedContainers[ctr] {
+				inUse = true
+				break
+			}
+		}
+		if inUse {
+			continue
+		}
+		if size, err := vol.Size(); err == nil {
+			report.Size = size
+		}
+		pruneReports = append(pruneReports, report)
+	}
+	return pruneReports, nil
+}
+
 func (ic *ContainerEngine) VolumeList(ctx context.Context, opts entities.VolumeListOptions) ([]*entities.VolumeListReport, error) {
 	volumeFilters, err := filters.GenerateVolumeFilters(opts.Filter)
 	if err != nil {
@@ -222,3 +258,65 @@ func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeRe
 	report := ic.Libpod.UpdateVolumePlugins(ctx)
 	return &entities.VolumeReloadReport{VolumeReload: *report}, nil
 }
+
+// VolumeExport writes the contents of the given volume as tarball to options.Output.
+func (ic *ContainerEngine) VolumeExport(ctx context.Context, nameOrID string, options entities.VolumeExportOptions) error {
+	mountPoint, err := ic.volumeContentPath(nameOrID)
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Exporting volume data from %s", mountPoint)
+	tarball, err := utils.Tar(mountPoint)
+	if err != nil {
+		return err
+	}
+	defer tarball.Close()
+	_, err = io.Copy(options.Output, tarball)
+	return err
+}
+
+// VolumeImport extracts the tarball read from options.Input into the given volume.
+func (ic *ContainerEngine) VolumeImport(ctx context.Context, nameOrID string, options entities.VolumeImportOptions) error {
+	mountPoint, err := ic.volumeContentPath(nameOrID)
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Importing volume data into %s", mountPoint)
+	// dont care if volume is mounted or not we are gonna import everything to mountPoint
+	return archive.Untar(options.Input, mountPoint, nil)
+}
+
+// volumeContentPath returns the path on the host holding the contents of the
+// volume. It errors if the contents are not accessible because the volume
+// requires a mount which has not happened yet.
+func (ic *ContainerEngine) volumeContentPath(nameOrID string) (string, error) {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return "", err
+	}
+	mountPoint, err := vol.MountPoint()
+	if err != nil {
+		return "", err
+	}
+	if mountPoint == "" {
+		return "", errors.New("volume is not mounted anywhere on host")
+	}
+	mountCount, err := vol.MountCount()
+	if err != nil {
+		return "", err
+	}
+	driver := vol.Driver()
+	// Check if volume is using external plugin and export only if volume is mounted
+	if driver != "" && driver != define.VolumeDriverLocal && mountCount == 0 {
+		return "", fmt.Errorf("volume is using a driver %s and volume is not mounted on %s", driver, mountPoint)
+	}
+	// Check if volume is using `local` driver and has mount options type other than tmpfs
+	if driver == define.VolumeDriverLocal {
+		if mountOptionType, ok := vol.Options()["type"]; ok {
+			if mountOptionType != "tmpfs" && mountCount == 0 {
+				return "", fmt.Errorf("volume is using a driver %s and volume is not mounted on %s", driver, mountPoint)
+			}
+		}
+	}
+	return mountPoint, nil
+}
@@ -39,7 +39,7 @@ func (ic *ContainerEngine) ContainerExists(ctx context.Context, nameOrID string,
 
 func (ic *ContainerEngine) ContainerWait(ctx context.Context, namesOrIds []string, opts entities.WaitOptions) ([]entities.WaitReport, error) {
 	responses := make([]entities.WaitReport, 0, len(namesOrIds))
-	options := new(containers.WaitOptions).WithCondition(opts.Condition).WithInterval(opts.Interval.String())
+	options := new(containers.WaitOptions).WithConditions(opts.Condition).WithInterval(opts.Interval.String())
 	for _, n := range namesOrIds {
 		response := entities.WaitReport{}
 		exitCode, err := containers.Wait(ic.ClientCtx, n, options)
@@ -282,7 +282,7 @@ func (ic *ContainerEngine) ContainerRm(ctx context.Context, namesOrIds []string,
 }
 
 func (ic *ContainerEngine) ContainerPrune(ctx context.Context, opts entities.ContainerPruneOptions) ([]*reports.PruneReport, error) {
-	options := new(containers.PruneOptions).WithFilters(opts.Filters)
+	options := new(containers.PruneOptions).WithFilters(opts.Filters).WithDryRun(opts.DryRun)
 	return containers.Prune(ic.ClientCtx, options)
 }
 
@@ -358,6 +358,11 @@ func (ic *ContainerEngine) ContainerExport(ctx context.Context, nameOrID string,
 	return containers.Export(ic.ClientCtx, nameOrID, options.Output, nil)
 }
 
+func (ic *ContainerEngine) ContainerCapture(ctx context.Context, nameOrID string, options entities.ContainerCaptureOptions) error {
+	opts := new(containers.CaptureOptions).WithInterface(options.Interface).WithFilter(options.Filter).WithCount(options.Count)
+	return containers.Capture(ic.ClientCtx, nameOrID, options.Output, opts)
+}
+
 func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds []string, opts entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
 	var (
 		err          error
@@ -1028,6 +1033,23 @@ func (ic *ContainerEngine) ContainerPort(ctx context.Context, nameOrID string, o
 	return reports, nil
 }
 
+func (ic *ContainerEngine) ContainerPortAdd(ctx context.Context, nameOrID string, options entities.ContainerPortAddOptions) (*entities.ContainerPortReport, error) {
+	addOptions := new(containers.PortAddOptions).WithPorts(options.Ports)
+	ports, err := containers.AddPorts(ic.ClientCtx, nameOrID, addOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerPortReport{
+		Id:    nameOrID,
+		Ports: ports,
+	}, nil
+}
+
+func (ic *ContainerEngine) ContainerPortRm(ctx context.Context, nameOrID string, options entities.ContainerPortRmOptions) error {
+	rmOptions := new(containers.PortRemoveOptions).WithPorts(options.Ports)
+	return containers.RemovePorts(ic.ClientCtx, nameOrID, rmOptions)
+}
+
 func (ic *ContainerEngine) ContainerCopyFromArchive(ctx context.Context, nameOrID, path string, reader io.Reader, options entities.CopyOptions) (entities.ContainerCopyFunc, error) {
 	copyOptions := new(containers.CopyOptions).WithChown(options.Chown).WithRename(options.Rename).WithNoOverwriteDirNonDir(options.NoOverwriteDirNonDir)
 	return containers.CopyFromArchiveWithOptions(ic.ClientCtx, nameOrID, path, reader, copyOptions)
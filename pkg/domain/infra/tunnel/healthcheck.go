@@ -2,6 +2,7 @@ package tunnel
 
 import (
 	"context"
+	"errors"
 
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/bindings/containers"
@@ -9,5 +10,8 @@ import (
 )
 
 func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string, options entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
+	if options.Readiness {
+		return nil, errors.New("readiness checks are not supported on the remote API")
+	}
 	return containers.RunHealthCheck(ic.ClientCtx, nameOrID, nil)
 }
@@ -96,7 +96,13 @@ func (ir *ImageEngine) Prune(ctx context.Context, opts entities.ImagePruneOption
 		f := strings.Split(filter, "=")
 		filters[f[0]] = f[1:]
 	}
-	options := new(images.PruneOptions).WithAll(opts.All).WithFilters(filters).WithExternal(opts.External)
+	options := new(images.PruneOptions).WithAll(opts.All).WithFilters(filters).WithExternal(opts.External).WithDryRun(opts.DryRun)
+	if opts.KeepStorage != 0 {
+		options.WithKeepStorage(opts.KeepStorage)
+	}
+	if opts.UntilUsed != "" {
+		options.WithUntilUsed(opts.UntilUsed)
+	}
 	reports, err := images.Prune(ir.ClientCtx, options)
 	if err != nil {
 		return nil, err
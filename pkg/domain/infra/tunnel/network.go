@@ -2,7 +2,6 @@ package tunnel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/containers/common/libnetwork/types"
@@ -47,7 +46,8 @@ func (ic *ContainerEngine) NetworkInspect(ctx context.Context, namesOrIds []stri
 }
 
 func (ic *ContainerEngine) NetworkReload(ctx context.Context, names []string, opts entities.NetworkReloadOptions) ([]*entities.NetworkReloadReport, error) {
-	return nil, errors.New("not implemented")
+	options := new(network.ReloadOptions).WithAll(opts.All).WithLatest(opts.Latest)
+	return network.Reload(ic.ClientCtx, names, options)
 }
 
 func (ic *ContainerEngine) NetworkRm(ctx context.Context, namesOrIds []string, opts entities.NetworkRmOptions) ([]*entities.NetworkRmReport, error) {
@@ -107,6 +107,6 @@ func (ic *ContainerEngine) NetworkExists(ctx context.Context, networkname string
 
 // Network prune removes unused networks
 func (ic *ContainerEngine) NetworkPrune(ctx context.Context, options entities.NetworkPruneOptions) ([]*entities.NetworkPruneReport, error) {
-	opts := new(network.PruneOptions).WithFilters(options.Filters)
+	opts := new(network.PruneOptions).WithFilters(options.Filters).WithDryRun(options.DryRun)
 	return network.Prune(ic.ClientCtx, opts)
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"time"
 
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/bindings/pods"
@@ -44,14 +47,42 @@ func (ic *ContainerEngine) PodKill(ctx context.Context, namesOrIds []string, opt
 	return reports, nil
 }
 
-func (ic *ContainerEngine) PodLogs(ctx context.Context, nameOrIDs string, options entities.PodLogsOptions) error {
-	// PodLogsOptions are similar but contains few extra fields like ctrName
-	// So cast other values as is so we can re-use the code
-	containerLogsOpts := entities.PodLogsOptionsToContainerLogsOptions(options)
+func (ic *ContainerEngine) PodLogs(_ context.Context, nameOrID string, opts entities.PodLogsOptions) error {
+	options := new(pods.LogOptions).WithFollow(opts.Follow).WithTimestamps(opts.Timestamps)
+	options.WithNames(opts.Names).WithColor(opts.Colors).WithTail(strconv.FormatInt(opts.Tail, 10))
+	if opts.ContainerName != "" {
+		options.WithContainer(opts.ContainerName)
+	}
+	if !opts.Since.IsZero() {
+		options.WithSince(opts.Since.Format(time.RFC3339Nano))
+	}
+	if !opts.Until.IsZero() {
+		options.WithUntil(opts.Until.Format(time.RFC3339Nano))
+	}
+
+	var err error
+	stdoutCh := make(chan string)
+	stderrCh := make(chan string)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		err = pods.Logs(ic.ClientCtx, nameOrID, options, stdoutCh, stderrCh)
+		cancel()
+	}()
 
-	// interface only accepts slice, keep everything consistent
-	name := []string{options.ContainerName}
-	return ic.ContainerLogs(ctx, name, containerLogsOpts)
+	for {
+		select {
+		case <-ctx.Done():
+			return err
+		case line := <-stdoutCh:
+			if opts.StdoutWriter != nil {
+				_, _ = io.WriteString(opts.StdoutWriter, line)
+			}
+		case line := <-stderrCh:
+			if opts.StderrWriter != nil {
+				_, _ = io.WriteString(opts.StderrWriter, line)
+			}
+		}
+	}
 }
 
 func (ic *ContainerEngine) PodPause(ctx context.Context, namesOrIds []string, options entities.PodPauseOptions) ([]*entities.PodPauseReport, error) {
@@ -194,7 +225,8 @@ func (ic *ContainerEngine) PodRm(ctx context.Context, namesOrIds []string, opts
 }
 
 func (ic *ContainerEngine) PodPrune(ctx context.Context, opts entities.PodPruneOptions) ([]*entities.PodPruneReport, error) {
-	return pods.Prune(ic.ClientCtx, nil)
+	options := new(pods.PruneOptions).WithDryRun(opts.DryRun)
+	return pods.Prune(ic.ClientCtx, options)
 }
 
 func (ic *ContainerEngine) PodCreate(ctx context.Context, specg entities.PodSpec) (*entities.PodCreateReport, error) {
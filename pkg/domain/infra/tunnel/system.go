@@ -19,7 +19,7 @@ func (ic *ContainerEngine) SetupRootless(_ context.Context, noMoveProcess bool)
 
 // SystemPrune prunes unused data from the system.
 func (ic *ContainerEngine) SystemPrune(ctx context.Context, opts entities.SystemPruneOptions) (*entities.SystemPruneReport, error) {
-	options := new(system.PruneOptions).WithAll(opts.All).WithVolumes(opts.Volume).WithFilters(opts.Filters).WithExternal(opts.External)
+	options := new(system.PruneOptions).WithAll(opts.All).WithVolumes(opts.Volume).WithFilters(opts.Filters).WithExternal(opts.External).WithDryRun(opts.DryRun)
 	return system.Prune(ic.ClientCtx, options)
 }
 
@@ -31,6 +31,18 @@ func (ic *ContainerEngine) Unshare(ctx context.Context, args []string, options e
 	return errors.New("unshare is not supported on remote clients")
 }
 
+func (ic *ContainerEngine) SystemRootlessNetNSInspect(ctx context.Context) (*entities.SystemRootlessNetNSInspectReport, error) {
+	return nil, errors.New("rootless-netns is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemRootlessNetNSExec(ctx context.Context, args []string) error {
+	return errors.New("rootless-netns is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemRootlessNetNSCleanup(ctx context.Context) error {
+	return errors.New("rootless-netns is not supported on remote clients")
+}
+
 func (ic ContainerEngine) Version(ctx context.Context) (*entities.SystemVersionReport, error) {
 	return system.Version(ic.ClientCtx, nil)
 }
@@ -76,7 +76,7 @@ func (ic *ContainerEngine) VolumeInspect(ctx context.Context, namesOrIds []strin
 }
 
 func (ic *ContainerEngine) VolumePrune(ctx context.Context, opts entities.VolumePruneOptions) ([]*reports.PruneReport, error) {
-	options := new(volumes.PruneOptions).WithFilters(opts.Filters)
+	options := new(volumes.PruneOptions).WithFilters(opts.Filters).WithDryRun(opts.DryRun)
 	return volumes.Prune(ic.ClientCtx, options)
 }
 
@@ -97,9 +97,12 @@ func (ic *ContainerEngine) VolumeExists(ctx context.Context, nameOrID string) (*
 }
 
 // Volumemounted check if a given volume using plugin or filesystem is mounted or not.
-// TODO: Not used and exposed to tunnel. Will be used by `export` command which is unavailable to `podman-remote`
 func (ic *ContainerEngine) VolumeMounted(ctx context.Context, nameOrID string) (*entities.BoolReport, error) {
-	return nil, errors.New("not implemented")
+	data, err := volumes.Inspect(ic.ClientCtx, nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.BoolReport{Value: data.MountCount > 0}, nil
 }
 
 func (ic *ContainerEngine) VolumeMount(ctx context.Context, nameOrIDs []string) ([]*entities.VolumeMountReport, error) {
@@ -113,3 +116,11 @@ func (ic *ContainerEngine) VolumeUnmount(ctx context.Context, nameOrIDs []string
 func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeReloadReport, error) {
 	return nil, errors.New("volume reload is not supported for remote clients")
 }
+
+func (ic *ContainerEngine) VolumeExport(ctx context.Context, nameOrID string, options entities.VolumeExportOptions) error {
+	return volumes.Export(ic.ClientCtx, nameOrID, options.Output, nil)
+}
+
+func (ic *ContainerEngine) VolumeImport(ctx context.Context, nameOrID string, options entities.VolumeImportOptions) error {
+	return volumes.Import(ic.ClientCtx, nameOrID, options.Input, nil)
+}
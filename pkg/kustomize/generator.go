@@ -0,0 +1,279 @@
+package kustomize
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// generator is an entry of configMapGenerator or secretGenerator.
+type generator struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace"`
+	Behavior  string            `yaml:"behavior"`
+	Literals  []string          `yaml:"literals"`
+	Files     []string          `yaml:"files"`
+	Envs      []string          `yaml:"envs"`
+	Env       string            `yaml:"env"`
+	Type      string            `yaml:"type"`
+	Options   *generatorOptions `yaml:"options"`
+}
+
+// generatorOptions are the options of generators, either for all generators
+// of a kustomization or for a single one.
+type generatorOptions struct {
+	Labels                map[string]string `yaml:"labels"`
+	Annotations           map[string]string `yaml:"annotations"`
+	DisableNameSuffixHash bool              `yaml:"disableNameSuffixHash"`
+}
+
+// generate adds the ConfigMaps and Secrets of the generators of k to
+// resources. Generators with the merge or replace behavior change a
+// generated resource of a base instead.
+func generate(dir string, k *kustomization, resources []*resource) ([]*resource, error) {
+	for _, g := range k.ConfigMapGenerator {
+		data, err := generatorData(dir, &g)
+		if err != nil {
+			return nil, fmt.Errorf("configMapGenerator %s: %w", g.Name, err)
+		}
+		if resources, err = addGenerated(resources, "ConfigMap", &g, k.GeneratorOptions, data); err != nil {
+			return nil, fmt.Errorf("configMapGenerator %s: %w", g.Name, err)
+		}
+	}
+	for _, g := range k.SecretGenerator {
+		data, err := generatorData(dir, &g)
+		if err != nil {
+			return nil, fmt.Errorf("secretGenerator %s: %w", g.Name, err)
+		}
+		if resources, err = addGenerated(resources, "Secret", &g, k.GeneratorOptions, data); err != nil {
+			return nil, fmt.Errorf("secretGenerator %s: %w", g.Name, err)
+		}
+	}
+	return resources, nil
+}
+
+// generatorData reads the key-value pairs of a generator from its literals,
+// files and env files.
+func generatorData(dir string, g *generator) (map[string][]byte, error) {
+	if g.Name == "" {
+		return nil, fmt.Errorf("name must be set")
+	}
+	data := make(map[string][]byte)
+	add := func(key string, value []byte) error {
+		if _, ok := data[key]; ok {
+			return fmt.Errorf("duplicate key %q", key)
+		}
+		data[key] = value
+		return nil
+	}
+
+	envs := g.Envs
+	if g.Env != "" {
+		envs = append(envs, g.Env)
+	}
+	for _, env := range envs {
+		content, err := readFile(dir, env)
+		if err != nil {
+			return nil, err
+		}
+		for i, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			kv := strings.SplitN(line, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, fmt.Errorf("%s line %d: expected KEY=VALUE", env, i+1)
+			}
+			if err := add(kv[0], []byte(kv[1])); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, literal := range g.Literals {
+		kv := strings.SplitN(literal, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("literal %q must be KEY=VALUE", literal)
+		}
+		value := kv[1]
+		if len(value) > 1 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		if err := add(kv[0], []byte(value)); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range g.Files {
+		key, path := filepath.Base(file), file
+		if kv := strings.SplitN(file, "=", 2); len(kv) == 2 {
+			key, path = kv[0], kv[1]
+		}
+		content, err := readFile(dir, path)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(key, content); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// addGenerated creates the resource of a generator, or merges it into or
+// replaces the resource of the same kind and name.
+func addGenerated(resources []*resource, kind string, g *generator, globalOptions *generatorOptions, data map[string][]byte) ([]*resource, error) {
+	var existing *resource
+	for _, r := range resources {
+		if r.kind() == kind && r.origName == g.Name {
+			existing = r
+			break
+		}
+	}
+
+	switch g.Behavior {
+	case "", "create":
+		if existing != nil {
+			return nil, fmt.Errorf("%s %s already exists, use the merge or replace behavior", kind, g.Name)
+		}
+		r := &resource{
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       kind,
+				"metadata":   map[string]interface{}{"name": g.Name},
+			},
+			origName:  g.Name,
+			needsHash: true,
+		}
+		if kind == "Secret" {
+			secretType := g.Type
+			if secretType == "" {
+				secretType = "Opaque"
+			}
+			r.obj["type"] = secretType
+		}
+		setGeneratedData(r, data, false)
+		applyGeneratorOptions(r, g, globalOptions)
+		return append(resources, r), nil
+	case "merge", "replace":
+		if existing == nil {
+			return nil, fmt.Errorf("no %s %s to %s", kind, g.Name, g.Behavior)
+		}
+		setGeneratedData(existing, data, g.Behavior == "merge")
+		applyGeneratorOptions(existing, g, globalOptions)
+		return resources, nil
+	default:
+		return nil, fmt.Errorf("unknown behavior %q", g.Behavior)
+	}
+}
+
+// setGeneratedData sets the data of a generated ConfigMap or Secret. Values
+// of Secrets and values of ConfigMaps which are not valid UTF-8 are encoded
+// with base64. If merge is set, the data is added to the existing data.
+func setGeneratedData(r *resource, data map[string][]byte, merge bool) {
+	if !merge {
+		delete(r.obj, "data")
+		delete(r.obj, "binaryData")
+	}
+	for key, value := range data {
+		field, encoded := "data", string(value)
+		switch {
+		case r.kind() == "Secret":
+			encoded = base64.StdEncoding.EncodeToString(value)
+		case !utf8.Valid(value):
+			field, encoded = "binaryData", base64.StdEncoding.EncodeToString(value)
+		}
+		nestedMap(r.obj, true, field)[key] = encoded
+	}
+}
+
+func applyGeneratorOptions(r *resource, g *generator, globalOptions *generatorOptions) {
+	if g.Namespace != "" {
+		nestedMap(r.obj, true, "metadata")["namespace"] = g.Namespace
+	}
+	for _, options := range []*generatorOptions{globalOptions, g.Options} {
+		if options == nil {
+			continue
+		}
+		for k, v := range options.Labels {
+			nestedMap(r.obj, true, "metadata", "labels")[k] = v
+		}
+		for k, v := range options.Annotations {
+			nestedMap(r.obj, true, "metadata", "annotations")[k] = v
+		}
+		if options.DisableNameSuffixHash {
+			r.needsHash = false
+		}
+	}
+}
+
+// addHashSuffixes appends a hash of their content to the names of generated
+// resources and updates the references to them, so that pods are recreated
+// with the new content when it changes. The hash follows the scheme of
+// kustomize.
+func addHashSuffixes(resources []*resource) error {
+	renames := make(map[string]map[string]string)
+	for _, r := range resources {
+		if !r.needsHash {
+			continue
+		}
+		encoded := map[string]interface{}{
+			"kind": r.kind(),
+			"name": r.name(),
+			"data": stringMap(r.obj["data"]),
+		}
+		switch r.kind() {
+		case "ConfigMap":
+			if binaryData := stringMap(r.obj["binaryData"]); len(binaryData) > 0 {
+				encoded["binaryData"] = binaryData
+			}
+		case "Secret":
+			encoded["type"] = r.obj["type"]
+		}
+		content, err := json.Marshal(encoded)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		name := r.name()
+		r.setName(name + "-" + encodeHash(hex.EncodeToString(sum[:])))
+		addRename(renames, r.kind(), name, r.name())
+	}
+	updateReferences(resources, renames)
+	return nil
+}
+
+// encodeHash shortens a hex encoded hash to ten characters and replaces
+// vowels and digits which look like vowels, to avoid generating words.
+func encodeHash(hash string) string {
+	enc := []byte(hash[:10])
+	for i := range enc {
+		switch enc[i] {
+		case '0':
+			enc[i] = 'g'
+		case '1':
+			enc[i] = 'h'
+		case '3':
+			enc[i] = 'k'
+		case 'a':
+			enc[i] = 'm'
+		case 'e':
+			enc[i] = 't'
+		}
+	}
+	return string(enc)
+}
+
+// stringMap converts a map decoded from YAML to a map of strings.
+func stringMap(v interface{}) map[string]string {
+	m, _ := v.(map[string]interface{})
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = fmt.Sprint(v)
+	}
+	return result
+}
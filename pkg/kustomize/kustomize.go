@@ -0,0 +1,258 @@
+// Package kustomize renders kustomization directories into Kubernetes YAML
+// which can be passed to kube play. It implements the subset of kustomize
+// commonly used to organize manifests in bases and overlays: resources,
+// patches, namePrefix, commonLabels, configMapGenerator and secretGenerator.
+package kustomize
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// kustomizationFileNames are the names of the kustomization file in a
+// kustomization directory, in the order they are looked up.
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// kustomization is the content of a kustomization file. Unknown fields are
+// rejected instead of being silently ignored.
+type kustomization struct {
+	APIVersion            string            `yaml:"apiVersion"`
+	Kind                  string            `yaml:"kind"`
+	Resources             []string          `yaml:"resources"`
+	Bases                 []string          `yaml:"bases"`
+	NamePrefix            string            `yaml:"namePrefix"`
+	CommonLabels          map[string]string `yaml:"commonLabels"`
+	Patches               []patch           `yaml:"patches"`
+	PatchesStrategicMerge []string          `yaml:"patchesStrategicMerge"`
+	PatchesJSON6902       []patch           `yaml:"patchesJson6902"`
+	ConfigMapGenerator    []generator       `yaml:"configMapGenerator"`
+	SecretGenerator       []generator       `yaml:"secretGenerator"`
+	GeneratorOptions      *generatorOptions `yaml:"generatorOptions"`
+}
+
+// resource is a Kubernetes object read from a resource file or created by a
+// generator.
+type resource struct {
+	obj map[string]interface{}
+	// origName is the name of the resource before a prefix or a hash
+	// suffix was added.
+	origName string
+	// needsHash is set for generated resources, which get a hash of their
+	// content appended to their name once all kustomizations are applied.
+	needsHash bool
+}
+
+// Build renders the kustomization in dir and returns the resulting resources
+// as a multi-document YAML stream.
+func Build(dir string) ([]byte, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := build(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := addHashSuffixes(resources); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	for i, r := range resources {
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		data, err := yamlv3.Marshal(r.obj)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(data)
+	}
+	return b.Bytes(), nil
+}
+
+// build applies the kustomization in dir. parents are the kustomization
+// directories which include dir, they are used to detect cycles.
+func build(dir string, parents []string) ([]*resource, error) {
+	for _, parent := range parents {
+		if parent == dir {
+			return nil, fmt.Errorf("cycle in kustomization resources: %s", strings.Join(append(parents, dir), " -> "))
+		}
+	}
+	parents = append(parents, dir)
+
+	k, err := readKustomization(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*resource
+	for _, res := range append(k.Bases, k.Resources...) {
+		r, err := loadResources(dir, res, parents)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r...)
+	}
+
+	if resources, err = generate(dir, k, resources); err != nil {
+		return nil, err
+	}
+	if err := applyPatches(dir, k, resources); err != nil {
+		return nil, err
+	}
+	if k.NamePrefix != "" {
+		renames := make(map[string]map[string]string)
+		for _, r := range resources {
+			name := r.name()
+			r.setName(k.NamePrefix + name)
+			addRename(renames, r.kind(), name, r.name())
+		}
+		updateReferences(resources, renames)
+	}
+	if len(k.CommonLabels) > 0 {
+		for _, r := range resources {
+			addCommonLabels(r, k.CommonLabels)
+		}
+	}
+	return resources, nil
+}
+
+// readKustomization reads the kustomization file in dir.
+func readKustomization(dir string) (*kustomization, error) {
+	for _, name := range kustomizationFileNames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		k := new(kustomization)
+		d := yamlv3.NewDecoder(bytes.NewReader(data))
+		d.KnownFields(true)
+		if err := d.Decode(k); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("reading %s: %w", filepath.Join(dir, name), err)
+		}
+		if k.Kind != "" && k.Kind != "Kustomization" {
+			return nil, fmt.Errorf("%s: unsupported kind %q", filepath.Join(dir, name), k.Kind)
+		}
+		return k, nil
+	}
+	return nil, fmt.Errorf("no kustomization file found in %s", dir)
+}
+
+// loadResources loads the resources of a resources entry of the
+// kustomization in dir. The entry is either a file, which must be inside of
+// dir, or another kustomization directory.
+func loadResources(dir, entry string, parents []string) ([]*resource, error) {
+	if strings.Contains(entry, "://") {
+		return nil, fmt.Errorf("remote resource %q is not supported", entry)
+	}
+	path := entry
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return build(filepath.Clean(path), parents)
+	}
+
+	data, err := readFile(dir, entry)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := decodeDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("reading resource %s: %w", entry, err)
+	}
+	resources := make([]*resource, 0, len(objs))
+	for _, obj := range objs {
+		r := &resource{obj: obj}
+		if r.kind() == "" || r.name() == "" {
+			return nil, fmt.Errorf("resource %s: kind and metadata.name must be set", entry)
+		}
+		r.origName = r.name()
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+// readFile reads a file referenced by the kustomization in dir. Like
+// kustomize, only files inside of dir can be read.
+func readFile(dir, name string) ([]byte, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	if rel, err := filepath.Rel(dir, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("file %q is not in or below the kustomization directory %s", name, dir)
+	}
+	return os.ReadFile(path)
+}
+
+// decodeDocuments decodes all non-empty documents of a YAML stream.
+func decodeDocuments(data []byte) ([]map[string]interface{}, error) {
+	var objs []map[string]interface{}
+	d := yamlv3.NewDecoder(bytes.NewReader(data))
+	for {
+		var obj map[string]interface{}
+		err := d.Decode(&obj)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+func (r *resource) kind() string {
+	kind, _ := r.obj["kind"].(string)
+	return kind
+}
+
+func (r *resource) name() string {
+	name, _ := nestedMap(r.obj, false, "metadata")["name"].(string)
+	return name
+}
+
+func (r *resource) setName(name string) {
+	nestedMap(r.obj, true, "metadata")["name"] = name
+}
+
+// nestedMap returns the map at path in obj. Missing maps are created if
+// create is set, otherwise nil is returned.
+func nestedMap(obj map[string]interface{}, create bool, path ...string) map[string]interface{} {
+	for _, key := range path {
+		if obj == nil {
+			return nil
+		}
+		next, ok := obj[key].(map[string]interface{})
+		if !ok {
+			if !create {
+				return nil
+			}
+			next = make(map[string]interface{})
+			obj[key] = next
+		}
+		obj = next
+	}
+	return obj
+}
@@ -0,0 +1,457 @@
+package kustomize
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func buildResources(t *testing.T, dir string) map[string]map[string]interface{} {
+	data, err := Build(dir)
+	require.NoError(t, err)
+	objs, err := decodeDocuments(data)
+	require.NoError(t, err)
+	resources := make(map[string]map[string]interface{})
+	for _, obj := range objs {
+		r := &resource{obj: obj}
+		resources[r.kind()+"/"+r.name()] = obj
+	}
+	return resources
+}
+
+func lookup(t *testing.T, obj interface{}, path ...interface{}) interface{} {
+	for _, p := range path {
+		switch key := p.(type) {
+		case string:
+			m, ok := obj.(map[string]interface{})
+			require.True(t, ok, "%v is not a map", path)
+			obj = m[key]
+		case int:
+			l, ok := obj.([]interface{})
+			require.True(t, ok, "%v is not a list", path)
+			require.Greater(t, len(l), key)
+			obj = l[key]
+		}
+	}
+	return obj
+}
+
+const testDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.23
+        envFrom:
+        - configMapRef:
+            name: web-config
+        env:
+        - name: PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: web-secret
+              key: password
+        ports:
+        - containerPort: 80
+      - name: sidecar
+        image: busybox
+      volumes:
+      - name: data
+        persistentVolumeClaim:
+          claimName: data
+`
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base/kustomization.yaml": `resources:
+- deployment.yaml
+- service.yaml
+- pvc.yaml
+- ingress.yaml
+configMapGenerator:
+- name: web-config
+  literals:
+  - MODE=base
+secretGenerator:
+- name: web-secret
+  literals:
+  - password=secret
+`,
+		"base/deployment.yaml": testDeployment,
+		"base/service.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+  ports:
+  - port: 80
+`,
+		"base/pvc.yaml": `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+spec:
+  accessModes: [ReadWriteOnce]
+`,
+		"base/ingress.yaml": `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  defaultBackend:
+    service:
+      name: web
+      port:
+        number: 80
+  tls:
+  - hosts: [web.example.com]
+    secretName: web-secret
+  rules:
+  - host: web.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web
+            port:
+              number: 80
+      - path: /static
+        pathType: Prefix
+        backend:
+          service:
+            name: static
+            port:
+              number: 80
+`,
+		"overlay/kustomization.yaml": `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- ../base
+namePrefix: prod-
+commonLabels:
+  env: prod
+configMapGenerator:
+- name: web-config
+  behavior: merge
+  files:
+  - settings.conf
+  envs:
+  - extra.env
+patches:
+- patch: |-
+    apiVersion: apps/v1
+    kind: Deployment
+    metadata:
+      name: web
+    spec:
+      template:
+        spec:
+          containers:
+          - name: web
+            image: nginx:1.25
+            ports:
+            - containerPort: 8080
+          - name: sidecar
+            $patch: delete
+- target:
+    kind: Service
+    name: web
+  patch: |-
+    - op: replace
+      path: /spec/ports/0/port
+      value: 8080
+    - op: add
+      path: /metadata/annotations
+      value:
+        patched: "true"
+`,
+		"overlay/settings.conf": "key = value\n",
+		"overlay/extra.env":     "# comment\nLEVEL=debug\n",
+	})
+
+	resources := buildResources(t, filepath.Join(dir, "overlay"))
+	require.Len(t, resources, 6)
+
+	var configMapName, secretName string
+	for key := range resources {
+		switch {
+		case strings.HasPrefix(key, "ConfigMap/prod-web-config-"):
+			configMapName = strings.TrimPrefix(key, "ConfigMap/")
+		case strings.HasPrefix(key, "Secret/prod-web-secret-"):
+			secretName = strings.TrimPrefix(key, "Secret/")
+		}
+	}
+	require.NotEmpty(t, configMapName, "generated ConfigMap not found in %v", resources)
+	require.NotEmpty(t, secretName, "generated Secret not found in %v", resources)
+	assert.Len(t, configMapName, len("prod-web-config-")+10)
+
+	configMap := resources["ConfigMap/"+configMapName]
+	assert.Equal(t, map[string]interface{}{
+		"MODE":          "base",
+		"LEVEL":         "debug",
+		"settings.conf": "key = value\n",
+	}, configMap["data"])
+	assert.Equal(t, "prod", lookup(t, configMap, "metadata", "labels", "env"))
+	assert.Equal(t, "c2VjcmV0", lookup(t, resources["Secret/"+secretName], "data", "password"))
+	assert.Equal(t, "Opaque", resources["Secret/"+secretName]["type"])
+
+	deployment := resources["Deployment/prod-web"]
+	require.NotNil(t, deployment)
+	assert.Equal(t, map[string]interface{}{"app": "web", "env": "prod"}, lookup(t, deployment, "spec", "selector", "matchLabels"))
+	assert.Equal(t, map[string]interface{}{"app": "web", "env": "prod"}, lookup(t, deployment, "spec", "template", "metadata", "labels"))
+	containers := lookup(t, deployment, "spec", "template", "spec", "containers").([]interface{})
+	require.Len(t, containers, 1)
+	assert.Equal(t, "nginx:1.25", lookup(t, containers, 0, "image"))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"containerPort": 80},
+		map[string]interface{}{"containerPort": 8080},
+	}, lookup(t, containers, 0, "ports"))
+	assert.Equal(t, configMapName, lookup(t, containers, 0, "envFrom", 0, "configMapRef", "name"))
+	assert.Equal(t, secretName, lookup(t, containers, 0, "env", 0, "valueFrom", "secretKeyRef", "name"))
+	assert.Equal(t, "prod-data", lookup(t, deployment, "spec", "template", "spec", "volumes", 0, "persistentVolumeClaim", "claimName"))
+
+	service := resources["Service/prod-web"]
+	require.NotNil(t, service)
+	assert.Equal(t, 8080, lookup(t, service, "spec", "ports", 0, "port"))
+	assert.Equal(t, "true", lookup(t, service, "metadata", "annotations", "patched"))
+	assert.Equal(t, map[string]interface{}{"app": "web", "env": "prod"}, lookup(t, service, "spec", "selector"))
+
+	assert.NotNil(t, resources["PersistentVolumeClaim/prod-data"])
+
+	// the Ingress refers to the renamed Service and Secret, references to
+	// resources which are not part of the build are kept
+	ingress := resources["Ingress/prod-web"]
+	require.NotNil(t, ingress)
+	assert.Equal(t, "prod-web", lookup(t, ingress, "spec", "defaultBackend", "service", "name"))
+	assert.Equal(t, secretName, lookup(t, ingress, "spec", "tls", 0, "secretName"))
+	assert.Equal(t, "prod-web", lookup(t, ingress, "spec", "rules", 0, "http", "paths", 0, "backend", "service", "name"))
+	assert.Equal(t, "static", lookup(t, ingress, "spec", "rules", 0, "http", "paths", 1, "backend", "service", "name"))
+
+	// the hash changes with the content, so that pods are recreated
+	writeFiles(t, dir, map[string]string{"overlay/extra.env": "LEVEL=info\n"})
+	resources = buildResources(t, filepath.Join(dir, "overlay"))
+	assert.Nil(t, resources["ConfigMap/"+configMapName])
+	assert.NotNil(t, resources["Secret/"+secretName])
+}
+
+func TestUpdateReferencesIngressV1beta1(t *testing.T) {
+	ingress := &resource{obj: map[string]interface{}{
+		"kind": "Ingress",
+		"spec": map[string]interface{}{
+			"backend": map[string]interface{}{"serviceName": "web"},
+			"rules": []interface{}{map[string]interface{}{
+				"http": map[string]interface{}{
+					"paths": []interface{}{map[string]interface{}{
+						"backend": map[string]interface{}{"serviceName": "web"},
+					}},
+				},
+			}},
+		},
+	}}
+	updateReferences([]*resource{ingress}, map[string]map[string]string{"Service": {"web": "prod-web"}})
+	assert.Equal(t, "prod-web", lookup(t, ingress.obj, "spec", "backend", "serviceName"))
+	assert.Equal(t, "prod-web", lookup(t, ingress.obj, "spec", "rules", 0, "http", "paths", 0, "backend", "serviceName"))
+}
+
+func TestBuildGeneratorOptions(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"kustomization.yaml": `generatorOptions:
+  disableNameSuffixHash: true
+  labels:
+    generated: "yes"
+configMapGenerator:
+- name: config
+  literals:
+  - QUOTED="a b"
+  files:
+  - renamed.txt=data.txt
+secretGenerator:
+- name: tls
+  type: kubernetes.io/tls
+  files:
+  - tls.crt=data.txt
+`,
+		"data.txt": "data",
+	})
+
+	resources := buildResources(t, dir)
+	configMap := resources["ConfigMap/config"]
+	require.NotNil(t, configMap)
+	assert.Equal(t, map[string]interface{}{"QUOTED": "a b", "renamed.txt": "data"}, configMap["data"])
+	assert.Equal(t, "yes", lookup(t, configMap, "metadata", "labels", "generated"))
+	secret := resources["Secret/tls"]
+	require.NotNil(t, secret)
+	assert.Equal(t, "kubernetes.io/tls", secret["type"])
+}
+
+func TestEncodeHash(t *testing.T) {
+	assert.Equal(t, "gh2k456m6t", encodeHash("0123456a6e89abcdef"))
+}
+
+func TestStrategicMerge(t *testing.T) {
+	var obj, patch map[string]interface{}
+	require.NoError(t, yamlv3.Unmarshal([]byte(`
+spec:
+  replicas: 1
+  paused: true
+  template:
+    spec:
+      containers:
+      - name: a
+        args: [x, y]
+        volumeMounts:
+        - mountPath: /data
+          name: data
+`), &obj))
+	require.NoError(t, yamlv3.Unmarshal([]byte(`
+spec:
+  replicas: 3
+  paused: null
+  template:
+    spec:
+      containers:
+      - name: a
+        args: [z]
+        volumeMounts:
+        - mountPath: /cache
+          name: cache
+      - name: b
+        image: busybox
+`), &patch))
+
+	var expected map[string]interface{}
+	require.NoError(t, yamlv3.Unmarshal([]byte(`
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: a
+        args: [z]
+        volumeMounts:
+        - mountPath: /data
+          name: data
+        - mountPath: /cache
+          name: cache
+      - name: b
+        image: busybox
+`), &expected))
+	assert.Equal(t, expected, strategicMerge(obj, patch))
+}
+
+func TestJSONPatch(t *testing.T) {
+	r := &resource{obj: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "labels": map[string]interface{}{"a/b": "c"}},
+		"spec":     map[string]interface{}{"args": []interface{}{"x", "y"}},
+	}}
+	for _, op := range []jsonPatchOp{
+		{Op: "add", Path: "/spec/args/1", Value: "inserted"},
+		{Op: "add", Path: "/spec/args/-", Value: "last"},
+		{Op: "remove", Path: "/spec/args/0"},
+		{Op: "replace", Path: "/metadata/labels/a~1b", Value: "d"},
+		{Op: "copy", From: "/metadata/name", Path: "/spec/name"},
+		{Op: "move", From: "/spec/name", Path: "/spec/hostname"},
+		{Op: "test", Path: "/spec/hostname", Value: "web"},
+	} {
+		require.NoError(t, applyJSONPatchOp(r, op), "%+v", op)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "labels": map[string]interface{}{"a/b": "d"}},
+		"spec":     map[string]interface{}{"args": []interface{}{"inserted", "y", "last"}, "hostname": "web"},
+	}, r.obj)
+
+	assert.EqualError(t, applyJSONPatchOp(r, jsonPatchOp{Op: "replace", Path: "/spec/missing", Value: 1}), "path /spec/missing does not exist")
+	assert.EqualError(t, applyJSONPatchOp(r, jsonPatchOp{Op: "test", Path: "/spec/hostname", Value: "db"}), "test of /spec/hostname failed")
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{
+			name:  "NoKustomization",
+			files: map[string]string{"deployment.yaml": testDeployment},
+			err:   "no kustomization file found in ",
+		},
+		{
+			name:  "UnknownField",
+			files: map[string]string{"kustomization.yaml": "images:\n- name: nginx\n"},
+			err:   "field images not found in type kustomize.kustomization",
+		},
+		{
+			name:  "FileOutsideOfDirectory",
+			files: map[string]string{"kustomization.yaml": "resources:\n- ../deployment.yaml\n", "../deployment.yaml": testDeployment},
+			err:   `file "../deployment.yaml" is not in or below the kustomization directory`,
+		},
+		{
+			name:  "RemoteResource",
+			files: map[string]string{"kustomization.yaml": "resources:\n- https://example.com/app\n"},
+			err:   `remote resource "https://example.com/app" is not supported`,
+		},
+		{
+			name:  "Cycle",
+			files: map[string]string{"kustomization.yaml": "resources:\n- .\n"},
+			err:   "cycle in kustomization resources",
+		},
+		{
+			name:  "DuplicateGenerator",
+			files: map[string]string{"kustomization.yaml": "configMapGenerator:\n- name: a\n  literals: [A=1]\n- name: a\n  literals: [A=2]\n"},
+			err:   "configMapGenerator a: ConfigMap a already exists, use the merge or replace behavior",
+		},
+		{
+			name:  "MergeMissing",
+			files: map[string]string{"kustomization.yaml": "configMapGenerator:\n- name: a\n  behavior: merge\n  literals: [A=1]\n"},
+			err:   "configMapGenerator a: no ConfigMap a to merge",
+		},
+		{
+			name: "PatchWithoutMatch",
+			files: map[string]string{
+				"kustomization.yaml": "resources: [deployment.yaml]\npatchesStrategicMerge:\n- patch.yaml\n",
+				"deployment.yaml":    testDeployment,
+				"patch.yaml":         "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: db\n",
+			},
+			err: "patchesStrategicMerge: no resource matches Deployment db",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, test.files)
+			_, err := Build(dir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
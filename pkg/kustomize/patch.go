@@ -0,0 +1,526 @@
+package kustomize
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// patch is an entry of patches or patchesJson6902. The patch is either
+// inline or read from a file at path.
+type patch struct {
+	Path   string    `yaml:"path"`
+	Patch  string    `yaml:"patch"`
+	Target *selector `yaml:"target"`
+}
+
+// selector selects the resources a patch applies to. Name is a regular
+// expression, like in kustomize.
+type selector struct {
+	Group              string `yaml:"group"`
+	Version            string `yaml:"version"`
+	Kind               string `yaml:"kind"`
+	Name               string `yaml:"name"`
+	Namespace          string `yaml:"namespace"`
+	LabelSelector      string `yaml:"labelSelector"`
+	AnnotationSelector string `yaml:"annotationSelector"`
+}
+
+// jsonPatchOp is an operation of a JSON patch (RFC 6902).
+type jsonPatchOp struct {
+	Op    string      `yaml:"op"`
+	Path  string      `yaml:"path"`
+	From  string      `yaml:"from"`
+	Value interface{} `yaml:"value"`
+}
+
+// strategicMergeKeys are the keys identifying the items of lists which are
+// merged instead of replaced by strategic merge patches, indexed by the name
+// of the list. They follow the patch strategies of the Kubernetes API.
+var strategicMergeKeys = map[string][]string{
+	"containers":       {"name"},
+	"initContainers":   {"name"},
+	"volumes":          {"name"},
+	"env":              {"name"},
+	"imagePullSecrets": {"name"},
+	"volumeMounts":     {"mountPath"},
+	"volumeDevices":    {"devicePath"},
+	"hostAliases":      {"ip"},
+	"ports":            {"containerPort", "port"},
+}
+
+// applyPatches applies the patches of the kustomization in dir, in the order
+// kustomize applies them.
+func applyPatches(dir string, k *kustomization, resources []*resource) error {
+	for _, p := range k.PatchesStrategicMerge {
+		content := []byte(p)
+		// entries are either file names or inline patches
+		if !strings.Contains(p, "\n") {
+			var err error
+			if content, err = readFile(dir, p); err != nil {
+				return err
+			}
+		}
+		if err := applyPatch(content, nil, resources); err != nil {
+			return fmt.Errorf("patchesStrategicMerge: %w", err)
+		}
+	}
+	for _, p := range append(k.PatchesJSON6902, k.Patches...) {
+		content := []byte(p.Patch)
+		switch {
+		case p.Path != "" && p.Patch != "":
+			return fmt.Errorf("patch %s: path and patch cannot be used together", p.Path)
+		case p.Path != "":
+			var err error
+			if content, err = readFile(dir, p.Path); err != nil {
+				return err
+			}
+		case p.Patch == "":
+			return fmt.Errorf("patch without path or patch")
+		}
+		if err := applyPatch(content, p.Target, resources); err != nil {
+			return fmt.Errorf("patches: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyPatch applies a strategic merge patch or a JSON patch to the
+// resources selected by target. Without a target, the kind and name of a
+// strategic merge patch select the resource to patch.
+func applyPatch(content []byte, target *selector, resources []*resource) error {
+	var decoded interface{}
+	if err := yamlv3.Unmarshal(content, &decoded); err != nil {
+		return err
+	}
+
+	if _, ok := decoded.([]interface{}); ok {
+		var ops []jsonPatchOp
+		if err := yamlv3.NewDecoder(bytes.NewReader(content)).Decode(&ops); err != nil {
+			return err
+		}
+		if target == nil {
+			return fmt.Errorf("JSON patches require a target")
+		}
+		matched := false
+		for _, r := range resources {
+			ok, err := target.matches(r)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			matched = true
+			for _, op := range ops {
+				if err := applyJSONPatchOp(r, op); err != nil {
+					return fmt.Errorf("%s %s: %w", r.kind(), r.name(), err)
+				}
+			}
+		}
+		if !matched {
+			return fmt.Errorf("no resource matches the target of the JSON patch")
+		}
+		return nil
+	}
+
+	docs, err := decodeDocuments(content)
+	if err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		sel := target
+		if sel == nil {
+			p := &resource{obj: doc}
+			if p.kind() == "" || p.name() == "" {
+				return fmt.Errorf("strategic merge patches without target must set kind and metadata.name")
+			}
+			sel = &selector{Kind: p.kind(), Name: regexp.QuoteMeta(p.name())}
+		}
+		matched := false
+		for _, r := range resources {
+			ok, err := sel.matches(r)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			matched = true
+			patched := strategicMerge(r.obj, deepCopy(doc).(map[string]interface{}))
+			if patched == nil {
+				return fmt.Errorf("%s %s: deleting resources with patches is not supported", r.kind(), r.name())
+			}
+			// with a target, the name of the patch is irrelevant
+			// and must not rename the patched resource
+			name := r.name()
+			r.obj = patched
+			r.setName(name)
+		}
+		if !matched {
+			return fmt.Errorf("no resource matches %s %s", sel.Kind, sel.Name)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the resource is selected. The name matches the
+// current name of the resource as well as the name it had before a prefix
+// or hash suffix was added.
+func (s *selector) matches(r *resource) (bool, error) {
+	if s.Kind != "" && s.Kind != r.kind() {
+		return false, nil
+	}
+	apiVersion, _ := r.obj["apiVersion"].(string)
+	group, version := "", apiVersion
+	if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+		group, version = apiVersion[:i], apiVersion[i+1:]
+	}
+	if (s.Group != "" && s.Group != group) || (s.Version != "" && s.Version != version) {
+		return false, nil
+	}
+	if s.Namespace != "" {
+		if namespace, _ := nestedMap(r.obj, false, "metadata")["namespace"].(string); namespace != s.Namespace {
+			return false, nil
+		}
+	}
+	if s.Name != "" {
+		re, err := regexp.Compile("^(?:" + s.Name + ")$")
+		if err != nil {
+			return false, fmt.Errorf("invalid target name %q: %w", s.Name, err)
+		}
+		if !re.MatchString(r.name()) && !re.MatchString(r.origName) {
+			return false, nil
+		}
+	}
+	for _, sel := range []struct{ selector, field string }{
+		{s.LabelSelector, "labels"},
+		{s.AnnotationSelector, "annotations"},
+	} {
+		ok, err := matchesSelector(sel.selector, nestedMap(r.obj, false, "metadata", sel.field))
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchesSelector reports whether a comma separated list of key=value,
+// key!=value and key requirements matches the given labels or annotations.
+func matchesSelector(sel string, values map[string]interface{}) (bool, error) {
+	if sel == "" {
+		return true, nil
+	}
+	for _, req := range strings.Split(sel, ",") {
+		req = strings.TrimSpace(req)
+		switch {
+		case strings.Contains(req, "!="):
+			kv := strings.SplitN(req, "!=", 2)
+			if v, ok := values[strings.TrimSpace(kv[0])]; ok && fmt.Sprint(v) == strings.TrimSpace(kv[1]) {
+				return false, nil
+			}
+		case strings.Contains(req, "="):
+			kv := strings.SplitN(strings.Replace(req, "==", "=", 1), "=", 2)
+			if v, ok := values[strings.TrimSpace(kv[0])]; !ok || fmt.Sprint(v) != strings.TrimSpace(kv[1]) {
+				return false, nil
+			}
+		case strings.HasPrefix(req, "!"):
+			if _, ok := values[req[1:]]; ok {
+				return false, nil
+			}
+		case req != "" && !strings.ContainsAny(req, " ()"):
+			if _, ok := values[req]; !ok {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported selector requirement %q", req)
+		}
+	}
+	return true, nil
+}
+
+// strategicMerge merges patch into obj and returns the result. A nil value in the patch deletes the field, the "$patch" directive with the
+// values "replace" and "delete" replaces or deletes a map. The result is nil
+// if obj is deleted.
+func strategicMerge(obj, patch map[string]interface{}) map[string]interface{} {
+	switch patch["$patch"] {
+	case "delete":
+		return nil
+	case "replace":
+		delete(patch, "$patch")
+		return patch
+	}
+	if obj == nil {
+		obj = make(map[string]interface{})
+	}
+	for key, value := range patch {
+		switch v := value.(type) {
+		case nil:
+			delete(obj, key)
+		case map[string]interface{}:
+			current, _ := obj[key].(map[string]interface{})
+			if merged := strategicMerge(current, v); merged != nil {
+				obj[key] = merged
+			} else {
+				delete(obj, key)
+			}
+		case []interface{}:
+			current, _ := obj[key].([]interface{})
+			obj[key] = mergeList(current, v, key)
+		default:
+			obj[key] = value
+		}
+	}
+	return obj
+}
+
+// mergeList merges the items of a patch into a list. Lists of maps with a
+// merge key are merged item by item, all other lists are replaced.
+func mergeList(list, patch []interface{}, field string) []interface{} {
+	mergeKey := ""
+	for _, key := range strategicMergeKeys[field] {
+		if len(patch) > 0 && allHaveKey(patch, key) {
+			mergeKey = key
+			break
+		}
+	}
+	if mergeKey == "" {
+		return patch
+	}
+
+	result := append([]interface{}{}, list...)
+	for _, item := range patch {
+		p := item.(map[string]interface{})
+		index := -1
+		for i, existing := range result {
+			if m, ok := existing.(map[string]interface{}); ok && reflect.DeepEqual(m[mergeKey], p[mergeKey]) {
+				index = i
+				break
+			}
+		}
+		switch {
+		case index < 0 && p["$patch"] == "delete":
+		case index < 0:
+			delete(p, "$patch")
+			result = append(result, p)
+		default:
+			current, _ := result[index].(map[string]interface{})
+			if merged := strategicMerge(current, p); merged != nil {
+				result[index] = merged
+			} else {
+				result = append(result[:index], result[index+1:]...)
+			}
+		}
+	}
+	return result
+}
+
+func allHaveKey(list []interface{}, key string) bool {
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// applyJSONPatchOp applies a single JSON patch operation to the resource.
+func applyJSONPatchOp(r *resource, op jsonPatchOp) error {
+	var root interface{} = r.obj
+	var err error
+	switch op.Op {
+	case "add":
+		root, err = jsonPointerSet(root, op.Path, deepCopy(op.Value), true)
+	case "replace":
+		root, err = jsonPointerSet(root, op.Path, deepCopy(op.Value), false)
+	case "remove":
+		root, _, err = jsonPointerRemove(root, op.Path)
+	case "move", "copy":
+		var value interface{}
+		if op.Op == "move" {
+			root, value, err = jsonPointerRemove(root, op.From)
+		} else {
+			value, err = jsonPointerGet(root, op.From)
+			value = deepCopy(value)
+		}
+		if err == nil {
+			root, err = jsonPointerSet(root, op.Path, value, true)
+		}
+	case "test":
+		var value interface{}
+		if value, err = jsonPointerGet(root, op.Path); err == nil && !reflect.DeepEqual(value, op.Value) {
+			err = fmt.Errorf("test of %s failed", op.Path)
+		}
+	default:
+		err = fmt.Errorf("unsupported JSON patch operation %q", op.Op)
+	}
+	if err != nil {
+		return err
+	}
+	obj, ok := root.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("JSON patch must not replace the resource with a non-object")
+	}
+	r.obj = obj
+	return nil
+}
+
+// splitJSONPointer splits a JSON pointer (RFC 6901) into its unescaped
+// reference tokens.
+func splitJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func jsonPointerGet(root interface{}, pointer string) (interface{}, error) {
+	tokens, err := splitJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := root
+	for _, token := range tokens {
+		switch c := current.(type) {
+		case map[string]interface{}:
+			v, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("path %s does not exist", pointer)
+			}
+			current = v
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, fmt.Errorf("path %s does not exist", pointer)
+			}
+			current = c[i]
+		default:
+			return nil, fmt.Errorf("path %s does not exist", pointer)
+		}
+	}
+	return current, nil
+}
+
+// jsonPointerSet sets the value at pointer and returns the new root. If add
+// is set, values are inserted into lists and missing map keys are created,
+// otherwise the value must exist and is replaced.
+func jsonPointerSet(root interface{}, pointer string, value interface{}, add bool) (interface{}, error) {
+	tokens, err := splitJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parentPointer := joinJSONPointer(tokens[:len(tokens)-1])
+	parent, err := jsonPointerGet(root, parentPointer)
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		if _, ok := p[last]; !ok && !add {
+			return nil, fmt.Errorf("path %s does not exist", pointer)
+		}
+		p[last] = value
+		return root, nil
+	case []interface{}:
+		i := len(p)
+		if last != "-" {
+			if i, err = strconv.Atoi(last); err != nil || i < 0 || i > len(p) || (!add && i == len(p)) {
+				return nil, fmt.Errorf("invalid list index in %s", pointer)
+			}
+		} else if !add {
+			return nil, fmt.Errorf("invalid list index in %s", pointer)
+		}
+		var list []interface{}
+		if add {
+			list = append(append(append([]interface{}{}, p[:i]...), value), p[i:]...)
+		} else {
+			list = append([]interface{}{}, p...)
+			list[i] = value
+		}
+		return jsonPointerSet(root, parentPointer, list, false)
+	default:
+		return nil, fmt.Errorf("path %s does not exist", pointer)
+	}
+}
+
+// jsonPointerRemove removes the value at pointer and returns the new root and
+// the removed value.
+func jsonPointerRemove(root interface{}, pointer string) (interface{}, interface{}, error) {
+	value, err := jsonPointerGet(root, pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	tokens, _ := splitJSONPointer(pointer)
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the whole resource")
+	}
+	parentPointer := joinJSONPointer(tokens[:len(tokens)-1])
+	parent, err := jsonPointerGet(root, parentPointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	last := tokens[len(tokens)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		delete(p, last)
+		return root, value, nil
+	case []interface{}:
+		i, _ := strconv.Atoi(last)
+		list := append(append([]interface{}{}, p[:i]...), p[i+1:]...)
+		root, err = jsonPointerSet(root, parentPointer, list, false)
+		return root, value, err
+	default:
+		return nil, nil, fmt.Errorf("path %s does not exist", pointer)
+	}
+}
+
+// joinJSONPointer is the inverse of splitJSONPointer.
+func joinJSONPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// deepCopy copies maps and lists decoded from YAML, so that patches applied
+// to several resources do not share their values.
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			m[k] = deepCopy(value)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = deepCopy(value)
+		}
+		return l
+	default:
+		return v
+	}
+}
@@ -0,0 +1,150 @@
+package kustomize
+
+// addRename records that the resource of the given kind was renamed.
+func addRename(renames map[string]map[string]string, kind, oldName, newName string) {
+	if oldName == newName {
+		return
+	}
+	if renames[kind] == nil {
+		renames[kind] = make(map[string]string)
+	}
+	renames[kind][oldName] = newName
+}
+
+// updateReferences updates the references to renamed ConfigMaps, Secrets and
+// PersistentVolumeClaims in the pod specs of all resources, and to renamed
+// Services and Secrets in the backends and TLS settings of Ingresses, like the
+// nameReference transformer of kustomize. renames maps the kind of a renamed
+// resource to its old and new names.
+func updateReferences(resources []*resource, renames map[string]map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+	rename := func(obj map[string]interface{}, key, kind string) {
+		if obj == nil {
+			return
+		}
+		if name, ok := obj[key].(string); ok {
+			if newName, ok := renames[kind][name]; ok {
+				obj[key] = newName
+			}
+		}
+	}
+
+	for _, r := range resources {
+		if r.kind() == "Ingress" {
+			spec := nestedMap(r.obj, false, "spec")
+			// networking.k8s.io/v1 and the older extensions/v1beta1
+			rename(nestedMap(spec, false, "defaultBackend", "service"), "name", "Service")
+			rename(nestedMap(spec, false, "backend"), "serviceName", "Service")
+			for _, rule := range mapList(spec["rules"]) {
+				for _, path := range mapList(nestedMap(rule, false, "http")["paths"]) {
+					rename(nestedMap(path, false, "backend", "service"), "name", "Service")
+					rename(nestedMap(path, false, "backend"), "serviceName", "Service")
+				}
+			}
+			for _, tls := range mapList(spec["tls"]) {
+				rename(tls, "secretName", "Secret")
+			}
+			continue
+		}
+		for _, spec := range podSpecs(r) {
+			for _, volume := range mapList(spec["volumes"]) {
+				rename(nestedMap(volume, false, "configMap"), "name", "ConfigMap")
+				rename(nestedMap(volume, false, "secret"), "secretName", "Secret")
+				rename(nestedMap(volume, false, "persistentVolumeClaim"), "claimName", "PersistentVolumeClaim")
+				for _, source := range mapList(nestedMap(volume, false, "projected")["sources"]) {
+					rename(nestedMap(source, false, "configMap"), "name", "ConfigMap")
+					rename(nestedMap(source, false, "secret"), "name", "Secret")
+				}
+			}
+			for _, secret := range mapList(spec["imagePullSecrets"]) {
+				rename(secret, "name", "Secret")
+			}
+			for _, ctr := range append(mapList(spec["initContainers"]), mapList(spec["containers"])...) {
+				for _, envFrom := range mapList(ctr["envFrom"]) {
+					rename(nestedMap(envFrom, false, "configMapRef"), "name", "ConfigMap")
+					rename(nestedMap(envFrom, false, "secretRef"), "name", "Secret")
+				}
+				for _, env := range mapList(ctr["env"]) {
+					rename(nestedMap(env, false, "valueFrom", "configMapKeyRef"), "name", "ConfigMap")
+					rename(nestedMap(env, false, "valueFrom", "secretKeyRef"), "name", "Secret")
+				}
+			}
+		}
+	}
+}
+
+// addCommonLabels adds labels to the resource and, like kustomize, to the
+// selectors and pod templates of workloads, Services and NetworkPolicies, so
+// that they still select the labeled pods.
+func addCommonLabels(r *resource, labels map[string]string) {
+	add := func(obj map[string]interface{}) {
+		for k, v := range labels {
+			obj[k] = v
+		}
+	}
+
+	add(nestedMap(r.obj, true, "metadata", "labels"))
+	switch r.kind() {
+	case "Deployment", "ReplicaSet", "StatefulSet", "DaemonSet":
+		add(nestedMap(r.obj, true, "spec", "selector", "matchLabels"))
+		add(nestedMap(r.obj, true, "spec", "template", "metadata", "labels"))
+	case "Job":
+		add(nestedMap(r.obj, true, "spec", "template", "metadata", "labels"))
+	case "CronJob":
+		add(nestedMap(r.obj, true, "spec", "jobTemplate", "spec", "template", "metadata", "labels"))
+	case "Service":
+		add(nestedMap(r.obj, true, "spec", "selector"))
+	case "NetworkPolicy":
+		// kustomize only extends existing pod selectors, an empty
+		// selector selects all pods
+		if selector := nestedMap(r.obj, false, "spec", "podSelector", "matchLabels"); selector != nil {
+			add(selector)
+		}
+		for _, rule := range mapList(nestedMap(r.obj, false, "spec")["ingress"]) {
+			for _, peer := range mapList(rule["from"]) {
+				if selector := nestedMap(peer, false, "podSelector", "matchLabels"); selector != nil {
+					add(selector)
+				}
+			}
+		}
+		for _, rule := range mapList(nestedMap(r.obj, false, "spec")["egress"]) {
+			for _, peer := range mapList(rule["to"]) {
+				if selector := nestedMap(peer, false, "podSelector", "matchLabels"); selector != nil {
+					add(selector)
+				}
+			}
+		}
+	}
+}
+
+// podSpecs returns the pod specs of a Pod or of the pod template of a
+// workload.
+func podSpecs(r *resource) []map[string]interface{} {
+	var spec map[string]interface{}
+	switch r.kind() {
+	case "Pod":
+		spec = nestedMap(r.obj, false, "spec")
+	case "Deployment", "ReplicaSet", "StatefulSet", "DaemonSet", "Job":
+		spec = nestedMap(r.obj, false, "spec", "template", "spec")
+	case "CronJob":
+		spec = nestedMap(r.obj, false, "spec", "jobTemplate", "spec", "template", "spec")
+	}
+	if spec == nil {
+		return nil
+	}
+	return []map[string]interface{}{spec}
+}
+
+// mapList returns the maps in a list, other list items are skipped.
+func mapList(v interface{}) []map[string]interface{} {
+	list, _ := v.([]interface{})
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
@@ -33,3 +33,11 @@ type Config struct {
 	ContainerID string
 	RootlessCNI bool
 }
+
+// Reload can be sent to the reload socket of a running rootlessport process
+// to replace the forwarded ports with Mappings.  Sending just the child IP as
+// JSON string keeps the ports and only changes the child IP.
+type Reload struct {
+	ChildIP  string
+	Mappings []types.PortMapping
+}
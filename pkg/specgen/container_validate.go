@@ -18,7 +18,7 @@ var (
 	// SystemDValues describes the only values that SystemD can be
 	SystemDValues = []string{"true", "false", "always"}
 	// SdNotifyModeValues describes the only values that SdNotifyMode can be
-	SdNotifyModeValues = []string{define.SdNotifyModeContainer, define.SdNotifyModeConmon, define.SdNotifyModeIgnore}
+	SdNotifyModeValues = []string{define.SdNotifyModeContainer, define.SdNotifyModeConmon, define.SdNotifyModeIgnore, define.SdNotifyModeReady}
 	// ImageVolumeModeValues describes the only values that ImageVolumeMode can be
 	ImageVolumeModeValues = []string{"ignore", "tmpfs", "anonymous"}
 )
@@ -84,6 +84,18 @@ func (s *SpecGenerator) Validate() error {
 		return fmt.Errorf("invalid ImageVolumeMode %q, value must be one of %s",
 			s.ContainerStorageConfig.ImageVolumeMode, strings.Join(ImageVolumeModeValues, ","))
 	}
+	for _, m := range s.Mounts {
+		if m.Type == define.TypeGlob {
+			if err := validateGlobMount(m); err != nil {
+				return err
+			}
+		}
+	}
+	for _, v := range s.OverlayVolumes {
+		if err := validateOverlayVolume(v); err != nil {
+			return err
+		}
+	}
 	// shmsize conflicts with IPC namespace
 	if s.ContainerStorageConfig.ShmSize != nil && (s.ContainerStorageConfig.IpcNS.IsHost() || s.ContainerStorageConfig.IpcNS.IsNone()) {
 		return fmt.Errorf("cannot set shmsize when running in the %s IPC Namespace", s.ContainerStorageConfig.IpcNS)
@@ -200,6 +212,9 @@ func (s *SpecGenerator) Validate() error {
 		// Note that we also get the ip and mac in the networks map
 		return errors.New("networks and static ip/mac address can only be used with Bridge mode networking")
 	}
+	if !s.NetworkShaping.IsZero() && s.NetNS.NSMode != Bridge && s.NetNS.NSMode != Slirp && s.NetNS.NSMode != Pasta {
+		return errors.New("network shaping can only be used with bridge, slirp4netns or pasta networking")
+	}
 
 	return nil
 }
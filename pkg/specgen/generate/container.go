@@ -447,6 +447,7 @@ func ConfigToSpec(rt *libpod.Runtime, specg *specgen.SpecGenerator, containerID
 				Name:    v.Name,
 				Dest:    v.Dest,
 				Options: v.Options,
+				SubPath: v.SubPath,
 			})
 		}
 	}
@@ -159,6 +159,13 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 		return nil, nil, nil, fmt.Errorf("invalid config provided: %w", err)
 	}
 
+	// Glob mounts match host paths, so they are expanded here and not by
+	// the client.
+	s.Mounts, err = expandGlobMounts(s.Mounts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	finalMounts, finalVolumes, finalOverlays, err := finalizeMounts(ctx, s, rt, rtc, newImage)
 	if err != nil {
 		return nil, nil, nil, err
@@ -461,6 +468,12 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.StopTimeout != nil {
 		options = append(options, libpod.WithStopTimeout(*s.StopTimeout))
 	}
+	if len(s.PostStartHook) > 0 {
+		options = append(options, libpod.WithPostStartHook(s.PostStartHook))
+	}
+	if len(s.PreStopHook) > 0 {
+		options = append(options, libpod.WithPreStopHook(s.PreStopHook))
+	}
 	if s.Timeout != 0 {
 		options = append(options, libpod.WithTimeout(s.Timeout))
 	}
@@ -531,6 +544,9 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.ContainerHealthCheckConfig.StartupHealthConfig != nil {
 		options = append(options, libpod.WithStartupHealthcheck(s.ContainerHealthCheckConfig.StartupHealthConfig))
 	}
+	if s.ContainerHealthCheckConfig.ReadinessCheckConfig != nil {
+		options = append(options, libpod.WithReadinessCheck(s.ContainerHealthCheckConfig.ReadinessCheckConfig))
+	}
 
 	if s.ContainerHealthCheckConfig.HealthCheckOnFailureAction != define.HealthCheckOnFailureActionNone {
 		options = append(options, libpod.WithHealthCheckOnFailureAction(s.ContainerHealthCheckConfig.HealthCheckOnFailureAction))
@@ -0,0 +1,428 @@
+package kube
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	v1net "github.com/containers/podman/v4/pkg/k8s.io/api/networking/v1"
+	v12 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultIngressImage is the image of the proxy of an Ingress. It must
+	// provide nginx and can be changed with the
+	// io.podman.annotations.ingress.image annotation.
+	DefaultIngressImage = "docker.io/library/nginx:stable-alpine"
+
+	// ingressConfigDir is the directory of the proxy configuration in the
+	// proxy container.
+	ingressConfigDir = "/etc/podman-ingress"
+	// ingressConfigFile is the name of the proxy configuration file.
+	ingressConfigFile = "nginx.conf"
+	// ingressTLSDir is the directory in the proxy container below which
+	// the TLS Secrets are mounted.
+	ingressTLSDir = ingressConfigDir + "/tls"
+)
+
+// dnsLabelRegex matches an RFC 1123 label. The names of Services and the
+// labels of host names and Secret names must match it before they are written
+// to the proxy configuration.
+var dnsLabelRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// isDNSLabel returns whether name is an RFC 1123 label.
+func isDNSLabel(name string) bool {
+	return len(name) <= 63 && dnsLabelRegex.MatchString(name)
+}
+
+// isDNSSubdomain returns whether name is an RFC 1123 subdomain.
+func isDNSSubdomain(name string) bool {
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if !isDNSLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isIngressHost returns whether host is an RFC 1123 host name, which may
+// start with a "*." wildcard label.
+func isIngressHost(host string) bool {
+	return isDNSSubdomain(strings.TrimPrefix(host, "*."))
+}
+
+// ingressServer is a virtual server of the proxy of an Ingress, serving a
+// host or, if host is empty, any host.
+type ingressServer struct {
+	host      string
+	tlsSecret string
+	locations []ingressLocation
+}
+
+// ingressLocation routes the requests matching an nginx location to a
+// Service.
+type ingressLocation struct {
+	match    string
+	upstream string
+	// exact is set for the locations of Exact paths
+	exact bool
+}
+
+// IngressPodName returns the name of the pod running the proxy of an
+// Ingress.
+func IngressPodName(ingress *v1net.Ingress) string {
+	return ingress.Name + "-ingress"
+}
+
+// IngressConfigName returns the name of the ConfigMap, and hence of the
+// volume, holding the configuration of the proxy of an Ingress.
+func IngressConfigName(ingress *v1net.Ingress) string {
+	return ingress.Name + "-ingress-config"
+}
+
+// ToIngressPod returns the pod running the reverse proxy of an Ingress and
+// the ConfigMap with the proxy configuration. The proxy routes HTTP requests
+// by host and path to the Services of the backends, which must be among
+// services, and terminates TLS with the certificates of the Secrets named by
+// the Ingress.
+func ToIngressPod(ingress *v1net.Ingress, services []v1.Service) (*v1.Pod, *v1.ConfigMap, error) {
+	if ingress.Name == "" {
+		return nil, nil, fmt.Errorf("Ingress name must be set")
+	}
+	image := DefaultIngressImage
+	if v, ok := ingress.Annotations[define.IngressImageAnnotation]; ok && v != "" {
+		image = v
+	}
+	httpPort, err := ingressHostPort(ingress, define.IngressHTTPPortAnnotation, 80)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpsPort, err := ingressHostPort(ingress, define.IngressHTTPSPortAnnotation, 443)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	servers, err := ingressServers(ingress, services)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Ingress %s: %w", ingress.Name, err)
+	}
+
+	configName := IngressConfigName(ingress)
+	container := v1.Container{
+		Name:            "proxy",
+		Image:           image,
+		ImagePullPolicy: v1.PullIfNotPresent,
+		Command:         []string{"nginx", "-c", ingressConfigDir + "/" + ingressConfigFile, "-g", "daemon off;"},
+		Ports:           []v1.ContainerPort{{Name: "http", ContainerPort: 80, HostPort: httpPort}},
+		VolumeMounts: []v1.VolumeMount{{
+			Name:      "config",
+			MountPath: ingressConfigDir,
+			ReadOnly:  true,
+		}},
+	}
+	volumes := []v1.Volume{{
+		Name: "config",
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: configName},
+			},
+		},
+	}}
+	if len(ingress.Spec.TLS) > 0 {
+		container.Ports = append(container.Ports, v1.ContainerPort{Name: "https", ContainerPort: 443, HostPort: httpsPort})
+	}
+	for _, secret := range ingressTLSSecrets(ingress) {
+		name := fmt.Sprintf("tls-%d", len(volumes))
+		volumes = append(volumes, v1.Volume{
+			Name:         name,
+			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: secret}},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: ingressTLSDir + "/" + secret,
+			ReadOnly:  true,
+		})
+	}
+
+	podName := IngressPodName(ingress)
+	pod := &v1.Pod{
+		ObjectMeta: v12.ObjectMeta{
+			Name:   podName,
+			Labels: map[string]string{"app": podName},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{container},
+			Volumes:    volumes,
+		},
+	}
+	configMap := &v1.ConfigMap{
+		ObjectMeta: v12.ObjectMeta{Name: configName},
+		Data: map[string]string{
+			ingressConfigFile: ingressNginxConfig(ingress.Name, servers),
+		},
+	}
+	return pod, configMap, nil
+}
+
+// ingressHostPort returns the host port set by an annotation of an Ingress,
+// or def.
+func ingressHostPort(ingress *v1net.Ingress, annotation string, def int32) (int32, error) {
+	v, ok := ingress.Annotations[annotation]
+	if !ok {
+		return def, nil
+	}
+	port, err := strconv.ParseUint(v, 10, 16)
+	if err != nil || port == 0 {
+		return 0, fmt.Errorf("Ingress %s: invalid annotation %s=%q, it must be a port number", ingress.Name, annotation, v)
+	}
+	return int32(port), nil
+}
+
+// ingressTLSSecrets returns the names of the TLS Secrets of an Ingress,
+// without duplicates.
+func ingressTLSSecrets(ingress *v1net.Ingress) []string {
+	var secrets []string
+	seen := make(map[string]bool)
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName != "" && !seen[tls.SecretName] {
+			seen[tls.SecretName] = true
+			secrets = append(secrets, tls.SecretName)
+		}
+	}
+	return secrets
+}
+
+// ingressServers converts the rules of an Ingress to virtual servers. The
+// first server is the default server for requests of any host, it serves
+// the rules without a host. The default backend serves every request which
+// matches no path of its server.
+func ingressServers(ingress *v1net.Ingress, services []v1.Service) ([]*ingressServer, error) {
+	var defaultSecret string
+	hostSecrets := make(map[string]string)
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" {
+			return nil, fmt.Errorf("TLS without secretName is not supported")
+		}
+		if !isDNSSubdomain(tls.SecretName) {
+			return nil, fmt.Errorf("invalid TLS secretName %q", tls.SecretName)
+		}
+		if len(tls.Hosts) == 0 || defaultSecret == "" {
+			defaultSecret = tls.SecretName
+		}
+		for _, host := range tls.Hosts {
+			if !isIngressHost(host) {
+				return nil, fmt.Errorf("invalid TLS host %q", host)
+			}
+			if _, ok := hostSecrets[host]; !ok {
+				hostSecrets[host] = tls.SecretName
+			}
+		}
+	}
+
+	servers := []*ingressServer{{tlsSecret: defaultSecret}}
+	serverIndex := map[string]*ingressServer{"": servers[0]}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" && !isIngressHost(rule.Host) {
+			return nil, fmt.Errorf("invalid host %q", rule.Host)
+		}
+		server, ok := serverIndex[rule.Host]
+		if !ok {
+			server = &ingressServer{host: rule.Host, tlsSecret: hostSecrets[rule.Host]}
+			serverIndex[rule.Host] = server
+			servers = append(servers, server)
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			path := &rule.HTTP.Paths[i]
+			upstream, err := ingressUpstream(&path.Backend, services)
+			if err != nil {
+				return nil, err
+			}
+			matches, err := ingressPathMatches(path)
+			if err != nil {
+				return nil, err
+			}
+			exact := path.PathType != nil && *path.PathType == v1net.PathTypeExact
+			for _, match := range matches {
+				if l := server.location(match); l != nil {
+					// an Exact path takes precedence over the
+					// exact match of a Prefix path, whichever
+					// comes first
+					if exact != l.exact {
+						if exact {
+							l.upstream, l.exact = upstream, true
+						}
+						continue
+					}
+					logrus.Warnf("Ingress %s: path %s of host %q is already routed, ignoring it", ingress.Name, path.Path, rule.Host)
+					continue
+				}
+				server.locations = append(server.locations, ingressLocation{match: match, upstream: upstream, exact: exact})
+			}
+		}
+	}
+
+	if ingress.Spec.DefaultBackend != nil {
+		upstream, err := ingressUpstream(ingress.Spec.DefaultBackend, services)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range servers {
+			if !server.hasLocation("/") {
+				server.locations = append(server.locations, ingressLocation{match: "/", upstream: upstream})
+			}
+		}
+	}
+	return servers, nil
+}
+
+func (s *ingressServer) hasLocation(match string) bool {
+	return s.location(match) != nil
+}
+
+// location returns the location of the server with the given match, or nil.
+func (s *ingressServer) location(match string) *ingressLocation {
+	for i := range s.locations {
+		if s.locations[i].match == match {
+			return &s.locations[i]
+		}
+	}
+	return nil
+}
+
+// ingressUpstream returns the URL of the Service of a backend. The Service
+// is reachable under its name on the network of the pods.
+func ingressUpstream(backend *v1net.IngressBackend, services []v1.Service) (string, error) {
+	if backend.Service == nil {
+		return "", fmt.Errorf("only Service backends are supported")
+	}
+	name := backend.Service.Name
+	if !isDNSLabel(name) {
+		return "", fmt.Errorf("invalid backend Service name %q", name)
+	}
+	for _, service := range services {
+		if service.Name != name {
+			continue
+		}
+		port := backend.Service.Port.Number
+		if backend.Service.Port.Name != "" {
+			port = 0
+			for _, p := range service.Spec.Ports {
+				if p.Name == backend.Service.Port.Name {
+					port = p.Port
+					break
+				}
+			}
+			if port == 0 {
+				return "", fmt.Errorf("Service %s has no port %q", name, backend.Service.Port.Name)
+			}
+		}
+		if port < 1 || port > 65535 {
+			return "", fmt.Errorf("invalid port %d of backend Service %s", port, name)
+		}
+		return fmt.Sprintf("http://%s:%d", name, port), nil
+	}
+	return "", fmt.Errorf("no Service %q, backend Services must be in the same YAML", name)
+}
+
+// ingressPathMatches returns the nginx locations matching a path. A Prefix
+// path matches whole path elements, so /foo matches /foo and /foo/bar but
+// not /foobar.
+func ingressPathMatches(path *v1net.HTTPIngressPath) ([]string, error) {
+	pathType := v1net.PathTypeImplementationSpecific
+	if path.PathType != nil {
+		pathType = *path.PathType
+	}
+	p := path.Path
+	if p == "" && pathType == v1net.PathTypeImplementationSpecific {
+		p = "/"
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("path %q must begin with /", path.Path)
+	}
+	if strings.ContainsAny(p, " \t\n;{}") {
+		return nil, fmt.Errorf("invalid path %q", path.Path)
+	}
+
+	switch pathType {
+	case v1net.PathTypeExact:
+		return []string{"= " + p}, nil
+	case v1net.PathTypePrefix:
+		p = strings.TrimRight(p, "/")
+		if p == "" {
+			return []string{"/"}, nil
+		}
+		return []string{"= " + p, p + "/"}, nil
+	case v1net.PathTypeImplementationSpecific:
+		return []string{p}, nil
+	default:
+		return nil, fmt.Errorf("unknown pathType %q", pathType)
+	}
+}
+
+// nginxServerName returns the nginx server name of an Ingress host. A wildcard
+// host only matches a single DNS label, unlike a wildcard name of nginx which
+// also matches a.b.example.com for *.example.com, so it becomes a regular
+// expression.
+func nginxServerName(host string) string {
+	if !strings.HasPrefix(host, "*.") {
+		return host
+	}
+	return `~^[^.]+` + strings.ReplaceAll(strings.TrimPrefix(host, "*"), ".", `\.`) + "$"
+}
+
+// ingressNginxConfig returns the nginx configuration serving the virtual
+// servers of an Ingress.
+func ingressNginxConfig(name string, servers []*ingressServer) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by podman kube play for Ingress %s.\n", name)
+	b.WriteString(`worker_processes auto;
+pid /tmp/nginx.pid;
+error_log stderr;
+
+events {
+    worker_connections 1024;
+}
+
+http {
+    access_log /dev/stdout;
+    proxy_http_version 1.1;
+    proxy_set_header Host $host;
+    proxy_set_header X-Real-IP $remote_addr;
+    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+    proxy_set_header X-Forwarded-Host $host;
+    proxy_set_header X-Forwarded-Proto $scheme;
+`)
+	for i, server := range servers {
+		defaultServer, serverName := "", nginxServerName(server.host)
+		if i == 0 {
+			defaultServer, serverName = " default_server", "_"
+		}
+		b.WriteString("\n    server {\n")
+		fmt.Fprintf(&b, "        listen 80%s;\n", defaultServer)
+		if server.tlsSecret != "" {
+			fmt.Fprintf(&b, "        listen 443 ssl%s;\n", defaultServer)
+			fmt.Fprintf(&b, "        ssl_certificate %s/%s/%s;\n", ingressTLSDir, server.tlsSecret, "tls.crt")
+			fmt.Fprintf(&b, "        ssl_certificate_key %s/%s/%s;\n", ingressTLSDir, server.tlsSecret, "tls.key")
+		}
+		fmt.Fprintf(&b, "        server_name %s;\n", serverName)
+		for _, l := range server.locations {
+			fmt.Fprintf(&b, "\n        location %s {\n            proxy_pass %s;\n        }\n", l.match, l.upstream)
+		}
+		if !server.hasLocation("/") {
+			b.WriteString("\n        location / {\n            return 404;\n        }\n")
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
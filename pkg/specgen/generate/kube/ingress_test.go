@@ -0,0 +1,336 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	v1net "github.com/containers/podman/v4/pkg/k8s.io/api/networking/v1"
+	metav1 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToIngressPod(t *testing.T) {
+	prefix := v1net.PathTypePrefix
+	exact := v1net.PathTypeExact
+	services := []v1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "http", Port: 8080}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}},
+		},
+	}
+	backend := func(name string, port v1net.ServiceBackendPort) v1net.IngressBackend {
+		return v1net.IngressBackend{Service: &v1net.IngressServiceBackend{Name: name, Port: port}}
+	}
+
+	ingress := &v1net.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "shop",
+			Annotations: map[string]string{"io.podman.annotations.ingress.http-port": "8000"},
+		},
+		Spec: v1net.IngressSpec{
+			DefaultBackend: &v1net.IngressBackend{Service: &v1net.IngressServiceBackend{Name: "web", Port: v1net.ServiceBackendPort{Name: "http"}}},
+			TLS:            []v1net.IngressTLS{{Hosts: []string{"shop.example.com"}, SecretName: "shop-tls"}},
+			Rules: []v1net.IngressRule{
+				{
+					Host: "shop.example.com",
+					IngressRuleValue: v1net.IngressRuleValue{HTTP: &v1net.HTTPIngressRuleValue{Paths: []v1net.HTTPIngressPath{
+						{Path: "/api/", PathType: &prefix, Backend: backend("api", v1net.ServiceBackendPort{Number: 80})},
+						{Path: "/health", PathType: &exact, Backend: backend("api", v1net.ServiceBackendPort{Number: 80})},
+					}}},
+				},
+				{
+					IngressRuleValue: v1net.IngressRuleValue{HTTP: &v1net.HTTPIngressRuleValue{Paths: []v1net.HTTPIngressPath{
+						{Path: "/status", Backend: backend("api", v1net.ServiceBackendPort{Number: 80})},
+					}}},
+				},
+			},
+		},
+	}
+
+	pod, configMap, err := ToIngressPod(ingress, services)
+	require.NoError(t, err)
+
+	assert.Equal(t, "shop-ingress", pod.Name)
+	require.Len(t, pod.Spec.Containers, 1)
+	ctr := pod.Spec.Containers[0]
+	assert.Equal(t, DefaultIngressImage, ctr.Image)
+	assert.Equal(t, []v1.ContainerPort{
+		{Name: "http", ContainerPort: 80, HostPort: 8000},
+		{Name: "https", ContainerPort: 443, HostPort: 443},
+	}, ctr.Ports)
+	assert.Equal(t, []v1.VolumeMount{
+		{Name: "config", MountPath: "/etc/podman-ingress", ReadOnly: true},
+		{Name: "tls-1", MountPath: "/etc/podman-ingress/tls/shop-tls", ReadOnly: true},
+	}, ctr.VolumeMounts)
+	require.Len(t, pod.Spec.Volumes, 2)
+	assert.Equal(t, "shop-ingress-config", pod.Spec.Volumes[0].ConfigMap.Name)
+	assert.Equal(t, "shop-tls", pod.Spec.Volumes[1].Secret.SecretName)
+
+	assert.Equal(t, "shop-ingress-config", configMap.Name)
+	assert.Equal(t, `# Generated by podman kube play for Ingress shop.
+worker_processes auto;
+pid /tmp/nginx.pid;
+error_log stderr;
+
+events {
+    worker_connections 1024;
+}
+
+http {
+    access_log /dev/stdout;
+    proxy_http_version 1.1;
+    proxy_set_header Host $host;
+    proxy_set_header X-Real-IP $remote_addr;
+    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+    proxy_set_header X-Forwarded-Host $host;
+    proxy_set_header X-Forwarded-Proto $scheme;
+
+    server {
+        listen 80 default_server;
+        listen 443 ssl default_server;
+        ssl_certificate /etc/podman-ingress/tls/shop-tls/tls.crt;
+        ssl_certificate_key /etc/podman-ingress/tls/shop-tls/tls.key;
+        server_name _;
+
+        location /status {
+            proxy_pass http://api:80;
+        }
+
+        location / {
+            proxy_pass http://web:8080;
+        }
+    }
+
+    server {
+        listen 80;
+        listen 443 ssl;
+        ssl_certificate /etc/podman-ingress/tls/shop-tls/tls.crt;
+        ssl_certificate_key /etc/podman-ingress/tls/shop-tls/tls.key;
+        server_name shop.example.com;
+
+        location = /api {
+            proxy_pass http://api:80;
+        }
+
+        location /api/ {
+            proxy_pass http://api:80;
+        }
+
+        location = /health {
+            proxy_pass http://api:80;
+        }
+
+        location / {
+            proxy_pass http://web:8080;
+        }
+    }
+}
+`, configMap.Data["nginx.conf"])
+}
+
+func TestToIngressPodWithoutDefaultBackend(t *testing.T) {
+	prefix := v1net.PathTypePrefix
+	ingress := &v1net.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Annotations: map[string]string{"io.podman.annotations.ingress.image": "quay.io/libpod/alpine_nginx"},
+		},
+		Spec: v1net.IngressSpec{
+			Rules: []v1net.IngressRule{{
+				Host: "web.example.com",
+				IngressRuleValue: v1net.IngressRuleValue{HTTP: &v1net.HTTPIngressRuleValue{Paths: []v1net.HTTPIngressPath{
+					{Path: "/", PathType: &prefix, Backend: v1net.IngressBackend{Service: &v1net.IngressServiceBackend{Name: "web", Port: v1net.ServiceBackendPort{Number: 80}}}},
+				}}},
+			}},
+		},
+	}
+	services := []v1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "web"}}}
+
+	pod, configMap, err := ToIngressPod(ingress, services)
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io/libpod/alpine_nginx", pod.Spec.Containers[0].Image)
+	assert.Equal(t, []v1.ContainerPort{{Name: "http", ContainerPort: 80, HostPort: 80}}, pod.Spec.Containers[0].Ports)
+	assert.Len(t, pod.Spec.Volumes, 1)
+
+	config := configMap.Data["nginx.conf"]
+	assert.NotContains(t, config, "ssl")
+	// requests for other hosts are not routed
+	assert.Contains(t, config, `        server_name _;
+
+        location / {
+            return 404;
+        }
+`)
+	assert.Contains(t, config, `        server_name web.example.com;
+
+        location / {
+            proxy_pass http://web:80;
+        }
+`)
+}
+
+func TestToIngressPodPathPrecedenceAndWildcardHost(t *testing.T) {
+	prefix := v1net.PathTypePrefix
+	exact := v1net.PathTypeExact
+	backend := func(name string) v1net.IngressBackend {
+		return v1net.IngressBackend{Service: &v1net.IngressServiceBackend{Name: name, Port: v1net.ServiceBackendPort{Number: 80}}}
+	}
+	services := []v1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "web"}}, {ObjectMeta: metav1.ObjectMeta{Name: "api"}}}
+
+	for _, paths := range [][]v1net.HTTPIngressPath{
+		{
+			{Path: "/foo", PathType: &prefix, Backend: backend("web")},
+			{Path: "/foo", PathType: &exact, Backend: backend("api")},
+		},
+		{
+			{Path: "/foo", PathType: &exact, Backend: backend("api")},
+			{Path: "/foo", PathType: &prefix, Backend: backend("web")},
+		},
+	} {
+		ingress := &v1net.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec: v1net.IngressSpec{
+				Rules: []v1net.IngressRule{{
+					Host:             "*.example.com",
+					IngressRuleValue: v1net.IngressRuleValue{HTTP: &v1net.HTTPIngressRuleValue{Paths: paths}},
+				}},
+			},
+		}
+		_, configMap, err := ToIngressPod(ingress, services)
+		require.NoError(t, err)
+		// the wildcard only matches a single label, and the Exact
+		// path takes precedence over the Prefix path
+		assert.Contains(t, configMap.Data["nginx.conf"], `        server_name ~^[^.]+\.example\.com$;
+
+        location = /foo {
+            proxy_pass http://api:80;
+        }
+
+        location /foo/ {
+            proxy_pass http://web:80;
+        }
+`)
+	}
+}
+
+func TestToIngressPodErrors(t *testing.T) {
+	services := []v1.Service{{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "http", Port: 80}}},
+	}}
+	exact := v1net.PathTypeExact
+	withPath := func(path string, pathType *v1net.PathType) v1net.IngressSpec {
+		return v1net.IngressSpec{Rules: []v1net.IngressRule{{
+			IngressRuleValue: v1net.IngressRuleValue{HTTP: &v1net.HTTPIngressRuleValue{Paths: []v1net.HTTPIngressPath{{
+				Path:     path,
+				PathType: pathType,
+				Backend:  v1net.IngressBackend{Service: &v1net.IngressServiceBackend{Name: "web", Port: v1net.ServiceBackendPort{Number: 80}}},
+			}}}},
+		}}}
+	}
+	withBackend := func(backend v1net.IngressBackend) v1net.IngressSpec {
+		return v1net.IngressSpec{DefaultBackend: &backend}
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		spec        v1net.IngressSpec
+		err         string
+	}{
+		{
+			name: "MissingService",
+			spec: withBackend(v1net.IngressBackend{Service: &v1net.IngressServiceBackend{Name: "api", Port: v1net.ServiceBackendPort{Number: 80}}}),
+			err:  `Ingress test: no Service "api", backend Services must be in the same YAML`,
+		},
+		{
+			name: "MissingPortName",
+			spec: withBackend(v1net.IngressBackend{Service: &v1net.IngressServiceBackend{Name: "web", Port: v1net.ServiceBackendPort{Name: "https"}}}),
+			err:  `Ingress test: Service web has no port "https"`,
+		},
+		{
+			name: "ResourceBackend",
+			spec: withBackend(v1net.IngressBackend{Resource: &v1.TypedLocalObjectReference{Kind: "StorageBucket", Name: "static"}}),
+			err:  "Ingress test: only Service backends are supported",
+		},
+		{
+			name: "RelativePath",
+			spec: withPath("api", &exact),
+			err:  `Ingress test: path "api" must begin with /`,
+		},
+		{
+			name: "InvalidPath",
+			spec: withPath("/api;", &exact),
+			err:  `Ingress test: invalid path "/api;"`,
+		},
+		{
+			name: "TLSWithoutSecret",
+			spec: v1net.IngressSpec{TLS: []v1net.IngressTLS{{Hosts: []string{"web.example.com"}}}},
+			err:  "Ingress test: TLS without secretName is not supported",
+		},
+		{
+			name: "InvalidHost",
+			spec: v1net.IngressSpec{Rules: []v1net.IngressRule{{Host: "web.example.com; location / { return 200; }"}}},
+			err:  `Ingress test: invalid host "web.example.com; location / { return 200; }"`,
+		},
+		{
+			name: "UppercaseHost",
+			spec: v1net.IngressSpec{Rules: []v1net.IngressRule{{Host: "Web.example.com"}}},
+			err:  `Ingress test: invalid host "Web.example.com"`,
+		},
+		{
+			name: "InnerWildcardHost",
+			spec: v1net.IngressSpec{Rules: []v1net.IngressRule{{Host: "web.*.example.com"}}},
+			err:  `Ingress test: invalid host "web.*.example.com"`,
+		},
+		{
+			name: "InvalidServiceName",
+			spec: withBackend(v1net.IngressBackend{Service: &v1net.IngressServiceBackend{Name: "web:80/;", Port: v1net.ServiceBackendPort{Number: 80}}}),
+			err:  `Ingress test: invalid backend Service name "web:80/;"`,
+		},
+		{
+			name: "InvalidSecretName",
+			spec: v1net.IngressSpec{TLS: []v1net.IngressTLS{{SecretName: "../cert"}}},
+			err:  `Ingress test: invalid TLS secretName "../cert"`,
+		},
+		{
+			name: "InvalidTLSHost",
+			spec: v1net.IngressSpec{TLS: []v1net.IngressTLS{{SecretName: "cert", Hosts: []string{"web example com"}}}},
+			err:  `Ingress test: invalid TLS host "web example com"`,
+		},
+		{
+			name:        "InvalidPort",
+			annotations: map[string]string{"io.podman.annotations.ingress.https-port": "https"},
+			err:         `Ingress test: invalid annotation io.podman.annotations.ingress.https-port="https", it must be a port number`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ingress := &v1net.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: test.annotations},
+				Spec:       test.spec,
+			}
+			_, _, err := ToIngressPod(ingress, services)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestIsIngressHost(t *testing.T) {
+	assert.True(t, isIngressHost("example.com"))
+	assert.True(t, isIngressHost("*.example.com"))
+	assert.True(t, isIngressHost("web-1.example.com"))
+	assert.False(t, isIngressHost(""))
+	assert.False(t, isIngressHost("*"))
+	assert.False(t, isIngressHost("-web.example.com"))
+	assert.False(t, isIngressHost("example.com."))
+	assert.False(t, isIngressHost(strings.Repeat("a", 64)+".example.com"))
+}
@@ -7,10 +7,11 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"net/url"
 	"os"
-	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -28,10 +29,15 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
 	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/api/resource"
+	v12 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/types"
+	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/containers/podman/v4/pkg/rootless"
 	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/containers/podman/v4/pkg/specgen/generate"
 	systemdDefine "github.com/containers/podman/v4/pkg/systemd/define"
 	"github.com/containers/podman/v4/pkg/util"
+	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/go-units"
 	"github.com/ghodss/yaml"
@@ -156,6 +162,9 @@ type CtrSpecGenOptions struct {
 	InitContainerType string
 	// PodSecurityContext is the security context specified for the pod
 	PodSecurityContext *v1.PodSecurityContext
+	// QOSClass is the quality of service class of the pod, it sets the
+	// oom_score_adj of the container
+	QOSClass v1.PodQOSClass
 }
 
 func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGenerator, error) {
@@ -235,6 +244,14 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure startupProbe: %w", err)
 	}
+	err = setupReadinessProbe(s, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure readinessProbe: %w", err)
+	}
+	err = setupLifecycleHooks(s, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure lifecycle: %w", err)
+	}
 
 	// Since we prefix the container name with pod name to work-around the uniqueness requirement,
 	// the seccomp profile should reference the actual container name from the YAML
@@ -273,6 +290,33 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 		s.ResourceLimits.Memory.Reservation = &memoryRes
 	}
 
+	if milliCPU := cpuRequest(&opts.Container); milliCPU > 0 {
+		if s.ResourceLimits.CPU == nil {
+			s.ResourceLimits.CPU = &spec.LinuxCPU{}
+		}
+		shares := milliCPUToShares(milliCPU)
+		s.ResourceLimits.CPU.Shares = &shares
+	}
+
+	if opts.QOSClass != "" {
+		var memoryCapacity int64
+		if opts.QOSClass == v1.PodQOSBurstable {
+			mi, err := system.ReadMemInfo()
+			if err != nil {
+				return nil, fmt.Errorf("failed to set oom_score_adj: %w", err)
+			}
+			memoryCapacity = mi.MemTotal
+		}
+		adj := oomScoreAdj(opts.QOSClass, memoryRequest(&opts.Container), memoryCapacity)
+		if rootless.IsRootless() {
+			if current, err := currentOOMScoreAdj(); err == nil && adj < current {
+				logrus.Debugf("Using oom_score_adj %d instead of %d for container %s, rootless users cannot lower it", current, adj, opts.Container.Name)
+				adj = current
+			}
+		}
+		s.OOMScoreAdj = &adj
+	}
+
 	ulimitVal, ok := opts.Annotations[define.UlimitAnnotation]
 	if ok {
 		ulimits := strings.Split(ulimitVal, ",")
@@ -398,7 +442,19 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 		volume.MountPath = dest
 		path := volumeSource.Source
 		if len(volume.SubPath) > 0 {
-			path = filepath.Join(path, volume.SubPath)
+			if err := util.ValidateSubPath(volume.SubPath); err != nil {
+				return nil, fmt.Errorf("volume mount %s: %w", volume.Name, err)
+			}
+			switch volumeSource.Type {
+			case KubeVolumeTypeBindMount, KubeVolumeTypeCharDevice, KubeVolumeTypeBlockDevice:
+				// resolve symlinks in the host path within the host
+				// path, named volumes are resolved the same way when
+				// mounted
+				path, err = securejoin.SecureJoin(path, volume.SubPath)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 		switch volumeSource.Type {
 		case KubeVolumeTypeBindMount:
@@ -471,6 +527,28 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 				SubPath:     volume.SubPath,
 			}
 			s.Volumes = append(s.Volumes, &emptyDirVolume)
+		case KubeVolumeTypeProjected:
+			// downwardAPI and projected volumes are always read-only in Kubernetes
+			if !cutil.StringInSlice("ro", options) {
+				options = append(options, "ro")
+			}
+			projectedVolume := specgen.NamedVolume{
+				Dest:    volume.MountPath,
+				Name:    volumeSource.Source,
+				Options: options,
+				SubPath: volume.SubPath,
+			}
+			s.Volumes = append(s.Volumes, &projectedVolume)
+		case KubeVolumeTypeImage:
+			// image volumes are always read-only in Kubernetes
+			if len(volume.SubPath) > 0 {
+				return nil, fmt.Errorf("volume mount %s: subPath is not supported for image volumes", volume.Name)
+			}
+			imageVolume := specgen.ImageVolume{
+				Source:      volumeSource.Source,
+				Destination: volume.MountPath,
+			}
+			s.ImageVolumes = append(s.ImageVolumes, &imageVolume)
 		default:
 			return nil, errors.New("unsupported volume source type")
 		}
@@ -589,6 +667,102 @@ func probeToHealthConfig(probe *v1.Probe) (*manifest.Schema2HealthConfig, error)
 	return makeHealthCheck(commandString, probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
 }
 
+// handlerToHookCommand converts a lifecycle handler into the command of a
+// post-start or pre-stop hook. HTTP requests are sent with curl from inside the
+// container, like HTTP probes, but curl is run without a shell so the URL and
+// headers are passed as they are. A named port is resolved against the ports
+// of the container.
+func handlerToHookCommand(handler *v1.Handler, ports []v1.ContainerPort) ([]string, error) {
+	switch {
+	case handler.Exec != nil:
+		if len(handler.Exec.Command) == 0 {
+			return nil, errors.New("exec handler requires a command")
+		}
+		return handler.Exec.Command, nil
+	case handler.HTTPGet != nil:
+		uriScheme := v1.URISchemeHTTP
+		if handler.HTTPGet.Scheme != "" {
+			uriScheme = handler.HTTPGet.Scheme
+		}
+		host := "localhost"
+		if handler.HTTPGet.Host != "" {
+			host = handler.HTTPGet.Host
+		}
+		port, err := containerPortNumber(handler.HTTPGet.Port, ports)
+		if err != nil {
+			return nil, err
+		}
+		// the path may carry a query, as with Kubernetes
+		u, err := url.Parse(handler.HTTPGet.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid httpGet path %q: %w", handler.HTTPGet.Path, err)
+		}
+		if u.Path == "" {
+			u.Path = "/"
+		}
+		u.Scheme = strings.ToLower(string(uriScheme))
+		u.Host = net.JoinHostPort(host, strconv.Itoa(int(port)))
+
+		cmd := []string{"curl", "-f"}
+		for _, header := range handler.HTTPGet.HTTPHeaders {
+			if header.Name == "" || strings.ContainsAny(header.Name, ":; \t\r\n") {
+				return nil, fmt.Errorf("invalid httpGet header name %q", header.Name)
+			}
+			if strings.ContainsAny(header.Value, "\r\n") {
+				return nil, fmt.Errorf("invalid value of httpGet header %q", header.Name)
+			}
+			// curl drops a header given as "Name:", an empty one is
+			// sent with "Name;"
+			if header.Value == "" {
+				cmd = append(cmd, "-H", header.Name+";")
+				continue
+			}
+			cmd = append(cmd, "-H", header.Name+": "+header.Value)
+		}
+		return append(cmd, u.String()), nil
+	case handler.TCPSocket != nil:
+		return nil, errors.New("tcpSocket handlers are not supported")
+	}
+	return nil, errors.New("handler requires an exec or httpGet action")
+}
+
+// containerPortNumber returns the number of a port given by number or by the
+// name of one of the TCP ports of the container.
+func containerPortNumber(port intstr.IntOrString, ports []v1.ContainerPort) (int32, error) {
+	if port.Type == intstr.Int {
+		if port.IntVal < 1 || port.IntVal > 65535 {
+			return 0, fmt.Errorf("invalid port %d", port.IntVal)
+		}
+		return port.IntVal, nil
+	}
+	for _, p := range ports {
+		if p.Name == port.StrVal && (p.Protocol == "" || p.Protocol == v1.ProtocolTCP) {
+			return p.ContainerPort, nil
+		}
+	}
+	return 0, fmt.Errorf("container has no TCP port named %q", port.StrVal)
+}
+
+func setupLifecycleHooks(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	var err error
+	if containerYAML.Lifecycle == nil {
+		return nil
+	}
+	if containerYAML.Lifecycle.PostStart != nil {
+		s.PostStartHook, err = handlerToHookCommand(containerYAML.Lifecycle.PostStart, containerYAML.Ports)
+		if err != nil {
+			return fmt.Errorf("postStart: %w", err)
+		}
+	}
+	if containerYAML.Lifecycle.PreStop != nil {
+		s.PreStopHook, err = handlerToHookCommand(containerYAML.Lifecycle.PreStop, containerYAML.Ports)
+		if err != nil {
+			return fmt.Errorf("preStop: %w", err)
+		}
+	}
+	return nil
+}
+
 func setupLivenessProbe(s *specgen.SpecGenerator, containerYAML v1.Container, restartPolicy string) error {
 	var err error
 	if containerYAML.LivenessProbe == nil {
@@ -641,6 +815,25 @@ func setupStartupProbe(s *specgen.SpecGenerator, containerYAML v1.Container, res
 	return nil
 }
 
+func setupReadinessProbe(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	if containerYAML.ReadinessProbe == nil {
+		return nil
+	}
+	emptyHandler := v1.Handler{}
+	if containerYAML.ReadinessProbe.Handler == emptyHandler {
+		return nil
+	}
+	healthConfig, err := probeToHealthConfig(containerYAML.ReadinessProbe)
+	if err != nil {
+		return err
+	}
+	s.ReadinessCheckConfig = &define.ReadinessCheck{
+		Schema2HealthConfig: *healthConfig,
+		Successes:           int(containerYAML.ReadinessProbe.SuccessThreshold),
+	}
+	return nil
+}
+
 func makeHealthCheck(inCmd string, interval int32, retries int32, timeout int32, startPeriod int32) (*manifest.Schema2HealthConfig, error) {
 	// Every healthcheck requires a command
 	if len(inCmd) == 0 {
@@ -893,31 +1086,68 @@ func envVarValue(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
 	return &env.Value, nil
 }
 
-func envVarValueFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
-	fieldRef := env.ValueFrom.FieldRef
-
-	fieldPathLabelPattern := `^metadata.labels\['(.+)'\]$`
-	fieldPathLabelRegex := regexp.MustCompile(fieldPathLabelPattern)
-	fieldPathAnnotationPattern := `^metadata.annotations\['(.+)'\]$`
-	fieldPathAnnotationRegex := regexp.MustCompile(fieldPathAnnotationPattern)
+var (
+	fieldPathLabelRegex      = regexp.MustCompile(`^metadata.labels\['(.+)'\]$`)
+	fieldPathAnnotationRegex = regexp.MustCompile(`^metadata.annotations\['(.+)'\]$`)
+)
 
-	fieldPath := fieldRef.FieldPath
+// isPodFieldSupported returns whether the field of the pod can be resolved
+// by podFieldValue. The complete labels and annotations are only supported
+// in volumes.
+func isPodFieldSupported(fieldPath string, volume bool) bool {
+	_, ok := podFieldValue(fieldPath, &v12.ObjectMeta{}, volume)
+	return ok
+}
 
-	if fieldPath == "metadata.name" {
-		return &opts.PodName, nil
-	}
-	if fieldPath == "metadata.uid" {
-		return &opts.PodID, nil
+// podFieldValue resolves fieldPath against the metadata of the pod, the
+// second return value is false if the field is not valid or not supported.
+func podFieldValue(fieldPath string, meta *v12.ObjectMeta, volume bool) (string, bool) {
+	switch fieldPath {
+	case "metadata.name":
+		return meta.Name, true
+	case "metadata.uid":
+		return string(meta.UID), true
+	case "metadata.labels":
+		return formatPodFieldMap(meta.Labels), volume
+	case "metadata.annotations":
+		return formatPodFieldMap(meta.Annotations), volume
 	}
 	fieldPathMatches := fieldPathLabelRegex.FindStringSubmatch(fieldPath)
 	if len(fieldPathMatches) == 2 { // 1 for entire regex and 1 for subexp
-		labelValue := opts.Labels[fieldPathMatches[1]] // not existent label is OK
-		return &labelValue, nil
+		return meta.Labels[fieldPathMatches[1]], true // not existent label is OK
 	}
 	fieldPathMatches = fieldPathAnnotationRegex.FindStringSubmatch(fieldPath)
 	if len(fieldPathMatches) == 2 { // 1 for entire regex and 1 for subexp
-		annotationValue := opts.Annotations[fieldPathMatches[1]] // not existent annotation is OK
-		return &annotationValue, nil
+		return meta.Annotations[fieldPathMatches[1]], true // not existent annotation is OK
+	}
+	return "", false
+}
+
+// formatPodFieldMap formats labels or annotations like Kubernetes does in
+// downward API volumes, one key="value" pair per line sorted by key.
+func formatPodFieldMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%s", k, strconv.Quote(m[k])))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func envVarValueFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
+	fieldPath := env.ValueFrom.FieldRef.FieldPath
+	meta := &v12.ObjectMeta{
+		Name:        opts.PodName,
+		UID:         ktypes.UID(opts.PodID),
+		Labels:      opts.Labels,
+		Annotations: opts.Annotations,
+	}
+	if value, ok := podFieldValue(fieldPath, meta, false); ok {
+		return &value, nil
 	}
 
 	return nil, fmt.Errorf(
@@ -0,0 +1,214 @@
+package kube
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	v1net "github.com/containers/podman/v4/pkg/k8s.io/api/networking/v1"
+	metav1 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/sirupsen/logrus"
+)
+
+// ToNetworkPolicy combines the NetworkPolicy objects selecting a pod with the
+// given labels into the network policy of the pod. The rules of all policies
+// are added up, like in Kubernetes. It returns nil if no policy selects the
+// pod. Podman has no namespaces, so namespace selectors match all pods.
+func ToNetworkPolicy(policies []v1net.NetworkPolicy, podLabels map[string]string, podSpec *v1.PodSpec) (*define.NetworkPolicy, error) {
+	var result *define.NetworkPolicy
+	for _, policy := range policies {
+		podSelector, err := toLabelSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("NetworkPolicy %s: %w", policy.Name, err)
+		}
+		if !podSelector.Matches(podLabels) {
+			continue
+		}
+		if result == nil {
+			result = new(define.NetworkPolicy)
+		}
+		result.Names = append(result.Names, policy.Name)
+
+		ingress, egress := len(policy.Spec.PolicyTypes) == 0, len(policy.Spec.PolicyTypes) == 0 && len(policy.Spec.Egress) > 0
+		for _, policyType := range policy.Spec.PolicyTypes {
+			switch policyType {
+			case v1net.PolicyTypeIngress:
+				ingress = true
+			case v1net.PolicyTypeEgress:
+				egress = true
+			default:
+				return nil, fmt.Errorf("NetworkPolicy %s: unsupported policy type %q", policy.Name, policyType)
+			}
+		}
+
+		if ingress {
+			result.IsolateIngress = true
+			for _, rule := range policy.Spec.Ingress {
+				r, err := toNetworkPolicyRule(policy.Name, rule.From, rule.Ports, podSpec)
+				if err != nil {
+					return nil, err
+				}
+				result.Ingress = append(result.Ingress, r)
+			}
+		}
+		if egress {
+			result.IsolateEgress = true
+			for _, rule := range policy.Spec.Egress {
+				// named ports refer to the ports of the peers, which
+				// are not known here
+				r, err := toNetworkPolicyRule(policy.Name, rule.To, rule.Ports, nil)
+				if err != nil {
+					return nil, err
+				}
+				result.Egress = append(result.Egress, r)
+			}
+		}
+	}
+	return result, nil
+}
+
+// toNetworkPolicyRule converts the peers and ports of an ingress or egress
+// rule. Named ports are looked up in the containers of podSpec.
+func toNetworkPolicyRule(policyName string, peers []v1net.NetworkPolicyPeer, ports []v1net.NetworkPolicyPort, podSpec *v1.PodSpec) (define.NetworkPolicyRule, error) {
+	var rule define.NetworkPolicyRule
+	for _, peer := range peers {
+		p, err := toNetworkPolicyPeer(policyName, peer)
+		if err != nil {
+			return rule, fmt.Errorf("NetworkPolicy %s: %w", policyName, err)
+		}
+		rule.Peers = append(rule.Peers, p)
+	}
+	for _, port := range ports {
+		p, err := toNetworkPolicyPort(port, podSpec)
+		if err != nil {
+			return rule, fmt.Errorf("NetworkPolicy %s: %w", policyName, err)
+		}
+		rule.Ports = append(rule.Ports, p)
+	}
+	return rule, nil
+}
+
+func toNetworkPolicyPeer(policyName string, peer v1net.NetworkPolicyPeer) (define.NetworkPolicyPeer, error) {
+	var result define.NetworkPolicyPeer
+	if peer.IPBlock != nil {
+		if peer.PodSelector != nil || peer.NamespaceSelector != nil {
+			return result, fmt.Errorf("ipBlock cannot be combined with podSelector or namespaceSelector")
+		}
+		if _, _, err := net.ParseCIDR(peer.IPBlock.CIDR); err != nil {
+			return result, fmt.Errorf("invalid ipBlock cidr: %w", err)
+		}
+		for _, except := range peer.IPBlock.Except {
+			if _, _, err := net.ParseCIDR(except); err != nil {
+				return result, fmt.Errorf("invalid ipBlock except: %w", err)
+			}
+		}
+		result.CIDR = peer.IPBlock.CIDR
+		result.Except = peer.IPBlock.Except
+		return result, nil
+	}
+
+	if peer.NamespaceSelector != nil && (len(peer.NamespaceSelector.MatchLabels) > 0 || len(peer.NamespaceSelector.MatchExpressions) > 0) {
+		logrus.Warnf("NetworkPolicy %s: namespaceSelector is ignored, all pods are in the same namespace with Podman", policyName)
+	}
+	selector := &metav1.LabelSelector{}
+	if peer.PodSelector != nil {
+		selector = peer.PodSelector
+	} else if peer.NamespaceSelector == nil {
+		return result, fmt.Errorf("peer must have a podSelector, namespaceSelector or ipBlock")
+	}
+	podSelector, err := toLabelSelector(selector)
+	if err != nil {
+		return result, err
+	}
+	result.PodSelector = podSelector
+	return result, nil
+}
+
+func toNetworkPolicyPort(port v1net.NetworkPolicyPort, podSpec *v1.PodSpec) (define.NetworkPolicyPort, error) {
+	result := define.NetworkPolicyPort{Protocol: "tcp"}
+	if port.Protocol != nil {
+		switch *port.Protocol {
+		case v1.ProtocolTCP, v1.ProtocolUDP, v1.ProtocolSCTP:
+			result.Protocol = strings.ToLower(string(*port.Protocol))
+		default:
+			return result, fmt.Errorf("unsupported protocol %q", *port.Protocol)
+		}
+	}
+	if port.Port == nil {
+		if port.EndPort != nil {
+			return result, fmt.Errorf("endPort requires port to be set")
+		}
+		return result, nil
+	}
+
+	switch port.Port.Type {
+	case intstr.Int:
+		if port.Port.IntVal < 1 || port.Port.IntVal > 65535 {
+			return result, fmt.Errorf("invalid port %d", port.Port.IntVal)
+		}
+		result.Port = uint16(port.Port.IntVal)
+	default:
+		if port.EndPort != nil {
+			return result, fmt.Errorf("endPort cannot be used with named port %q", port.Port.StrVal)
+		}
+		number, err := namedPort(port.Port.StrVal, result.Protocol, podSpec)
+		if err != nil {
+			return result, err
+		}
+		result.Port = number
+	}
+	if port.EndPort != nil {
+		if *port.EndPort < int32(result.Port) || *port.EndPort > 65535 {
+			return result, fmt.Errorf("invalid endPort %d for port %d", *port.EndPort, result.Port)
+		}
+		result.EndPort = uint16(*port.EndPort)
+	}
+	return result, nil
+}
+
+// namedPort returns the number of the container port with the name and
+// protocol in the pod.
+func namedPort(name, protocol string, podSpec *v1.PodSpec) (uint16, error) {
+	if podSpec == nil {
+		return 0, fmt.Errorf("named port %q is only supported in ingress rules", name)
+	}
+	for _, ctr := range podSpec.Containers {
+		for _, port := range ctr.Ports {
+			portProtocol := "tcp"
+			if port.Protocol != "" {
+				portProtocol = strings.ToLower(string(port.Protocol))
+			}
+			if port.Name == name && portProtocol == protocol {
+				return uint16(port.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no %s container port named %q in the pod", protocol, name)
+}
+
+func toLabelSelector(selector *metav1.LabelSelector) (*define.LabelSelector, error) {
+	result := &define.LabelSelector{MatchLabels: selector.MatchLabels}
+	for _, req := range selector.MatchExpressions {
+		switch req.Operator {
+		case metav1.LabelSelectorOpIn, metav1.LabelSelectorOpNotIn:
+			if len(req.Values) == 0 {
+				return nil, fmt.Errorf("label selector operator %s requires values", req.Operator)
+			}
+		case metav1.LabelSelectorOpExists, metav1.LabelSelectorOpDoesNotExist:
+			if len(req.Values) > 0 {
+				return nil, fmt.Errorf("label selector operator %s does not accept values", req.Operator)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported label selector operator %q", req.Operator)
+		}
+		result.MatchExpressions = append(result.MatchExpressions, define.LabelSelectorRequirement{
+			Key:      req.Key,
+			Operator: string(req.Operator),
+			Values:   req.Values,
+		})
+	}
+	return result, nil
+}
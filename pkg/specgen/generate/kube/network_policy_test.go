@@ -0,0 +1,150 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v4/libpod/define"
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	v1net "github.com/containers/podman/v4/pkg/k8s.io/api/networking/v1"
+	metav1 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToNetworkPolicy(t *testing.T) {
+	udp := v1.ProtocolUDP
+	port80 := intstr.FromInt(80)
+	portHTTP := intstr.FromString("http")
+	endPort := int32(90)
+	podSpec := &v1.PodSpec{
+		Containers: []v1.Container{{
+			Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		}},
+	}
+
+	denyAll := v1net.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "deny-all"},
+		Spec: v1net.NetworkPolicySpec{
+			PolicyTypes: []v1net.PolicyType{v1net.PolicyTypeIngress, v1net.PolicyTypeEgress},
+		},
+	}
+	allowWeb := v1net.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-web"},
+		Spec: v1net.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Ingress: []v1net.NetworkPolicyIngressRule{{
+				From: []v1net.NetworkPolicyPeer{
+					{PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "role", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend"}},
+					}}},
+					{IPBlock: &v1net.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+				},
+				Ports: []v1net.NetworkPolicyPort{
+					{Port: &port80, EndPort: &endPort},
+					{Port: &portHTTP},
+					{Protocol: &udp},
+				},
+			}},
+		},
+	}
+	allowDNS := v1net.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-dns"},
+		Spec: v1net.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			Egress: []v1net.NetworkPolicyEgressRule{{
+				To: []v1net.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+			}},
+		},
+	}
+
+	policy, err := ToNetworkPolicy([]v1net.NetworkPolicy{allowWeb, allowDNS}, map[string]string{"app": "other"}, podSpec)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = ToNetworkPolicy([]v1net.NetworkPolicy{allowWeb, allowDNS}, map[string]string{"app": "web"}, podSpec)
+	require.NoError(t, err)
+	assert.Equal(t, &define.NetworkPolicy{
+		Names:          []string{"allow-web"},
+		IsolateIngress: true,
+		Ingress: []define.NetworkPolicyRule{{
+			Peers: []define.NetworkPolicyPeer{
+				{PodSelector: &define.LabelSelector{MatchExpressions: []define.LabelSelectorRequirement{
+					{Key: "role", Operator: "In", Values: []string{"frontend"}},
+				}}},
+				{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+			},
+			Ports: []define.NetworkPolicyPort{
+				{Protocol: "tcp", Port: 80, EndPort: 90},
+				{Protocol: "tcp", Port: 8080},
+				{Protocol: "udp"},
+			},
+		}},
+	}, policy)
+
+	// egress is only isolated if the policy has egress rules or type
+	policy, err = ToNetworkPolicy([]v1net.NetworkPolicy{allowDNS, denyAll}, map[string]string{"app": "db"}, podSpec)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"allow-dns", "deny-all"}, policy.Names)
+	assert.True(t, policy.IsolateIngress)
+	assert.True(t, policy.IsolateEgress)
+	assert.Empty(t, policy.Ingress)
+	require.Len(t, policy.Egress, 1)
+	assert.Equal(t, []define.NetworkPolicyPeer{{PodSelector: &define.LabelSelector{}}}, policy.Egress[0].Peers)
+}
+
+func TestToNetworkPolicyErrors(t *testing.T) {
+	portHTTP := intstr.FromString("http")
+	port0 := intstr.FromInt(0)
+	sctp := v1.Protocol("ICMP")
+	for name, spec := range map[string]v1net.NetworkPolicySpec{
+		"invalid cidr": {Ingress: []v1net.NetworkPolicyIngressRule{{
+			From: []v1net.NetworkPolicyPeer{{IPBlock: &v1net.IPBlock{CIDR: "10.0.0.1"}}},
+		}}},
+		"ipBlock with selector": {Ingress: []v1net.NetworkPolicyIngressRule{{
+			From: []v1net.NetworkPolicyPeer{{IPBlock: &v1net.IPBlock{CIDR: "10.0.0.0/8"}, PodSelector: &metav1.LabelSelector{}}},
+		}}},
+		"empty peer": {Ingress: []v1net.NetworkPolicyIngressRule{{
+			From: []v1net.NetworkPolicyPeer{{}},
+		}}},
+		"unknown named port": {Ingress: []v1net.NetworkPolicyIngressRule{{
+			Ports: []v1net.NetworkPolicyPort{{Port: &portHTTP}},
+		}}},
+		"named port in egress": {PolicyTypes: []v1net.PolicyType{v1net.PolicyTypeEgress}, Egress: []v1net.NetworkPolicyEgressRule{{
+			Ports: []v1net.NetworkPolicyPort{{Port: &portHTTP}},
+		}}},
+		"invalid port": {Ingress: []v1net.NetworkPolicyIngressRule{{
+			Ports: []v1net.NetworkPolicyPort{{Port: &port0}},
+		}}},
+		"invalid protocol": {Ingress: []v1net.NetworkPolicyIngressRule{{
+			Ports: []v1net.NetworkPolicyPort{{Protocol: &sctp}},
+		}}},
+		"invalid operator": {PodSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: "Like"},
+		}}},
+		"invalid policy type": {PolicyTypes: []v1net.PolicyType{"Both"}},
+	} {
+		policy := v1net.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: spec}
+		_, err := ToNetworkPolicy([]v1net.NetworkPolicy{policy}, nil, &v1.PodSpec{})
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	labels := map[string]string{"app": "web", "tier": "frontend"}
+	for _, tt := range []struct {
+		selector define.LabelSelector
+		match    bool
+	}{
+		{define.LabelSelector{}, true},
+		{define.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, true},
+		{define.LabelSelector{MatchLabels: map[string]string{"app": "db"}}, false},
+		{define.LabelSelector{MatchExpressions: []define.LabelSelectorRequirement{{Key: "tier", Operator: "In", Values: []string{"backend", "frontend"}}}}, true},
+		{define.LabelSelector{MatchExpressions: []define.LabelSelectorRequirement{{Key: "tier", Operator: "NotIn", Values: []string{"frontend"}}}}, false},
+		{define.LabelSelector{MatchExpressions: []define.LabelSelectorRequirement{{Key: "env", Operator: "NotIn", Values: []string{"prod"}}}}, true},
+		{define.LabelSelector{MatchExpressions: []define.LabelSelectorRequirement{{Key: "app", Operator: "Exists"}}}, true},
+		{define.LabelSelector{MatchExpressions: []define.LabelSelectorRequirement{{Key: "app", Operator: "DoesNotExist"}}}, false},
+	} {
+		assert.Equal(t, tt.match, tt.selector.Matches(labels), "%+v", tt.selector)
+	}
+}
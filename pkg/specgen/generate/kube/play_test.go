@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/containers/common/pkg/secrets"
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
@@ -238,6 +239,167 @@ func TestConfigMapVolumes(t *testing.T) {
 	}
 }
 
+func TestProjectedVolumes(t *testing.T) {
+	d := t.TempDir()
+	secretsManager := createSecrets(t, d)
+	pod := &v12.ObjectMeta{
+		Name:        "web",
+		UID:         "ec71ff37c67b",
+		Labels:      map[string]string{"app": "web", "tier": "front"},
+		Annotations: map[string]string{"note": "say \"hi\""},
+	}
+
+	tests := []struct {
+		name          string
+		volume        v1.VolumeSource
+		errorMessage  string
+		expectedItems map[string][]byte
+	}{
+		{
+			"DownwardAPI",
+			v1.VolumeSource{
+				DownwardAPI: &v1.DownwardAPIVolumeSource{
+					Items: []v1.DownwardAPIVolumeFile{
+						{Path: "name", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+						{Path: "meta/uid", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.uid"}},
+						{Path: "labels", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels"}},
+						{Path: "annotations", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations"}},
+						{Path: "app", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels['app']"}},
+					},
+				},
+			},
+			"",
+			map[string][]byte{
+				"name":        []byte("web"),
+				"meta/uid":    []byte("ec71ff37c67b"),
+				"labels":      []byte("app=\"web\"\ntier=\"front\""),
+				"annotations": []byte(`note="say \"hi\""`),
+				"app":         []byte("web"),
+			},
+		},
+		{
+			"Projected",
+			v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{
+						{ConfigMap: &v1.ConfigMapProjection{
+							LocalObjectReference: v1.LocalObjectReference{Name: "multi-item"},
+							Items:                []v1.KeyToPath{{Key: "fizz", Path: "config/fizz"}},
+						}},
+						{Secret: &v1.SecretProjection{
+							LocalObjectReference: v1.LocalObjectReference{Name: "foo"},
+							Items:                []v1.KeyToPath{{Key: "myvar", Path: "secret/myvar"}},
+						}},
+						{DownwardAPI: &v1.DownwardAPIProjection{
+							Items: []v1.DownwardAPIVolumeFile{
+								{Path: "name", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+							},
+						}},
+					},
+				},
+			},
+			"",
+			map[string][]byte{
+				"config/fizz":  []byte("buzz"),
+				"secret/myvar": []byte("foo"),
+				"name":         []byte("web"),
+			},
+		},
+		{
+			"ProjectedSecretMissingKey",
+			v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{
+						{Secret: &v1.SecretProjection{
+							LocalObjectReference: v1.LocalObjectReference{Name: "foo"},
+							Items:                []v1.KeyToPath{{Key: "missing", Path: "missing"}},
+						}},
+					},
+				},
+			},
+			`no key "missing" in secret "foo"`,
+			nil,
+		},
+		{
+			"ProjectedServiceAccountToken",
+			v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{
+						{ServiceAccountToken: &v1.ServiceAccountTokenProjection{Path: "token"}},
+					},
+				},
+			},
+			"serviceAccountToken projections are not supported",
+			nil,
+		},
+		{
+			"DownwardAPIResourceFieldRef",
+			v1.VolumeSource{
+				DownwardAPI: &v1.DownwardAPIVolumeSource{
+					Items: []v1.DownwardAPIVolumeFile{
+						{Path: "cpu", ResourceFieldRef: &v1.ResourceFieldSelector{Resource: "limits.cpu"}},
+					},
+				},
+			},
+			`resourceFieldRef of "cpu" is not supported`,
+			nil,
+		},
+		{
+			"DownwardAPIInvalidField",
+			v1.VolumeSource{
+				DownwardAPI: &v1.DownwardAPIVolumeSource{
+					Items: []v1.DownwardAPIVolumeFile{
+						{Path: "node", FieldRef: &v1.ObjectFieldSelector{FieldPath: "spec.nodeName"}},
+					},
+				},
+			},
+			`fieldPath "spec.nodeName" of "node" is either not valid or not supported`,
+			nil,
+		},
+		{
+			"DownwardAPIPathEscape",
+			v1.VolumeSource{
+				DownwardAPI: &v1.DownwardAPIVolumeSource{
+					Items: []v1.DownwardAPIVolumeFile{
+						{Path: "../name", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+					},
+				},
+			},
+			`invalid path "../name": must not contain '..'`,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			result, err := VolumeFromSource(test.volume, configMapList, secretsManager, "test-volume")
+			if test.errorMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, KubeVolumeTypeProjected, result.Type)
+				assert.Equal(t, test.expectedItems, result.ProjectedItems(pod))
+			} else {
+				assert.EqualError(t, err, test.errorMessage)
+			}
+		})
+	}
+}
+
+func TestImageVolumes(t *testing.T) {
+	volume, err := VolumeFromSource(v1.VolumeSource{
+		Image: &v1.ImageVolumeSource{Reference: "quay.io/libpod/alpine:latest", PullPolicy: v1.PullIfNotPresent},
+	}, nil, nil, "data")
+	assert.NoError(t, err)
+	assert.Equal(t, &KubeVolume{
+		Type:       KubeVolumeTypeImage,
+		Source:     "quay.io/libpod/alpine:latest",
+		PullPolicy: v1.PullIfNotPresent,
+	}, volume)
+
+	_, err = VolumeFromSource(v1.VolumeSource{Image: &v1.ImageVolumeSource{}}, nil, nil, "data")
+	assert.EqualError(t, err, "image volume reference must be set")
+}
+
 func TestEnvVarsFrom(t *testing.T) {
 	d := t.TempDir()
 	secretsManager := createSecrets(t, d)
@@ -724,6 +886,22 @@ func TestEnvVarValue(t *testing.T) {
 			true,
 			"ec71ff37c67b688598c0008187ab0960dc34e1dfdcbf3a74e3d778bafcfe0977",
 		},
+		{
+			"FieldRefMetadataAllLabels",
+			v1.EnvVar{
+				Name: "FOO",
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{
+						FieldPath: "metadata.labels",
+					},
+				},
+			},
+			CtrSpecGenOptions{
+				Labels: map[string]string{"label": "value"},
+			},
+			false,
+			nilString,
+		},
 		{
 			"FieldRefMetadataLabelsExist",
 			v1.EnvVar{
@@ -1258,3 +1436,148 @@ func TestHttpLivenessProbe(t *testing.T) {
 		})
 	}
 }
+
+func TestLifecycleHooks(t *testing.T) {
+	tests := []struct {
+		name      string
+		lifecycle *v1.Lifecycle
+		succeed   bool
+		postStart []string
+		preStop   []string
+	}{
+		{
+			"NoLifecycle",
+			nil,
+			true,
+			nil,
+			nil,
+		},
+		{
+			"ExecHandlers",
+			&v1.Lifecycle{
+				PostStart: &v1.Handler{Exec: &v1.ExecAction{Command: []string{"/bin/warmup"}}},
+				PreStop:   &v1.Handler{Exec: &v1.ExecAction{Command: []string{"nginx", "-s", "quit"}}},
+			},
+			true,
+			[]string{"/bin/warmup"},
+			[]string{"nginx", "-s", "quit"},
+		},
+		{
+			"HttpHandlerUsesDefaults",
+			&v1.Lifecycle{
+				PreStop: &v1.Handler{HTTPGet: &v1.HTTPGetAction{Port: intstr.FromInt(8080), Path: "/drain"}},
+			},
+			true,
+			nil,
+			[]string{"curl", "-f", "http://localhost:8080/drain"},
+		},
+		{
+			"HttpHandlerWithHeadersAndQuery",
+			&v1.Lifecycle{
+				PostStart: &v1.Handler{HTTPGet: &v1.HTTPGetAction{
+					Host:   "fe80::1",
+					Port:   intstr.FromInt(8443),
+					Path:   "/warm up?level=1; rm -rf /",
+					Scheme: v1.URISchemeHTTPS,
+					HTTPHeaders: []v1.HTTPHeader{
+						{Name: "Authorization", Value: "Bearer $TOKEN"},
+						{Name: "X-Empty"},
+					},
+				}},
+			},
+			true,
+			[]string{"curl", "-f", "-H", "Authorization: Bearer $TOKEN", "-H", "X-Empty;", "https://[fe80::1]:8443/warm%20up?level=1; rm -rf /"},
+			nil,
+		},
+		{
+			"HttpHandlerInvalidHeader",
+			&v1.Lifecycle{
+				PreStop: &v1.Handler{HTTPGet: &v1.HTTPGetAction{
+					Port:        intstr.FromInt(8080),
+					HTTPHeaders: []v1.HTTPHeader{{Name: "X-Injected", Value: "a\r\nHost: evil"}},
+				}},
+			},
+			false,
+			nil,
+			nil,
+		},
+		{
+			"TCPSocketHandlerUnsupported",
+			&v1.Lifecycle{
+				PostStart: &v1.Handler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(80)}},
+			},
+			false,
+			nil,
+			nil,
+		},
+		{
+			"EmptyHandler",
+			&v1.Lifecycle{
+				PreStop: &v1.Handler{},
+			},
+			false,
+			nil,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := specgen.SpecGenerator{}
+			err := setupLifecycleHooks(&s, v1.Container{Lifecycle: test.lifecycle})
+			assert.Equal(t, test.succeed, err == nil)
+			assert.Equal(t, test.postStart, s.PostStartHook)
+			assert.Equal(t, test.preStop, s.PreStopHook)
+		})
+	}
+}
+
+func TestLifecycleHookNamedPort(t *testing.T) {
+	container := v1.Container{
+		Ports: []v1.ContainerPort{
+			{Name: "metrics", ContainerPort: 9090, Protocol: v1.ProtocolUDP},
+			{Name: "http", ContainerPort: 8080},
+		},
+		Lifecycle: &v1.Lifecycle{
+			PreStop: &v1.Handler{HTTPGet: &v1.HTTPGetAction{Port: intstr.FromString("http"), Path: "/drain"}},
+		},
+	}
+	s := specgen.SpecGenerator{}
+	err := setupLifecycleHooks(&s, container)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"curl", "-f", "http://localhost:8080/drain"}, s.PreStopHook)
+
+	container.Lifecycle.PreStop.HTTPGet.Port = intstr.FromString("metrics")
+	err = setupLifecycleHooks(&s, container)
+	assert.EqualError(t, err, `preStop: container has no TCP port named "metrics"`)
+
+	container.Lifecycle.PreStop.HTTPGet.Port = intstr.FromInt(0)
+	err = setupLifecycleHooks(&s, container)
+	assert.EqualError(t, err, "preStop: invalid port 0")
+}
+
+func TestReadinessProbe(t *testing.T) {
+	s := specgen.SpecGenerator{}
+	err := setupReadinessProbe(&s, v1.Container{
+		ReadinessProbe: &v1.Probe{
+			Handler: v1.Handler{
+				Exec: &v1.ExecAction{Command: []string{"cat", "/tmp/ready"}},
+			},
+			PeriodSeconds:    5,
+			SuccessThreshold: 2,
+			FailureThreshold: 4,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, s.HealthConfig)
+	assert.Equal(t, []string{"CMD", "cat", "/tmp/ready"}, s.ReadinessCheckConfig.Test)
+	assert.Equal(t, 5*time.Second, s.ReadinessCheckConfig.Interval)
+	assert.Equal(t, 4, s.ReadinessCheckConfig.Retries)
+	assert.Equal(t, 2, s.ReadinessCheckConfig.Successes)
+
+	s = specgen.SpecGenerator{}
+	err = setupReadinessProbe(&s, v1.Container{ReadinessProbe: &v1.Probe{}})
+	assert.NoError(t, err)
+	assert.Nil(t, s.ReadinessCheckConfig)
+}
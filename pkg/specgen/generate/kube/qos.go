@@ -0,0 +1,133 @@
+package kube
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// minShares is the minimum number of CPU shares of a container, as
+	// enforced by the kernel.
+	minShares = 2
+	// sharesPerCPU is the number of CPU shares of a full CPU.
+	sharesPerCPU = 1024
+
+	// guaranteedOOMScoreAdj is the oom_score_adj of containers of
+	// Guaranteed pods, Kubernetes reserves lower values for the system.
+	guaranteedOOMScoreAdj = -997
+	// besteffortOOMScoreAdj is the oom_score_adj of containers of
+	// BestEffort pods, they are killed first.
+	besteffortOOMScoreAdj = 1000
+)
+
+// QOSClass returns the quality of service class of a pod, computed like the
+// kubelet does. A pod is Guaranteed if every container has CPU and memory
+// limits equal to its requests, BestEffort if no container has any request
+// or limit and Burstable otherwise. Like in Kubernetes, an unset request
+// defaults to the limit.
+func QOSClass(podSpec *v1.PodSpec) v1.PodQOSClass {
+	hasResources := false
+	isGuaranteed := true
+	for _, ctr := range append(podSpec.InitContainers, podSpec.Containers...) {
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			limit, hasLimit := nonZeroQuantity(ctr.Resources.Limits, name)
+			request, hasRequest := nonZeroQuantity(ctr.Resources.Requests, name)
+			if hasLimit || hasRequest {
+				hasResources = true
+			}
+			if !hasLimit || (hasRequest && request.Cmp(limit) != 0) {
+				isGuaranteed = false
+			}
+		}
+	}
+	switch {
+	case !hasResources:
+		return v1.PodQOSBestEffort
+	case isGuaranteed:
+		return v1.PodQOSGuaranteed
+	default:
+		return v1.PodQOSBurstable
+	}
+}
+
+// nonZeroQuantity returns the quantity of a resource in list, if it is set
+// and not zero.
+func nonZeroQuantity(list v1.ResourceList, name v1.ResourceName) (resource.Quantity, bool) {
+	q, ok := list[name]
+	if !ok || q.IsZero() {
+		return q, false
+	}
+	return q, true
+}
+
+// cpuRequest returns the CPU request of a container in milli CPUs. An unset
+// request defaults to the limit.
+func cpuRequest(container *v1.Container) int64 {
+	if q, ok := nonZeroQuantity(container.Resources.Requests, v1.ResourceCPU); ok {
+		return q.MilliValue()
+	}
+	if q, ok := nonZeroQuantity(container.Resources.Limits, v1.ResourceCPU); ok {
+		return q.MilliValue()
+	}
+	return 0
+}
+
+// memoryRequest returns the memory request of a container in bytes. An
+// unset request defaults to the limit.
+func memoryRequest(container *v1.Container) int64 {
+	if q, ok := nonZeroQuantity(container.Resources.Requests, v1.ResourceMemory); ok {
+		return q.Value()
+	}
+	if q, ok := nonZeroQuantity(container.Resources.Limits, v1.ResourceMemory); ok {
+		return q.Value()
+	}
+	return 0
+}
+
+// milliCPUToShares converts a CPU request to CPU shares, which the OCI
+// runtime converts to cpu.weight on cgroup v2.
+func milliCPUToShares(milliCPU int64) uint64 {
+	shares := milliCPU * sharesPerCPU / 1000
+	if shares < minShares {
+		return minShares
+	}
+	return uint64(shares)
+}
+
+// oomScoreAdj returns the oom_score_adj of a container of a pod of the
+// given class. Containers of Burstable pods are more likely to be killed
+// the less memory they request compared to the memory of the host.
+func oomScoreAdj(class v1.PodQOSClass, memoryRequest, memoryCapacity int64) int {
+	switch class {
+	case v1.PodQOSGuaranteed:
+		return guaranteedOOMScoreAdj
+	case v1.PodQOSBestEffort:
+		return besteffortOOMScoreAdj
+	}
+	adj := besteffortOOMScoreAdj
+	if memoryCapacity > 0 {
+		adj = 1000 - int(1000*memoryRequest/memoryCapacity)
+	}
+	// Keep Burstable containers between Guaranteed and BestEffort ones.
+	if adj < 1000+guaranteedOOMScoreAdj {
+		adj = 1000 + guaranteedOOMScoreAdj
+	}
+	if adj >= besteffortOOMScoreAdj {
+		adj = besteffortOOMScoreAdj - 1
+	}
+	return adj
+}
+
+// currentOOMScoreAdj returns the oom_score_adj of the current process.
+// Unprivileged processes cannot give their children a lower value.
+func currentOOMScoreAdj() (int, error) {
+	content, err := os.ReadFile("/proc/self/oom_score_adj")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
@@ -0,0 +1,100 @@
+package kube
+
+import (
+	"testing"
+
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/api/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQOSClass(t *testing.T) {
+	resources := func(limits, requests map[v1.ResourceName]string) v1.ResourceRequirements {
+		r := v1.ResourceRequirements{Limits: v1.ResourceList{}, Requests: v1.ResourceList{}}
+		for name, q := range limits {
+			r.Limits[name] = resource.MustParse(q)
+		}
+		for name, q := range requests {
+			r.Requests[name] = resource.MustParse(q)
+		}
+		return r
+	}
+	both := map[v1.ResourceName]string{v1.ResourceCPU: "500m", v1.ResourceMemory: "128Mi"}
+
+	tests := []struct {
+		name       string
+		containers []v1.ResourceRequirements
+		expected   v1.PodQOSClass
+	}{
+		{
+			name:       "NoResources",
+			containers: []v1.ResourceRequirements{{}, {}},
+			expected:   v1.PodQOSBestEffort,
+		},
+		{
+			name:       "LimitsEqualRequests",
+			containers: []v1.ResourceRequirements{resources(both, both)},
+			expected:   v1.PodQOSGuaranteed,
+		},
+		{
+			name:       "RequestsDefaultToLimits",
+			containers: []v1.ResourceRequirements{resources(both, nil)},
+			expected:   v1.PodQOSGuaranteed,
+		},
+		{
+			name: "RequestsBelowLimits",
+			containers: []v1.ResourceRequirements{
+				resources(both, map[v1.ResourceName]string{v1.ResourceCPU: "250m"}),
+			},
+			expected: v1.PodQOSBurstable,
+		},
+		{
+			name: "OnlyMemoryLimit",
+			containers: []v1.ResourceRequirements{
+				resources(map[v1.ResourceName]string{v1.ResourceMemory: "128Mi"}, nil),
+			},
+			expected: v1.PodQOSBurstable,
+		},
+		{
+			name:       "OneContainerWithoutResources",
+			containers: []v1.ResourceRequirements{resources(both, both), {}},
+			expected:   v1.PodQOSBurstable,
+		},
+		{
+			name: "ZeroQuantities",
+			containers: []v1.ResourceRequirements{
+				resources(map[v1.ResourceName]string{v1.ResourceCPU: "0"}, map[v1.ResourceName]string{v1.ResourceMemory: "0"}),
+			},
+			expected: v1.PodQOSBestEffort,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			podSpec := &v1.PodSpec{}
+			for _, r := range test.containers {
+				podSpec.Containers = append(podSpec.Containers, v1.Container{Resources: r})
+			}
+			assert.Equal(t, test.expected, QOSClass(podSpec))
+		})
+	}
+}
+
+func TestMilliCPUToShares(t *testing.T) {
+	assert.Equal(t, uint64(2), milliCPUToShares(0))
+	assert.Equal(t, uint64(2), milliCPUToShares(1))
+	assert.Equal(t, uint64(102), milliCPUToShares(100))
+	assert.Equal(t, uint64(512), milliCPUToShares(500))
+	assert.Equal(t, uint64(2048), milliCPUToShares(2000))
+}
+
+func TestOOMScoreAdj(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+	assert.Equal(t, -997, oomScoreAdj(v1.PodQOSGuaranteed, gib, 8*gib))
+	assert.Equal(t, 1000, oomScoreAdj(v1.PodQOSBestEffort, 0, 8*gib))
+	assert.Equal(t, 875, oomScoreAdj(v1.PodQOSBurstable, gib, 8*gib))
+	// Burstable containers stay between the other classes.
+	assert.Equal(t, 999, oomScoreAdj(v1.PodQOSBurstable, 0, 8*gib))
+	assert.Equal(t, 3, oomScoreAdj(v1.PodQOSBurstable, 8*gib, 8*gib))
+}
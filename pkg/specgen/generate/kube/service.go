@@ -0,0 +1,109 @@
+package kube
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ToServices returns the DNS aliases and port redirects for the Service
+// objects selecting a pod with the given labels. Every selecting Service is
+// reachable as name, name.namespace, name.namespace.svc and
+// name.namespace.svc.cluster.local, with the namespace defaulting to
+// "default". Service ports whose targetPort differs from the port are
+// redirected to the targetPort; named target ports are looked up in the
+// containers of podSpec.
+func ToServices(services []v1.Service, podLabels map[string]string, podSpec *v1.PodSpec) ([]string, []define.ServicePort, error) {
+	var (
+		aliases []string
+		ports   []define.ServicePort
+	)
+	for _, service := range services {
+		if !selectsPod(service.Spec.Selector, podLabels) {
+			continue
+		}
+		aliases = append(aliases, serviceAliases(service.Name, service.Namespace)...)
+
+		// headless services resolve straight to the pods, so there is
+		// no service port to redirect
+		if service.Spec.ClusterIP == v1.ClusterIPNone {
+			continue
+		}
+		for _, port := range service.Spec.Ports {
+			servicePort, err := toServicePort(service.Name, port, podSpec)
+			if err != nil {
+				return nil, nil, fmt.Errorf("Service %s: %w", service.Name, err)
+			}
+			if servicePort.TargetPort == servicePort.Port {
+				continue
+			}
+			for _, p := range ports {
+				if p.Protocol == servicePort.Protocol && p.Port == servicePort.Port && p.TargetPort != servicePort.TargetPort {
+					return nil, nil, fmt.Errorf("Service %s: %s port %d is already redirected to port %d by Service %s",
+						service.Name, p.Protocol, p.Port, p.TargetPort, p.Service)
+				}
+			}
+			ports = append(ports, servicePort)
+		}
+	}
+	return aliases, ports, nil
+}
+
+// selectsPod reports whether a Service selector matches the pod labels.
+// Services without a selector have manually managed endpoints, which are not
+// supported, so they select no pod.
+func selectsPod(selector, podLabels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for k, v := range selector {
+		if l, ok := podLabels[k]; !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
+
+func serviceAliases(name, namespace string) []string {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return []string{
+		name,
+		name + "." + namespace,
+		name + "." + namespace + ".svc",
+		name + "." + namespace + ".svc.cluster.local",
+	}
+}
+
+func toServicePort(serviceName string, port v1.ServicePort, podSpec *v1.PodSpec) (define.ServicePort, error) {
+	result := define.ServicePort{Service: serviceName, Protocol: "tcp"}
+	if port.Protocol != "" {
+		result.Protocol = strings.ToLower(string(port.Protocol))
+	}
+	if port.Port < 1 || port.Port > 65535 {
+		return result, fmt.Errorf("invalid port %d", port.Port)
+	}
+	result.Port = uint16(port.Port)
+
+	switch {
+	case port.TargetPort.Type == intstr.String && port.TargetPort.StrVal != "":
+		targetPort, err := namedPort(port.TargetPort.StrVal, result.Protocol, podSpec)
+		if err != nil {
+			return result, err
+		}
+		result.TargetPort = targetPort
+	case port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal != 0:
+		if port.TargetPort.IntVal < 1 || port.TargetPort.IntVal > 65535 {
+			return result, fmt.Errorf("invalid target port %d", port.TargetPort.IntVal)
+		}
+		result.TargetPort = uint16(port.TargetPort.IntVal)
+	default:
+		// like in Kubernetes, the target port defaults to the port
+		result.TargetPort = result.Port
+	}
+	return result, nil
+}
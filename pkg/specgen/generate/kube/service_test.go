@@ -0,0 +1,118 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v4/libpod/define"
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	metav1 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToServices(t *testing.T) {
+	podLabels := map[string]string{"app": "web", "tier": "frontend"}
+	podSpec := &v1.PodSpec{
+		Containers: []v1.Container{{
+			Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		}},
+	}
+
+	web := v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports: []v1.ServicePort{
+				{Port: 80, TargetPort: intstr.FromString("http")},
+				{Port: 443},
+				{Port: 53, Protocol: v1.ProtocolUDP, TargetPort: intstr.FromInt(5353)},
+			},
+		},
+	}
+	headless := v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-pods", Namespace: "prod"},
+		Spec: v1.ServiceSpec{
+			ClusterIP: v1.ClusterIPNone,
+			Selector:  map[string]string{"tier": "frontend"},
+			Ports:     []v1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	}
+	other := v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db"},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": "db"},
+			Ports:    []v1.ServicePort{{Port: 5432, TargetPort: intstr.FromInt(5433)}},
+		},
+	}
+	noSelector := v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "external"},
+		Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}},
+	}
+
+	aliases, ports, err := ToServices([]v1.Service{web, headless, other, noSelector}, podLabels, podSpec)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"web", "web.default", "web.default.svc", "web.default.svc.cluster.local",
+		"web-pods", "web-pods.prod", "web-pods.prod.svc", "web-pods.prod.svc.cluster.local",
+	}, aliases)
+	assert.Equal(t, []define.ServicePort{
+		{Service: "web", Protocol: "tcp", Port: 80, TargetPort: 8080},
+		{Service: "web", Protocol: "udp", Port: 53, TargetPort: 5353},
+	}, ports)
+
+	aliases, ports, err = ToServices([]v1.Service{other}, podLabels, podSpec)
+	require.NoError(t, err)
+	assert.Empty(t, aliases)
+	assert.Empty(t, ports)
+}
+
+func TestToServicesErrors(t *testing.T) {
+	podLabels := map[string]string{"app": "web"}
+	service := func(name string, port int32, targetPort intstr.IntOrString) v1.Service {
+		return v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.ServiceSpec{
+				Selector: podLabels,
+				Ports:    []v1.ServicePort{{Port: port, TargetPort: targetPort}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		services []v1.Service
+		err      string
+	}{
+		{
+			name:     "UnknownNamedPort",
+			services: []v1.Service{service("web", 80, intstr.FromString("http"))},
+			err:      `Service web: no tcp container port named "http" in the pod`,
+		},
+		{
+			name:     "InvalidPort",
+			services: []v1.Service{service("web", 70000, intstr.FromInt(80))},
+			err:      "Service web: invalid port 70000",
+		},
+		{
+			name:     "InvalidTargetPort",
+			services: []v1.Service{service("web", 80, intstr.FromInt(-1))},
+			err:      "Service web: invalid target port -1",
+		},
+		{
+			name: "ConflictingRedirects",
+			services: []v1.Service{
+				service("web", 80, intstr.FromInt(8080)),
+				service("web2", 80, intstr.FromInt(9090)),
+			},
+			err: "Service web2: tcp port 80 is already redirected to port 8080 by Service web",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := ToServices(test.services, podLabels, &v1.PodSpec{})
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
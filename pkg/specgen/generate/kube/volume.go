@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/containers/common/pkg/parse"
 	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v4/libpod"
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	v12 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/ghodss/yaml"
 	"github.com/sirupsen/logrus"
@@ -32,6 +35,8 @@ const (
 	KubeVolumeTypeCharDevice
 	KubeVolumeTypeSecret
 	KubeVolumeTypeEmptyDir
+	KubeVolumeTypeProjected
+	KubeVolumeTypeImage
 )
 
 //nolint:revive
@@ -48,6 +53,13 @@ type KubeVolume struct {
 	// If the volume is optional, we can move on if it is not found
 	// Only used when there are volumes in a yaml that refer to a configmap
 	Optional bool
+	// FieldRefs maps file names to the fields of the pod written to them
+	// This is only used for downwardAPI and projected volumes, the pod
+	// must exist to resolve the fields.
+	FieldRefs map[string]string
+	// PullPolicy is the pull policy of the image of an image volume
+	// This is only used for image volumes, their Source is the image reference.
+	PullPolicy v1.PullPolicy
 }
 
 // Create a KubeVolume from an HostPathVolumeSource
@@ -231,6 +243,140 @@ func VolumeFromEmptyDir(emptyDirVolumeSource *v1.EmptyDirVolumeSource, name stri
 	return &KubeVolume{Type: KubeVolumeTypeEmptyDir, Source: name}, nil
 }
 
+// Create a KubeVolume from an ImageVolumeSource
+func VolumeFromImage(imageVolumeSource *v1.ImageVolumeSource) (*KubeVolume, error) {
+	if imageVolumeSource.Reference == "" {
+		return nil, errors.New("image volume reference must be set")
+	}
+	return &KubeVolume{
+		Type:       KubeVolumeTypeImage,
+		Source:     imageVolumeSource.Reference,
+		PullPolicy: imageVolumeSource.PullPolicy,
+	}, nil
+}
+
+// validateProjectionPath checks that path is a relative path within a volume.
+func validateProjectionPath(path string) error {
+	if path == "" || filepath.IsAbs(path) {
+		return fmt.Errorf("invalid path %q: must be a relative path", path)
+	}
+	for _, elem := range strings.Split(path, "/") {
+		if elem == ".." {
+			return fmt.Errorf("invalid path %q: must not contain '..'", path)
+		}
+	}
+	return nil
+}
+
+// addDownwardAPIItems adds the files of a downward API volume or projection
+// to the kube volume.
+func addDownwardAPIItems(kv *KubeVolume, items []v1.DownwardAPIVolumeFile) error {
+	for _, item := range items {
+		if err := validateProjectionPath(item.Path); err != nil {
+			return err
+		}
+		switch {
+		case item.FieldRef != nil:
+			if !isPodFieldSupported(item.FieldRef.FieldPath, true) {
+				return fmt.Errorf("fieldPath %q of %q is either not valid or not supported", item.FieldRef.FieldPath, item.Path)
+			}
+			kv.FieldRefs[item.Path] = item.FieldRef.FieldPath
+		case item.ResourceFieldRef != nil:
+			return fmt.Errorf("resourceFieldRef of %q is not supported", item.Path)
+		default:
+			return fmt.Errorf("%q must have either a fieldRef or a resourceFieldRef", item.Path)
+		}
+	}
+	return nil
+}
+
+// VolumeFromDownwardAPI creates a KubeVolume from a DownwardAPIVolumeSource
+func VolumeFromDownwardAPI(downwardAPIVolumeSource *v1.DownwardAPIVolumeSource, name string) (*KubeVolume, error) {
+	kv := &KubeVolume{
+		Type:      KubeVolumeTypeProjected,
+		Source:    name,
+		Items:     map[string][]byte{},
+		FieldRefs: map[string]string{},
+	}
+	if err := addDownwardAPIItems(kv, downwardAPIVolumeSource.Items); err != nil {
+		return nil, err
+	}
+	return kv, nil
+}
+
+// VolumeFromProjected creates a KubeVolume combining the configmaps, secrets
+// and downward API files of a ProjectedVolumeSource
+func VolumeFromProjected(projectedVolumeSource *v1.ProjectedVolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, name string) (*KubeVolume, error) {
+	kv := &KubeVolume{
+		Type:      KubeVolumeTypeProjected,
+		Source:    name,
+		Items:     map[string][]byte{},
+		FieldRefs: map[string]string{},
+	}
+	for _, source := range projectedVolumeSource.Sources {
+		var items map[string][]byte
+		switch {
+		case source.ConfigMap != nil:
+			cmVolume, err := VolumeFromConfigMap(&v1.ConfigMapVolumeSource{
+				LocalObjectReference: source.ConfigMap.LocalObjectReference,
+				Items:                source.ConfigMap.Items,
+				Optional:             source.ConfigMap.Optional,
+			}, configMaps)
+			if err != nil {
+				return nil, err
+			}
+			items = cmVolume.Items
+		case source.Secret != nil:
+			secretVolume, err := VolumeFromSecret(&v1.SecretVolumeSource{
+				SecretName: source.Secret.Name,
+				Optional:   source.Secret.Optional,
+			}, secretsManager)
+			if err != nil {
+				return nil, err
+			}
+			items = secretVolume.Items
+			// Unlike secret volumes, projected secrets honour the items
+			if len(source.Secret.Items) > 0 {
+				items = map[string][]byte{}
+				for _, item := range source.Secret.Items {
+					if val, ok := secretVolume.Items[item.Key]; ok {
+						items[item.Path] = val
+					} else if source.Secret.Optional == nil || !*source.Secret.Optional {
+						return nil, fmt.Errorf("no key %q in secret %q", item.Key, source.Secret.Name)
+					}
+				}
+			}
+		case source.DownwardAPI != nil:
+			if err := addDownwardAPIItems(kv, source.DownwardAPI.Items); err != nil {
+				return nil, err
+			}
+		case source.ServiceAccountToken != nil:
+			return nil, errors.New("serviceAccountToken projections are not supported")
+		}
+		for path, data := range items {
+			if err := validateProjectionPath(path); err != nil {
+				return nil, err
+			}
+			kv.Items[path] = data
+		}
+	}
+	return kv, nil
+}
+
+// ProjectedItems returns the files of a projected volume with the downward
+// API fields resolved for the given pod.
+func (kv *KubeVolume) ProjectedItems(pod *v12.ObjectMeta) map[string][]byte {
+	items := make(map[string][]byte, len(kv.Items)+len(kv.FieldRefs))
+	for path, data := range kv.Items {
+		items[path] = data
+	}
+	for path, fieldPath := range kv.FieldRefs {
+		value, _ := podFieldValue(fieldPath, pod, true)
+		items[path] = []byte(value)
+	}
+	return items
+}
+
 // Create a KubeVolume from one of the supported VolumeSource
 func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, volName string) (*KubeVolume, error) {
 	switch {
@@ -244,13 +390,19 @@ func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, s
 		return VolumeFromSecret(volumeSource.Secret, secretsManager)
 	case volumeSource.EmptyDir != nil:
 		return VolumeFromEmptyDir(volumeSource.EmptyDir, volName)
+	case volumeSource.DownwardAPI != nil:
+		return VolumeFromDownwardAPI(volumeSource.DownwardAPI, volName)
+	case volumeSource.Projected != nil:
+		return VolumeFromProjected(volumeSource.Projected, configMaps, secretsManager, volName)
+	case volumeSource.Image != nil:
+		return VolumeFromImage(volumeSource.Image)
 	default:
-		return nil, errors.New("HostPath, ConfigMap, EmptyDir, Secret, and PersistentVolumeClaim are currently the only supported VolumeSource")
+		return nil, errors.New("HostPath, ConfigMap, EmptyDir, Secret, PersistentVolumeClaim, DownwardAPI, Projected and Image are currently the only supported VolumeSource")
 	}
 }
 
 // Create a map of volume name to KubeVolume
-func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager) (map[string]*KubeVolume, error) {
+func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, podName string) (map[string]*KubeVolume, error) {
 	volumes := make(map[string]*KubeVolume)
 
 	for _, specVolume := range specVolumes {
@@ -258,6 +410,11 @@ func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secre
 		if err != nil {
 			return nil, fmt.Errorf("failed to create volume %q: %w", specVolume.Name, err)
 		}
+		if volume.Type == KubeVolumeTypeProjected {
+			// The files contain data of the pod, do not share the
+			// volume with other pods.
+			volume.Source = podName + "-" + specVolume.Name
+		}
 
 		volumes[specVolume.Name] = volume
 	}
@@ -361,6 +361,9 @@ func namespaceOptions(s *specgen.SpecGenerator, rt *libpod.Runtime, pod *libpod.
 	if s.NetworkOptions != nil {
 		toReturn = append(toReturn, libpod.WithNetworkOptions(s.NetworkOptions))
 	}
+	if !s.NetworkShaping.IsZero() {
+		toReturn = append(toReturn, libpod.WithNetworkShaping(s.NetworkShaping))
+	}
 
 	return toReturn, nil
 }
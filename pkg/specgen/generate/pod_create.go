@@ -164,6 +164,18 @@ func createPodOptions(p *specgen.PodSpecGenerator) ([]libpod.PodCreateOption, er
 		options = append(options, libpod.WithPodResources(*p.ResourceLimits))
 	}
 
+	if p.NetworkPolicy != nil {
+		options = append(options, libpod.WithPodNetworkPolicy(p.NetworkPolicy))
+	}
+
+	if len(p.ServicePorts) > 0 {
+		options = append(options, libpod.WithPodServicePorts(p.ServicePorts))
+	}
+
+	if p.QOSClass != "" {
+		options = append(options, libpod.WithPodQOSClass(p.QOSClass))
+	}
+
 	options = append(options, libpod.WithPodExitPolicy(p.ExitPolicy))
 
 	return options, nil
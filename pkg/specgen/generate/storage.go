@@ -197,6 +197,41 @@ func finalizeMounts(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.Ru
 	return finalMounts, finalVolumes, finalOverlays, nil
 }
 
+// expandGlobMounts replaces every glob mount with bind mounts of the host
+// paths matching its pattern. Each path is mounted into the destination
+// directory of the glob mount under its base name.
+func expandGlobMounts(mounts []spec.Mount) ([]spec.Mount, error) {
+	expanded := make([]spec.Mount, 0, len(mounts))
+	for _, m := range mounts {
+		if m.Type != define.TypeGlob {
+			expanded = append(expanded, m)
+			continue
+		}
+		matches, err := filepath.Glob(m.Source)
+		if err != nil {
+			return nil, fmt.Errorf("expanding glob mount %q: %w", m.Source, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("glob mount %q does not match any path: %w", m.Source, define.ErrInvalidArg)
+		}
+		dests := make(map[string]string, len(matches))
+		for _, match := range matches {
+			dest := path.Join(m.Destination, filepath.Base(match))
+			if other, ok := dests[dest]; ok {
+				return nil, fmt.Errorf("glob mount %q matches %q and %q which are both mounted at %q: %w", m.Source, other, match, dest, specgen.ErrDuplicateDest)
+			}
+			dests[dest] = match
+			expanded = append(expanded, spec.Mount{
+				Type:        define.TypeBind,
+				Source:      match,
+				Destination: dest,
+				Options:     append([]string{}, m.Options...),
+			})
+		}
+	}
+	return expanded, nil
+}
+
 // Get image volumes from the given image
 func getImageVolumes(ctx context.Context, img *libimage.Image, s *specgen.SpecGenerator) (map[string]spec.Mount, map[string]*specgen.NamedVolume, error) {
 	mounts := make(map[string]spec.Mount)
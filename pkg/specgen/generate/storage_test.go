@@ -0,0 +1,42 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/specgen"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandGlobMounts(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.conf", "b.conf", "c.txt"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	for _, sub := range []string{"sub1", "sub2"} {
+		assert.NoError(t, os.Mkdir(filepath.Join(dir, sub), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, sub, "a.conf"), nil, 0644))
+	}
+
+	tmpfs := spec.Mount{Type: define.TypeTmpfs, Source: define.TypeTmpfs, Destination: "/tmp"}
+	mounts, err := expandGlobMounts([]spec.Mount{
+		tmpfs,
+		{Type: define.TypeGlob, Source: filepath.Join(dir, "*.conf"), Destination: "/conf", Options: []string{"ro"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []spec.Mount{
+		tmpfs,
+		{Type: define.TypeBind, Source: filepath.Join(dir, "a.conf"), Destination: "/conf/a.conf", Options: []string{"ro"}},
+		{Type: define.TypeBind, Source: filepath.Join(dir, "b.conf"), Destination: "/conf/b.conf", Options: []string{"ro"}},
+	}, mounts)
+
+	_, err = expandGlobMounts([]spec.Mount{{Type: define.TypeGlob, Source: filepath.Join(dir, "*.none"), Destination: "/conf"}})
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+
+	// matches with the same base name would be mounted at the same path
+	_, err = expandGlobMounts([]spec.Mount{{Type: define.TypeGlob, Source: filepath.Join(dir, "sub*", "a.conf"), Destination: "/conf"}})
+	assert.ErrorIs(t, err, specgen.ErrDuplicateDest)
+}
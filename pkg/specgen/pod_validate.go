@@ -64,6 +64,12 @@ func (p *PodSpecGenerator) Validate() error {
 		if p.NoManageResolvConf {
 			return exclusivePodOptions("NoInfra", "NoManageResolvConf")
 		}
+		if p.NetworkPolicy != nil {
+			return exclusivePodOptions("NoInfra", "NetworkPolicy")
+		}
+		if len(p.ServicePorts) > 0 {
+			return exclusivePodOptions("NoInfra", "ServicePorts")
+		}
 	}
 	if p.NetNS.NSMode != "" && p.NetNS.NSMode != Bridge && p.NetNS.NSMode != Slirp && p.NetNS.NSMode != Pasta && p.NetNS.NSMode != Default {
 		if len(p.PortMappings) > 0 {
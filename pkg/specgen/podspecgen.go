@@ -4,6 +4,7 @@ import (
 	"net"
 
 	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/libpod/define"
 	storageTypes "github.com/containers/storage/types"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -162,6 +163,16 @@ type PodNetworkConfig struct {
 	// NetworkOptions are additional options for each network
 	// Optional.
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// NetworkPolicy restricts the network traffic of the pod. It is only
+	// enforced on bridge networks.
+	// Conflicts with NoInfra=true.
+	// Optional.
+	NetworkPolicy *define.NetworkPolicy `json:"network_policy,omitempty"`
+	// ServicePorts are ports of Kubernetes Services which are redirected
+	// to another port of the pod.
+	// Conflicts with NoInfra=true.
+	// Optional.
+	ServicePorts []define.ServicePort `json:"service_ports,omitempty"`
 }
 
 // PodStorageConfig contains all of the storage related options for the pod and its infra container.
@@ -228,6 +239,10 @@ type PodResourceConfig struct {
 	CPUQuota int64 `json:"cpu_quota,omitempty"`
 	// ThrottleReadBpsDevice contains the rate at which the devices in the pod can be read from/accessed
 	ThrottleReadBpsDevice map[string]spec.LinuxThrottleDevice `json:"throttleReadBpsDevice,omitempty"`
+	// QOSClass is the Kubernetes quality of service class of the pod,
+	// derived from the resource requests and limits of its containers.
+	// Optional.
+	QOSClass string `json:"qos_class,omitempty"`
 }
 
 type PodSecurityConfig struct {
@@ -88,6 +88,14 @@ type ContainerBasicConfig struct {
 	// instead.
 	// Optional.
 	StopTimeout *uint `json:"stop_timeout,omitempty"`
+	// PostStartHook is a command executed in the container after it has
+	// been started. If it fails, the container is stopped again.
+	// Optional.
+	PostStartHook []string `json:"post_start_hook,omitempty"`
+	// PreStopHook is a command executed in the container before the stop
+	// signal is sent.
+	// Optional.
+	PreStopHook []string `json:"pre_stop_hook,omitempty"`
 	// Timeout is a maximum time in seconds the container will run before
 	// main process is sent SIGKILL.
 	// If 0 is used, signal will not be sent. Container can run indefinitely
@@ -501,6 +509,11 @@ type ContainerNetworkConfig struct {
 	// NetworkOptions are additional options for each network
 	// Optional.
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// NetworkShaping is the traffic control applied to the network
+	// interfaces of the container.
+	// Only available if NetNS is set to bridge, slirp4netns or pasta.
+	// Optional.
+	NetworkShaping *define.NetworkShaping `json:"network_shaping,omitempty"`
 }
 
 // ContainerResourceConfig contains information on container resource limits.
@@ -546,6 +559,10 @@ type ContainerHealthCheckConfig struct {
 	// Requires that HealthConfig be set.
 	// Optional.
 	StartupHealthConfig *define.StartupHealthCheck `json:"startupHealthConfig,omitempty"`
+	// Readiness check for a container. It decides whether the container
+	// is ready and does not depend on HealthConfig.
+	// Optional.
+	ReadinessCheckConfig *define.ReadinessCheck `json:"readinessCheckConfig,omitempty"`
 }
 
 // SpecGenerator creates an OCI spec and Libpod configuration options to create
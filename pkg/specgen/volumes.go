@@ -3,11 +3,13 @@ package specgen
 import (
 	"errors"
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/containers/common/pkg/parse"
 	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/util"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 )
@@ -67,6 +69,7 @@ func GenVolumeMounts(volumeFlag []string) (map[string]spec.Mount, map[string]*Na
 			options []string
 			src     string
 			dest    string
+			subPath string
 			err     error
 		)
 
@@ -100,7 +103,11 @@ func GenVolumeMounts(volumeFlag []string) (map[string]spec.Mount, map[string]*Na
 			dest = splitVol[1]
 		}
 		if len(splitVol) > 2 {
-			if options, err = parse.ValidateVolumeOpts(strings.Split(splitVol[2], ",")); err != nil {
+			opts := strings.Split(splitVol[2], ",")
+			if opts, subPath, err = extractSubPath(opts); err != nil {
+				return nil, nil, nil, err
+			}
+			if options, err = parse.ValidateVolumeOpts(opts); err != nil {
 				return nil, nil, nil, err
 			}
 		}
@@ -114,6 +121,9 @@ func GenVolumeMounts(volumeFlag []string) (map[string]spec.Mount, map[string]*Na
 
 		if strings.HasPrefix(src, "/") || strings.HasPrefix(src, ".") || isHostWinPath(src) {
 			// This is not a named volume
+			if subPath != "" {
+				return nil, nil, nil, fmt.Errorf("%v: subpath is only supported for named volumes", vol)
+			}
 			overlayFlag := false
 			chownFlag := false
 			upperDirFlag := false
@@ -184,9 +194,10 @@ func GenVolumeMounts(volumeFlag []string) (map[string]spec.Mount, map[string]*Na
 			newNamedVol.Name = src
 			newNamedVol.Dest = dest
 			newNamedVol.Options = options
+			newNamedVol.SubPath = subPath
 
 			if vol, ok := volumes[newNamedVol.Dest]; ok {
-				if vol.Name == newNamedVol.Name {
+				if vol.Name == newNamedVol.Name && vol.SubPath == newNamedVol.SubPath {
 					continue
 				}
 				return nil, nil, nil, fmt.Errorf("%v: %w", newNamedVol.Dest, ErrDuplicateDest)
@@ -200,6 +211,77 @@ func GenVolumeMounts(volumeFlag []string) (map[string]spec.Mount, map[string]*Na
 	return mounts, volumes, overlayVolumes, nil
 }
 
+// extractSubPath removes the subpath option from the options of a volume and
+// returns it separately, it is not a mount option.
+func extractSubPath(options []string) ([]string, string, error) {
+	var subPath string
+	rest := make([]string, 0, len(options))
+	for _, opt := range options {
+		name, value, hasValue := strings.Cut(opt, "=")
+		if name != "subpath" {
+			rest = append(rest, opt)
+			continue
+		}
+		if subPath != "" {
+			return nil, "", errors.New("cannot pass 'subpath' option more than once")
+		}
+		if !hasValue || value == "" {
+			return nil, "", errors.New("must provide an argument for option subpath")
+		}
+		if err := util.ValidateSubPath(value); err != nil {
+			return nil, "", err
+		}
+		subPath = path.Clean(value)
+	}
+	return rest, subPath, nil
+}
+
+// validateGlobMount checks that the source of a glob mount is an absolute,
+// well formed glob pattern.
+func validateGlobMount(m spec.Mount) error {
+	if !filepath.IsAbs(m.Source) {
+		return fmt.Errorf("glob mount source %q must be an absolute path: %w", m.Source, ErrInvalidSpecConfig)
+	}
+	if _, err := filepath.Match(m.Source, ""); err != nil {
+		return fmt.Errorf("invalid glob mount source %q: %w", m.Source, err)
+	}
+	if m.Destination == "" {
+		return fmt.Errorf("glob mount %q must set a destination: %w", m.Source, ErrInvalidSpecConfig)
+	}
+	return nil
+}
+
+// validateOverlayVolume checks that the source, upper and work directories
+// of an overlay volume are absolute paths, and that upperdir and workdir are
+// set together.
+func validateOverlayVolume(v *OverlayVolume) error {
+	if !filepath.IsAbs(v.Source) {
+		return fmt.Errorf("overlay volume source %q must be an absolute path: %w", v.Source, ErrInvalidSpecConfig)
+	}
+	var upperDir, workDir string
+	for _, o := range v.Options {
+		name, value, _ := strings.Cut(o, "=")
+		switch name {
+		case "upperdir":
+			upperDir = value
+		case "workdir":
+			workDir = value
+		default:
+			continue
+		}
+		if !filepath.IsAbs(value) {
+			return fmt.Errorf("overlay volume %s %q must be an absolute path: %w", name, value, ErrInvalidSpecConfig)
+		}
+	}
+	if (upperDir == "") != (workDir == "") {
+		return fmt.Errorf("overlay volume at %q must set both upperdir and workdir: %w", v.Destination, ErrInvalidSpecConfig)
+	}
+	if upperDir != "" && filepath.Clean(upperDir) == filepath.Clean(workDir) {
+		return fmt.Errorf("overlay volume at %q must use different upperdir and workdir: %w", v.Destination, ErrInvalidSpecConfig)
+	}
+	return nil
+}
+
 // Splits a volume string, accounting for Win drive paths
 // when running as a WSL linux guest or Windows client
 func SplitVolumeString(vol string) []string {
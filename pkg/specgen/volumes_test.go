@@ -0,0 +1,62 @@
+package specgen
+
+import (
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGlobMount(t *testing.T) {
+	assert.NoError(t, validateGlobMount(spec.Mount{Source: "/etc/app/*.conf", Destination: "/conf"}))
+	assert.Error(t, validateGlobMount(spec.Mount{Source: "etc/*.conf", Destination: "/conf"}))
+	assert.Error(t, validateGlobMount(spec.Mount{Source: "/etc/[", Destination: "/conf"}))
+	assert.Error(t, validateGlobMount(spec.Mount{Source: "/etc/*.conf"}))
+}
+
+func TestValidateOverlayVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		volume  OverlayVolume
+		wantErr bool
+	}{
+		{
+			name:   "without upperdir",
+			volume: OverlayVolume{Source: "/src", Destination: "/data", Options: []string{"O"}},
+		},
+		{
+			name:   "with upperdir and workdir",
+			volume: OverlayVolume{Source: "/src", Destination: "/data", Options: []string{"O", "upperdir=/upper", "workdir=/work"}},
+		},
+		{
+			name:    "relative source",
+			volume:  OverlayVolume{Source: "src", Destination: "/data"},
+			wantErr: true,
+		},
+		{
+			name:    "relative upperdir",
+			volume:  OverlayVolume{Source: "/src", Destination: "/data", Options: []string{"upperdir=upper", "workdir=/work"}},
+			wantErr: true,
+		},
+		{
+			name:    "upperdir without workdir",
+			volume:  OverlayVolume{Source: "/src", Destination: "/data", Options: []string{"upperdir=/upper"}},
+			wantErr: true,
+		},
+		{
+			name:    "same upperdir and workdir",
+			volume:  OverlayVolume{Source: "/src", Destination: "/data", Options: []string{"upperdir=/upper", "workdir=/upper/"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOverlayVolume(&tt.volume)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -290,6 +290,30 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.StartupHealthConfig.Successes = int(c.StartupHCSuccesses)
 	}
 
+	if c.ReadinessCmd != "" {
+		tmpHcConfig, err := makeHealthCheckFromCli(c.ReadinessCmd, c.ReadinessInterval, c.ReadinessRetries, c.ReadinessTimeout, "0s", false)
+		if err != nil {
+			return err
+		}
+		s.ReadinessCheckConfig = &define.ReadinessCheck{
+			Schema2HealthConfig: *tmpHcConfig,
+			Successes:           int(c.ReadinessSuccesses),
+		}
+	}
+
+	if c.HookPostStart != "" {
+		s.PostStartHook, err = makeHookFromCli(c.HookPostStart)
+		if err != nil {
+			return fmt.Errorf("invalid --hook-post-start: %w", err)
+		}
+	}
+	if c.HookPreStop != "" {
+		s.PreStopHook, err = makeHookFromCli(c.HookPreStop)
+		if err != nil {
+			return fmt.Errorf("invalid --hook-pre-stop: %w", err)
+		}
+	}
+
 	if err := setNamespaces(s, c); err != nil {
 		return err
 	}
@@ -501,6 +525,13 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.NetworkOptions = c.Net.NetworkOptions
 		s.UseImageHosts = c.Net.NoHosts
 	}
+	if c.NetworkRateLimit != "" || c.NetworkDelay != "" || c.NetworkLoss != "" {
+		shaping, err := ParseNetworkShaping(s.NetworkShaping, c.NetworkRateLimit, c.NetworkDelay, c.NetworkLoss)
+		if err != nil {
+			return err
+		}
+		s.NetworkShaping = shaping
+	}
 	if len(s.HostUsers) == 0 || len(c.HostUsers) != 0 {
 		s.HostUsers = c.HostUsers
 	}
@@ -851,6 +882,22 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	return nil
 }
 
+// makeHookFromCli parses a lifecycle hook command, which is either a JSON array
+// executed as is or a command string run by /bin/sh.
+func makeHookFromCli(inCmd string) ([]string, error) {
+	if strings.HasPrefix(strings.TrimSpace(inCmd), "[") {
+		cmdArr := []string{}
+		if err := json.Unmarshal([]byte(inCmd), &cmdArr); err != nil {
+			return nil, err
+		}
+		if len(cmdArr) == 0 || cmdArr[0] == "" {
+			return nil, errors.New("hook command must not be empty")
+		}
+		return cmdArr, nil
+	}
+	return []string{"/bin/sh", "-c", inCmd}, nil
+}
+
 func makeHealthCheckFromCli(inCmd, interval string, retries uint, timeout, startPeriod string, isStartup bool) (*manifest.Schema2HealthConfig, error) {
 	cmdArr := []string{}
 	isArr := true
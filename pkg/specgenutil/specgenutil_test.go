@@ -154,3 +154,19 @@ func TestParseLinuxResourcesDeviceAccess(t *testing.T) {
 	_, err = parseLinuxResourcesDeviceAccess("a *:-3 r")
 	assert.NotNil(t, err, "err is not nil")
 }
+
+func TestMakeHookFromCli(t *testing.T) {
+	hook, err := makeHookFromCli(`["/usr/bin/drain", "--timeout", "10"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/usr/bin/drain", "--timeout", "10"}, hook)
+
+	hook, err = makeHookFromCli("nginx -s quit && sleep 5")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/bin/sh", "-c", "nginx -s quit && sleep 5"}, hook)
+
+	_, err = makeHookFromCli("[]")
+	assert.Error(t, err)
+
+	_, err = makeHookFromCli(`["unterminated`)
+	assert.Error(t, err)
+}
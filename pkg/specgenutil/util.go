@@ -7,9 +7,11 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod/define"
 	storageTypes "github.com/containers/storage/types"
 	"github.com/sirupsen/logrus"
 )
@@ -261,6 +263,99 @@ func parseAndValidatePort(port string) (uint16, error) {
 	return uint16(num), nil
 }
 
+// rateUnits maps the units of --network-rate-limit to their size in bits.
+var rateUnits = map[string]uint64{
+	"":     1,
+	"bit":  1,
+	"kbit": 1e3,
+	"mbit": 1e6,
+	"gbit": 1e9,
+	"tbit": 1e12,
+	"bps":  8,
+	"kbps": 8e3,
+	"mbps": 8e6,
+	"gbps": 8e9,
+	"tbps": 8e12,
+}
+
+// maxNetworkDelay is the largest delay supported by the netem qdisc.
+const maxNetworkDelay = time.Minute
+
+// ParseNetworkShaping returns the traffic control of base with the settings
+// given in the format of --network-rate-limit, --network-delay and
+// --network-loss replaced. Empty values keep the setting of base, base is
+// not modified. nil is returned if no traffic control remains.
+func ParseNetworkShaping(base *define.NetworkShaping, rateLimit, delay, loss string) (*define.NetworkShaping, error) {
+	shaping := define.NetworkShaping{}
+	if base != nil {
+		shaping = *base
+	}
+
+	if rateLimit != "" {
+		for _, limit := range strings.Split(rateLimit, ",") {
+			direction, value, hasValue := strings.Cut(limit, "=")
+			if !hasValue {
+				return nil, fmt.Errorf("invalid network rate limit %q, must be ingress=RATE or egress=RATE", limit)
+			}
+			rate, err := parseNetworkRate(value)
+			if err != nil {
+				return nil, err
+			}
+			switch direction {
+			case "ingress":
+				shaping.IngressRate = rate
+			case "egress":
+				shaping.EgressRate = rate
+			default:
+				return nil, fmt.Errorf("invalid network rate limit direction %q, must be ingress or egress", direction)
+			}
+		}
+	}
+
+	if delay != "" {
+		d, err := time.ParseDuration(delay)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network delay %q: %w", delay, err)
+		}
+		if d < 0 || d > maxNetworkDelay {
+			return nil, fmt.Errorf("invalid network delay %q, must be between 0 and %s", delay, maxNetworkDelay)
+		}
+		shaping.Delay = d
+	}
+
+	if loss != "" {
+		l, err := strconv.ParseFloat(strings.TrimSuffix(loss, "%"), 64)
+		if err != nil || l < 0 || l > 100 {
+			return nil, fmt.Errorf("invalid network loss %q, must be a percentage between 0 and 100", loss)
+		}
+		shaping.Loss = l
+	}
+
+	if shaping.IsZero() {
+		return nil, nil
+	}
+	return &shaping, nil
+}
+
+// parseNetworkRate parses a rate such as 10mbit into bits per second.
+func parseNetworkRate(rate string) (uint64, error) {
+	i := strings.IndexFunc(rate, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(rate)
+	}
+	unit, ok := rateUnits[strings.ToLower(rate[i:])]
+	if !ok {
+		return 0, fmt.Errorf("invalid unit in network rate %q, must be one of bit, kbit, mbit, gbit, tbit, bps, kbps, mbps, gbps or tbps", rate)
+	}
+	value, err := strconv.ParseFloat(rate[:i], 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid network rate %q", rate)
+	}
+	return uint64(value * float64(unit)), nil
+}
+
 func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, syslog, rm, exec bool) ([]string, error) {
 	// We need a cleanup process for containers in the current model.
 	// But we can't assume that the caller is Podman - it could be another
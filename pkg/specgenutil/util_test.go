@@ -3,6 +3,9 @@ package specgenutil
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
 )
 
 func TestCreateExpose(t *testing.T) {
@@ -144,3 +147,94 @@ func Test_parseAndValidatePort(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNetworkShaping(t *testing.T) {
+	type args struct {
+		base      *define.NetworkShaping
+		rateLimit string
+		delay     string
+		loss      string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *define.NetworkShaping
+		wantErr bool
+	}{
+		{
+			name: "nothing set",
+			args: args{},
+			want: nil,
+		},
+		{
+			name: "all set",
+			args: args{
+				rateLimit: "ingress=10mbit,egress=1.5kbps",
+				delay:     "100ms",
+				loss:      "2.5%",
+			},
+			want: &define.NetworkShaping{IngressRate: 10000000, EgressRate: 12000, Delay: 100 * time.Millisecond, Loss: 2.5},
+		},
+		{
+			name: "rate without unit",
+			args: args{rateLimit: "egress=8000"},
+			want: &define.NetworkShaping{EgressRate: 8000},
+		},
+		{
+			name: "update keeps other settings",
+			args: args{
+				base:      &define.NetworkShaping{IngressRate: 1000, EgressRate: 2000, Loss: 1},
+				rateLimit: "ingress=5Mbit",
+				delay:     "1s",
+			},
+			want: &define.NetworkShaping{IngressRate: 5000000, EgressRate: 2000, Delay: time.Second, Loss: 1},
+		},
+		{
+			name: "update removes all settings",
+			args: args{
+				base:      &define.NetworkShaping{IngressRate: 1000, Delay: time.Second},
+				rateLimit: "ingress=0",
+				delay:     "0",
+			},
+			want: nil,
+		},
+		{
+			name:    "invalid direction",
+			args:    args{rateLimit: "inbound=10mbit"},
+			wantErr: true,
+		},
+		{
+			name:    "missing direction",
+			args:    args{rateLimit: "10mbit"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid unit",
+			args:    args{rateLimit: "ingress=10mb"},
+			wantErr: true,
+		},
+		{
+			name:    "delay too large",
+			args:    args{delay: "2m"},
+			wantErr: true,
+		},
+		{
+			name:    "loss too large",
+			args:    args{loss: "101%"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNetworkShaping(tt.args.base, tt.args.rateLimit, tt.args.delay, tt.args.loss)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseNetworkShaping() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseNetworkShaping() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,7 +17,7 @@ import (
 var (
 	errOptionArg     = errors.New("must provide an argument for option")
 	errNoDest        = errors.New("must set volume destination")
-	errInvalidSyntax = errors.New("incorrect mount format: should be --mount type=<bind|tmpfs|volume>,[src=<host-dir|volume-name>,]target=<ctr-dir>[,options]")
+	errInvalidSyntax = errors.New("incorrect mount format: should be --mount type=<bind|glob|overlay|tmpfs|volume>,[src=<host-dir|volume-name>,]target=<ctr-dir>[,options]")
 )
 
 // Parse all volume-related options in the create config into a set of mounts
@@ -28,13 +28,13 @@ var (
 // TODO: handle options parsing/processing via containers/storage/pkg/mount
 func parseVolumes(volumeFlag, mountFlag, tmpfsFlag []string) ([]spec.Mount, []*specgen.NamedVolume, []*specgen.OverlayVolume, []*specgen.ImageVolume, error) {
 	// Get mounts from the --mounts flag.
-	unifiedMounts, unifiedVolumes, unifiedImageVolumes, err := Mounts(mountFlag)
+	unifiedMounts, unifiedVolumes, overlayVolumes, unifiedImageVolumes, err := Mounts(mountFlag)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
 
 	// Next --volumes flag.
-	volumeMounts, volumeVolumes, overlayVolumes, err := specgen.GenVolumeMounts(volumeFlag)
+	volumeMounts, volumeVolumes, volumeOverlays, err := specgen.GenVolumeMounts(volumeFlag)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -67,6 +67,16 @@ func parseVolumes(volumeFlag, mountFlag, tmpfsFlag []string) ([]spec.Mount, []*s
 		}
 		unifiedVolumes[dest] = volume
 	}
+	for dest, overlay := range volumeOverlays {
+		if vol, ok := overlayVolumes[dest]; ok {
+			if overlay.Source == vol.Source &&
+				specgen.StringSlicesEqual(vol.Options, overlay.Options) {
+				continue
+			}
+			return nil, nil, nil, nil, fmt.Errorf("%v: %w", dest, specgen.ErrDuplicateDest)
+		}
+		overlayVolumes[dest] = overlay
+	}
 	// Now --tmpfs
 	for dest, tmpfs := range tmpfsMounts {
 		if vol, ok := unifiedMounts[dest]; ok {
@@ -127,6 +137,11 @@ func parseVolumes(volumeFlag, mountFlag, tmpfsFlag []string) ([]spec.Mount, []*s
 	}
 	finalOverlayVolume := make([]*specgen.OverlayVolume, 0)
 	for _, volume := range overlayVolumes {
+		absSrc, err := specgen.ConvertWinMountPath(volume.Source)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("getting absolute path of %s: %w", volume.Source, err)
+		}
+		volume.Source = absSrc
 		finalOverlayVolume = append(finalOverlayVolume, volume)
 	}
 	finalImageVolumes := make([]*specgen.ImageVolume, 0, len(unifiedImageVolumes))
@@ -167,73 +182,94 @@ func findMountType(input string) (mountType string, tokens []string, err error)
 }
 
 // Mounts takes user-provided input from the --mount flag and creates OCI
-// spec mounts and Libpod named volumes.
+// spec mounts, Libpod named volumes and overlay volumes.
 // podman run --mount type=bind,src=/etc/resolv.conf,target=/etc/resolv.conf ...
+// podman run --mount type=glob,src=/etc/app/*.conf,target=/conf ...
+// podman run --mount type=overlay,src=/src,target=/src,upperdir=/upper,workdir=/work ...
 // podman run --mount type=tmpfs,target=/dev/shm ...
 // podman run --mount type=volume,source=test-volume, ...
-func Mounts(mountFlag []string) (map[string]spec.Mount, map[string]*specgen.NamedVolume, map[string]*specgen.ImageVolume, error) {
+func Mounts(mountFlag []string) (map[string]spec.Mount, map[string]*specgen.NamedVolume, map[string]*specgen.OverlayVolume, map[string]*specgen.ImageVolume, error) {
 	finalMounts := make(map[string]spec.Mount)
 	finalNamedVolumes := make(map[string]*specgen.NamedVolume)
+	finalOverlayVolumes := make(map[string]*specgen.OverlayVolume)
 	finalImageVolumes := make(map[string]*specgen.ImageVolume)
 
 	for _, mount := range mountFlag {
 		// TODO: Docker defaults to "volume" if no mount type is specified.
 		mountType, tokens, err := findMountType(mount)
 		if err != nil {
-			return nil, nil, nil, err
+			return nil, nil, nil, nil, err
 		}
 		switch mountType {
 		case define.TypeBind:
 			mount, err := getBindMount(tokens)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, err
+			}
+			if _, ok := finalMounts[mount.Destination]; ok {
+				return nil, nil, nil, nil, fmt.Errorf("%v: %w", mount.Destination, specgen.ErrDuplicateDest)
+			}
+			finalMounts[mount.Destination] = mount
+		case define.TypeGlob:
+			mount, err := getGlobMount(tokens)
+			if err != nil {
+				return nil, nil, nil, nil, err
 			}
 			if _, ok := finalMounts[mount.Destination]; ok {
-				return nil, nil, nil, fmt.Errorf("%v: %w", mount.Destination, specgen.ErrDuplicateDest)
+				return nil, nil, nil, nil, fmt.Errorf("%v: %w", mount.Destination, specgen.ErrDuplicateDest)
 			}
 			finalMounts[mount.Destination] = mount
+		case define.TypeOverlay:
+			volume, err := getOverlayVolume(tokens)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+			if _, ok := finalOverlayVolumes[volume.Destination]; ok {
+				return nil, nil, nil, nil, fmt.Errorf("%v: %w", volume.Destination, specgen.ErrDuplicateDest)
+			}
+			finalOverlayVolumes[volume.Destination] = volume
 		case define.TypeTmpfs:
 			mount, err := getTmpfsMount(tokens)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, err
 			}
 			if _, ok := finalMounts[mount.Destination]; ok {
-				return nil, nil, nil, fmt.Errorf("%v: %w", mount.Destination, specgen.ErrDuplicateDest)
+				return nil, nil, nil, nil, fmt.Errorf("%v: %w", mount.Destination, specgen.ErrDuplicateDest)
 			}
 			finalMounts[mount.Destination] = mount
 		case define.TypeDevpts:
 			mount, err := getDevptsMount(tokens)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, err
 			}
 			if _, ok := finalMounts[mount.Destination]; ok {
-				return nil, nil, nil, fmt.Errorf("%v: %w", mount.Destination, specgen.ErrDuplicateDest)
+				return nil, nil, nil, nil, fmt.Errorf("%v: %w", mount.Destination, specgen.ErrDuplicateDest)
 			}
 			finalMounts[mount.Destination] = mount
 		case "image":
 			volume, err := getImageVolume(tokens)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, err
 			}
 			if _, ok := finalImageVolumes[volume.Destination]; ok {
-				return nil, nil, nil, fmt.Errorf("%v: %w", volume.Destination, specgen.ErrDuplicateDest)
+				return nil, nil, nil, nil, fmt.Errorf("%v: %w", volume.Destination, specgen.ErrDuplicateDest)
 			}
 			finalImageVolumes[volume.Destination] = volume
 		case "volume":
 			volume, err := getNamedVolume(tokens)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, err
 			}
 			if _, ok := finalNamedVolumes[volume.Dest]; ok {
-				return nil, nil, nil, fmt.Errorf("%v: %w", volume.Dest, specgen.ErrDuplicateDest)
+				return nil, nil, nil, nil, fmt.Errorf("%v: %w", volume.Dest, specgen.ErrDuplicateDest)
 			}
 			finalNamedVolumes[volume.Dest] = volume
 		default:
-			return nil, nil, nil, fmt.Errorf("invalid filesystem type %q", mountType)
+			return nil, nil, nil, nil, fmt.Errorf("invalid filesystem type %q", mountType)
 		}
 	}
 
-	return finalMounts, finalNamedVolumes, finalImageVolumes, nil
+	return finalMounts, finalNamedVolumes, finalOverlayVolumes, finalImageVolumes, nil
 }
 
 // Parse a single bind mount entry from the --mount flag.
@@ -385,6 +421,98 @@ func getBindMount(args []string) (spec.Mount, error) {
 	return newMount, nil
 }
 
+// Parse a single glob mount entry from the --mount flag. The source is a
+// glob pattern of host paths and the target the directory in the container
+// the matching paths are bind mounted into. The pattern is expanded when the
+// container is created.
+func getGlobMount(args []string) (spec.Mount, error) {
+	setSource := false
+	for _, val := range args {
+		kv := strings.SplitN(val, "=", 2)
+		if (kv[0] == "src" || kv[0] == "source") && len(kv) == 2 && kv[1] != "" {
+			setSource = true
+		}
+	}
+	if !setSource {
+		return spec.Mount{}, errors.New("must set source glob pattern for glob mount")
+	}
+	newMount, err := getBindMount(args)
+	if err != nil {
+		return newMount, err
+	}
+	newMount.Type = define.TypeGlob
+	return newMount, nil
+}
+
+// Parse a single overlay mount entry from the --mount flag. Without upperdir
+// and workdir, writes to the mount are discarded when the container stops.
+func getOverlayVolume(args []string) (*specgen.OverlayVolume, error) {
+	newVolume := new(specgen.OverlayVolume)
+
+	var setOwnership bool
+	upperDir, workDir := "", ""
+	for _, val := range args {
+		kv := strings.SplitN(val, "=", 2)
+		switch kv[0] {
+		case "src", "source":
+			if len(kv) == 1 {
+				return nil, fmt.Errorf("%v: %w", kv[0], errOptionArg)
+			}
+			if len(kv[1]) == 0 {
+				return nil, fmt.Errorf("host directory cannot be empty: %w", errOptionArg)
+			}
+			newVolume.Source = kv[1]
+		case "target", "dst", "destination":
+			if len(kv) == 1 {
+				return nil, fmt.Errorf("%v: %w", kv[0], errOptionArg)
+			}
+			if err := parse.ValidateVolumeCtrDir(kv[1]); err != nil {
+				return nil, err
+			}
+			newVolume.Destination = unixPathClean(kv[1])
+		case "upperdir", "workdir":
+			if len(kv) == 1 || len(kv[1]) == 0 {
+				return nil, fmt.Errorf("%v: %w", kv[0], errOptionArg)
+			}
+			if kv[0] == "upperdir" {
+				upperDir = kv[1]
+			} else {
+				workDir = kv[1]
+			}
+		case "U", "chown":
+			if setOwnership {
+				return nil, fmt.Errorf("cannot pass 'U' or 'chown' option more than once: %w", errOptionArg)
+			}
+			ok, err := validChownFlag(val)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				newVolume.Options = append(newVolume.Options, "U")
+			}
+			setOwnership = true
+		case "consistency":
+			// Often used on MACs and mistakenly on Linux platforms.
+			// Since Docker ignores this option so shall we.
+			continue
+		default:
+			return nil, fmt.Errorf("%s: %w", kv[0], util.ErrBadMntOption)
+		}
+	}
+
+	if len(newVolume.Source)*len(newVolume.Destination) == 0 {
+		return nil, errors.New("must set source and destination for overlay mount")
+	}
+	if (upperDir == "") != (workDir == "") {
+		return nil, errors.New("must set both `upperdir` and `workdir`")
+	}
+	if upperDir != "" {
+		newVolume.Options = append(newVolume.Options, "upperdir="+upperDir, "workdir="+workDir)
+	}
+
+	return newVolume, nil
+}
+
 // Parse a single tmpfs mount entry from the --mount flag
 func getTmpfsMount(args []string) (spec.Mount, error) {
 	newMount := spec.Mount{
@@ -517,13 +645,25 @@ func getDevptsMount(args []string) (spec.Mount, error) {
 func getNamedVolume(args []string) (*specgen.NamedVolume, error) {
 	newVolume := new(specgen.NamedVolume)
 
-	var setDest, setRORW, setSuid, setDev, setExec, setOwnership bool
+	var setDest, setRORW, setSuid, setDev, setExec, setOwnership, setSubPath bool
 
 	for _, val := range args {
 		kv := strings.SplitN(val, "=", 2)
 		switch kv[0] {
 		case "volume-opt":
 			newVolume.Options = append(newVolume.Options, val)
+		case "subpath", "volume-subpath":
+			if setSubPath {
+				return nil, fmt.Errorf("cannot pass 'subpath' option more than once: %w", errOptionArg)
+			}
+			if len(kv) == 1 || kv[1] == "" {
+				return nil, fmt.Errorf("%v: %w", kv[0], errOptionArg)
+			}
+			if err := util.ValidateSubPath(kv[1]); err != nil {
+				return nil, err
+			}
+			newVolume.SubPath = path.Clean(kv[1])
+			setSubPath = true
 		case "ro", "rw":
 			if setRORW {
 				return nil, fmt.Errorf("cannot pass 'ro' and 'rw' options more than once: %w", errOptionArg)
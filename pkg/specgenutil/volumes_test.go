@@ -1,6 +1,10 @@
 package specgenutil
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func Test_validChownFlag(t *testing.T) {
 	type args struct {
@@ -66,3 +70,113 @@ func Test_validChownFlag(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseVolumesSubPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		volumeFlag  []string
+		mountFlag   []string
+		wantSubPath string
+		wantErr     bool
+	}{
+		{
+			name:        "mount subpath",
+			mountFlag:   []string{"type=volume,src=vol,dst=/data,subpath=config/app"},
+			wantSubPath: "config/app",
+		},
+		{
+			name:        "mount volume-subpath is cleaned",
+			mountFlag:   []string{"type=volume,src=vol,dst=/data,volume-subpath=./config//app/"},
+			wantSubPath: "config/app",
+		},
+		{
+			name:        "volume subpath",
+			volumeFlag:  []string{"vol:/data:subpath=config,ro"},
+			wantSubPath: "config",
+		},
+		{
+			name:      "mount subpath outside of volume",
+			mountFlag: []string{"type=volume,src=vol,dst=/data,subpath=config/../../etc"},
+			wantErr:   true,
+		},
+		{
+			name:      "mount absolute subpath",
+			mountFlag: []string{"type=volume,src=vol,dst=/data,subpath=/etc"},
+			wantErr:   true,
+		},
+		{
+			name:      "mount empty subpath",
+			mountFlag: []string{"type=volume,src=vol,dst=/data,subpath="},
+			wantErr:   true,
+		},
+		{
+			name:      "mount subpath twice",
+			mountFlag: []string{"type=volume,src=vol,dst=/data,subpath=a,subpath=b"},
+			wantErr:   true,
+		},
+		{
+			name:      "bind mount subpath",
+			mountFlag: []string{"type=bind,src=/tmp,dst=/data,subpath=a"},
+			wantErr:   true,
+		},
+		{
+			name:       "volume subpath with host directory",
+			volumeFlag: []string{"/tmp:/data:subpath=a"},
+			wantErr:    true,
+		},
+		{
+			name:       "volume subpath outside of volume",
+			volumeFlag: []string{"vol:/data:subpath=.."},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, volumes, _, _, err := parseVolumes(tt.volumeFlag, tt.mountFlag, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if assert.Len(t, volumes, 1) {
+				assert.Equal(t, "vol", volumes[0].Name)
+				assert.Equal(t, "/data", volumes[0].Dest)
+				assert.Equal(t, tt.wantSubPath, volumes[0].SubPath)
+			}
+		})
+	}
+}
+
+func Test_parseVolumesGlobAndOverlay(t *testing.T) {
+	mounts, _, overlays, _, err := parseVolumes(nil, []string{
+		"type=glob,src=/etc/app/*.conf,dst=/conf,ro=true",
+		"type=overlay,src=/src,dst=/data,upperdir=/upper,workdir=/work,U=true",
+	}, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, mounts, 1) {
+		assert.Equal(t, "glob", mounts[0].Type)
+		assert.Equal(t, "/etc/app/*.conf", mounts[0].Source)
+		assert.Equal(t, "/conf", mounts[0].Destination)
+		assert.Contains(t, mounts[0].Options, "ro")
+	}
+	if assert.Len(t, overlays, 1) {
+		assert.Equal(t, "/src", overlays[0].Source)
+		assert.Equal(t, "/data", overlays[0].Destination)
+		assert.Equal(t, []string{"U", "upperdir=/upper", "workdir=/work"}, overlays[0].Options)
+	}
+
+	for _, mountFlag := range []string{
+		"type=glob,dst=/conf",
+		"type=overlay,src=/src",
+		"type=overlay,dst=/data",
+		"type=overlay,src=/src,dst=/data,upperdir=/upper",
+		"type=overlay,src=/src,dst=/data,workdir=",
+		"type=overlay,src=/src,dst=/data,ro",
+	} {
+		_, _, _, _, err := parseVolumes(nil, []string{mountFlag}, nil)
+		assert.Error(t, err, mountFlag)
+	}
+
+	_, _, _, _, err = parseVolumes([]string{"/src:/data:O"}, []string{"type=overlay,src=/other,dst=/data"}, nil)
+	assert.Error(t, err)
+}
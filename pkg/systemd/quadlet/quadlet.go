@@ -2,6 +2,7 @@ package quadlet
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -80,6 +81,7 @@ const (
 	KeyUser                  = "User"
 	KeyVolatileTmp           = "VolatileTmp"
 	KeyVolume                = "Volume"
+	KeyWait                  = "Wait"
 	KeyYaml                  = "Yaml"
 )
 
@@ -159,6 +161,7 @@ var (
 		KeyRemapUID:     true,
 		KeyRemapUIDSize: true,
 		KeyRemapUsers:   true,
+		KeyWait:         true,
 		KeyYaml:         true,
 	}
 )
@@ -787,13 +790,18 @@ func ConvertKube(kube *parser.UnitFile, isUser bool) (*parser.UnitFile, error) {
 
 	execStart := NewPodmanCmdline("kube", "play")
 
-	execStart.add(
-		// Replace any previous container with the same name, not fail
-		"--replace",
+	// Replace any previous container with the same name, not fail
+	execStart.add("--replace")
 
+	// With Wait, podman stays attached as the main process of the service
+	// and tears the pods down once they exit or the service is stopped.
+	wait := kube.LookupBooleanWithDefault(KubeGroup, KeyWait, false)
+	if wait {
+		execStart.add("--wait")
+	} else {
 		// Use a service container
-		"--service-container=true",
-	)
+		execStart.add("--service-container=true")
+	}
 
 	if err := handleUserRemap(kube, KubeGroup, execStart, isUser, false); err != nil {
 		return nil, err
@@ -814,13 +822,25 @@ func ConvertKube(kube *parser.UnitFile, isUser bool) (*parser.UnitFile, error) {
 		return nil, err
 	}
 
+	// A directory is a kustomization, which podman renders itself
+	kustomize := false
+	if info, err := os.Stat(yamlPath); err == nil && info.IsDir() {
+		kustomize = true
+		execStart.add("--kustomize")
+	}
+
 	execStart.add(yamlPath)
 
 	service.AddCmdline(ServiceGroup, "ExecStart", execStart.Args)
 
-	execStop := NewPodmanCmdline("kube", "down")
-	execStop.add(yamlPath)
-	service.AddCmdline(ServiceGroup, "ExecStop", execStop.Args)
+	if !wait {
+		execStop := NewPodmanCmdline("kube", "down")
+		if kustomize {
+			execStop.add("--kustomize")
+		}
+		execStop.add(yamlPath)
+		service.AddCmdline(ServiceGroup, "ExecStop", execStop.Args)
+	}
 
 	return service, nil
 }
@@ -153,6 +153,19 @@ func trustTypeDescription(trustType string) string {
 	return trustDescription
 }
 
+// validTypes are the trust types accepted by AddPolicyEntries.
+var validTypes = []string{"accept", "insecureAcceptAnything", "reject", "signedBy", "sigstoreSigned"}
+
+// IsValidType returns true if trustType is accepted by AddPolicyEntries.
+func IsValidType(trustType string) bool {
+	for _, t := range validTypes {
+		if t == trustType {
+			return true
+		}
+	}
+	return false
+}
+
 // AddPolicyEntriesInput collects some parameters to AddPolicyEntries,
 // primarily so that the callers use named values instead of just strings in a sequence.
 type AddPolicyEntriesInput struct {
@@ -194,3 +194,12 @@ func xNewPRSigstoreSignedKeyPath(t *testing.T, keyPath string, signedIdentity si
 	require.NoError(t, err)
 	return pr
 }
+
+func TestIsValidType(t *testing.T) {
+	for _, valid := range []string{"accept", "insecureAcceptAnything", "reject", "signedBy", "sigstoreSigned"} {
+		assert.True(t, IsValidType(valid), valid)
+	}
+	for _, invalid := range []string{"", "Accept", "GPGKeys", "unknown"} {
+		assert.False(t, IsValidType(invalid), invalid)
+	}
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 )
 
@@ -195,3 +196,18 @@ func ParseDriverOpts(option string) (string, string, error) {
 	}
 	return opt[0], opt[1], nil
 }
+
+// ValidateSubPath checks that the subpath of a volume mount is a relative path
+// which does not leave the volume. Symlinks are not checked here, they must be
+// resolved within the volume when it is mounted.
+func ValidateSubPath(subPath string) error {
+	if filepath.IsAbs(subPath) {
+		return fmt.Errorf("subpath %q must be a relative path: %w", subPath, ErrBadMntOption)
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(subPath), "/") {
+		if elem == ".." {
+			return fmt.Errorf("subpath %q must not contain '..': %w", subPath, ErrBadMntOption)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/containers/podman/v4/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Podman container capture", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman container capture on loopback", func() {
+		setup := podmanTest.Podman([]string{"run", "--name", "test", "-d", ALPINE, "top"})
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(Exit(0))
+
+		pcap := filepath.Join(podmanTest.TempDir, "lo.pcap")
+		capture := podmanTest.Podman([]string{"container", "capture", "-i", "lo", "-c", "2", "--filter", "icmp and host 127.0.0.1", "-w", pcap, "test"})
+		// ping until the capture saw enough packets, it may not have
+		// been started when the first ping is sent
+		for i := 0; i < 10 && capture.ExitCode() == -1; i++ {
+			ping := podmanTest.Podman([]string{"exec", "test", "ping", "-c", "1", "127.0.0.1"})
+			ping.WaitWithDefaultTimeout()
+			Expect(ping).Should(Exit(0))
+			time.Sleep(500 * time.Millisecond)
+		}
+		capture.WaitWithDefaultTimeout()
+		Expect(capture).Should(Exit(0))
+
+		data, err := os.ReadFile(pcap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(data)).To(BeNumerically(">", 24))
+		Expect(binary.LittleEndian.Uint32(data[0:4])).To(Equal(uint32(0xa1b23c4d)))
+		// Ethernet link type
+		Expect(binary.LittleEndian.Uint32(data[20:24])).To(Equal(uint32(1)))
+
+		// count the records, the length of the captured data is at
+		// offset 8 of each record header
+		records := 0
+		for off := 24; off+16 <= len(data); records++ {
+			off += 16 + int(binary.LittleEndian.Uint32(data[off+8:off+12]))
+		}
+		Expect(records).To(Equal(2))
+	})
+
+	It("podman container capture errors", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		pcap := filepath.Join(podmanTest.TempDir, "out.pcap")
+		session = podmanTest.Podman([]string{"container", "capture", "-w", pcap, "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("must be running"))
+
+		session = podmanTest.Podman([]string{"start", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"container", "capture", "--filter", "tcp port http", "-w", pcap, "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring(`invalid port "http"`))
+
+		session = podmanTest.Podman([]string{"container", "capture", "-i", "doesnotexist", "-w", pcap, "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("interface doesnotexist"))
+
+		session = podmanTest.Podman([]string{"run", "--name", "hostnet", "--network", "host", "-d", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"container", "capture", "-w", pcap, "hostnet"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("does not have a network namespace"))
+	})
+})
@@ -245,4 +245,20 @@ var _ = Describe("Podman events", func() {
 		Expect(result.OutputToStringArray()).ToNot(BeEmpty(), "Number of health_status events")
 	})
 
+	It("podman events exec_create generated", func() {
+		session := podmanTest.Podman([]string{"run", "--name", "test-exec", "-d", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		exec := podmanTest.Podman([]string{"exec", "test-exec", "true"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(Exit(0))
+
+		result := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "event=exec_create", "--filter", "container=test-exec", "--since", "1m"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToStringArray()).To(HaveLen(1))
+		Expect(result.OutputToString()).To(ContainSubstring("execCommand=true"))
+	})
+
 })
@@ -95,6 +95,24 @@ var _ = Describe("Podman kube generate", func() {
 		Expect(kube.OutputToString()).To(ContainSubstring("level: s0:c100,c200"))
 	})
 
+	It("podman generate kube on container with lifecycle hooks", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test-hooks", "--hook-post-start", `["/bin/warmup"]`, "--hook-pre-stop", "sleep 5", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "test-hooks"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		pod := new(v1.Pod)
+		err := yaml.Unmarshal(kube.Out.Contents(), pod)
+		Expect(err).ToNot(HaveOccurred())
+		lifecycle := pod.Spec.Containers[0].Lifecycle
+		Expect(lifecycle).ToNot(BeNil())
+		Expect(lifecycle.PostStart.Exec.Command).To(Equal([]string{"/bin/warmup"}))
+		Expect(lifecycle.PreStop.Exec.Command).To(Equal([]string{"/bin/sh", "-c", "sleep 5"}))
+	})
+
 	It("podman generate service kube on container with --security-opt disable", func() {
 		session := podmanTest.Podman([]string{"create", "--name", "test-disable", "--security-opt", "label=disable", "alpine"})
 		session.WaitWithDefaultTimeout()
@@ -962,6 +980,30 @@ var _ = Describe("Podman kube generate", func() {
 		Expect(pod.Spec.DNSConfig.Options[0]).To(HaveField("Value", &s))
 	})
 
+	It("podman generate kube on a container with an image mount", func() {
+		top := podmanTest.Podman([]string{"run", "-dt", "--name", "top", "--mount", "type=image,source=" + ALPINE + ",destination=/data", ALPINE, "top"})
+		top.WaitWithDefaultTimeout()
+		Expect(top).Should(Exit(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "top"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		pod := new(v1.Pod)
+		err := yaml.Unmarshal(kube.Out.Contents(), pod)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(pod.Spec.Volumes).To(HaveLen(1))
+		Expect(pod.Spec.Volumes[0].Name).To(Equal("quay-io-libpod-alpine-latest-e2c93039-image"))
+		Expect(pod.Spec.Volumes[0].Image).ToNot(BeNil())
+		Expect(pod.Spec.Volumes[0].Image.Reference).To(Equal(ALPINE))
+		Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(v1.VolumeMount{
+			Name:      "quay-io-libpod-alpine-latest-e2c93039-image",
+			MountPath: "/data",
+			ReadOnly:  true,
+		}))
+	})
+
 	It("podman generate kube multiple container dns servers and options are cumulative", func() {
 		top1 := podmanTest.Podman([]string{"run", "-dt", "--name", "top1", "--dns", "8.8.8.8", "--dns-search", "foobar.com", ALPINE, "top"})
 		top1.WaitWithDefaultTimeout()
@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/podman/v4/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Podman generate quadlet", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		SkipIfRemote("podman generate quadlet is not supported on the remote client")
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman generate quadlet bogus should fail", func() {
+		session := podmanTest.Podman([]string{"generate", "quadlet", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("foobar does not refer to a container, pod, volume or network"))
+	})
+
+	It("podman generate quadlet container", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "web", "-p", "8080:80", "-e", "FOO=a b", "-v", "systemd-data:/data:ro", "--cap-add", "NET_ADMIN", "--workdir", "/srv", ALPINE, "sleep", "100"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"generate", "quadlet", "web"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		output := session.OutputToString()
+		Expect(output).To(ContainSubstring("# web.container"))
+		Expect(output).To(ContainSubstring("[Container]"))
+		Expect(output).To(ContainSubstring("Image=" + ALPINE))
+		Expect(output).To(ContainSubstring("ContainerName=web"))
+		Expect(output).To(ContainSubstring("PublishPort=8080:80"))
+		Expect(output).To(ContainSubstring(`Environment="FOO=a b"`))
+		Expect(output).To(ContainSubstring("Volume=data.volume:/data:ro"))
+		Expect(output).To(ContainSubstring("AddCapability=CAP_NET_ADMIN"))
+		Expect(output).To(ContainSubstring("PodmanArgs=--workdir /srv"))
+		Expect(output).To(ContainSubstring("Exec=sleep 100"))
+		Expect(output).To(ContainSubstring("WantedBy=default.target"))
+		// Values inherited from the image are not repeated.
+		Expect(output).ToNot(ContainSubstring("PATH="))
+	})
+
+	It("podman generate quadlet volume and network", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--label", "app=web", "--opt", "o=uid=1000", "systemd-data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"generate", "quadlet", "--no-header", "systemd-data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToStringArray()).To(Equal([]string{"[Volume]", "User=1000", "Label=app=web", "[Install]", "WantedBy=default.target"}))
+
+		netName := createNetworkName("systemd-")
+		session = podmanTest.Podman([]string{"network", "create", "--subnet", "10.99.0.0/24", "--ip-range", "10.99.0.128/25", "--internal", netName})
+		session.WaitWithDefaultTimeout()
+		defer podmanTest.removeNetwork(netName)
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"generate", "quadlet", netName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		output := session.OutputToString()
+		Expect(output).To(ContainSubstring("# " + netName[len("systemd-"):] + ".network"))
+		Expect(output).To(ContainSubstring("Subnet=10.99.0.0/24"))
+		Expect(output).To(ContainSubstring("Gateway=10.99.0.1"))
+		Expect(output).To(ContainSubstring("IPRange=10.99.0.128/25"))
+		Expect(output).To(ContainSubstring("Internal=true"))
+	})
+
+	It("podman generate quadlet pod with --files", func() {
+		session := podmanTest.Podman([]string{"create", "--pod", "new:quadletpod", "--name", "quadletctr", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		// The pod's infra container is not supported.
+		session = podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.InfraContainerID}}", "quadletpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		session = podmanTest.Podman([]string{"generate", "quadlet", session.OutputToString()})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+
+		session = podmanTest.Podman([]string{"generate", "quadlet", "--files", "quadletpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		cwd, err := os.Getwd()
+		Expect(err).ToNot(HaveOccurred())
+		kubeFile := filepath.Join(cwd, "quadletpod.kube")
+		yamlFile := filepath.Join(cwd, "quadletpod.yaml")
+		defer os.Remove(kubeFile)
+		defer os.Remove(yamlFile)
+		Expect(session.OutputToStringArray()).To(Equal([]string{kubeFile, yamlFile}))
+
+		content, err := os.ReadFile(kubeFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("Yaml=quadletpod.yaml"))
+		content, err = os.ReadFile(yamlFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("name: quadletctr"))
+	})
+})
@@ -373,4 +373,47 @@ HEALTHCHECK CMD ls -l / 2>&1`, ALPINE)
 		Expect(ps.OutputToStringArray()).To(HaveLen(2))
 		Expect(ps.OutputToString()).To(ContainSubstring("hc"))
 	})
+	It("podman healthcheck run --readiness", func() {
+		SkipIfRemote("readiness checks are not supported on the remote client")
+		ctrName := "readyCtr"
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", ctrName, "--health-readiness-cmd", "cat /ready", "--health-readiness-retries", "1", "--health-readiness-interval", "disable", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		ps := podmanTest.Podman([]string{"ps", "--format", "{{.Ready}}", "--filter", "name=" + ctrName})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps).Should(Exit(0))
+		Expect(ps.OutputToString()).To(Equal("false"))
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "--readiness", ctrName})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(Exit(1))
+		Expect(hc.OutputToString()).To(Equal(define.ReadinessCheckNotReady))
+
+		exec := podmanTest.Podman([]string{"exec", ctrName, "touch", "/ready"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(Exit(0))
+
+		hc = podmanTest.Podman([]string{"healthcheck", "run", "--readiness", ctrName})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(Exit(0))
+
+		ps = podmanTest.Podman([]string{"ps", "--format", "{{.Ready}}", "--filter", "name=" + ctrName})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps).Should(Exit(0))
+		Expect(ps.OutputToString()).To(Equal("true"))
+
+		// The regular healthcheck is not affected.
+		inspect := podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].State.Ready).To(BeTrue())
+		Expect(inspect[0].State.Health.Status).To(BeEmpty())
+
+		exec = podmanTest.Podman([]string{"exec", ctrName, "rm", "/ready"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(Exit(0))
+
+		hc = podmanTest.Podman([]string{"healthcheck", "run", "--readiness", ctrName})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(Exit(1))
+	})
 })
@@ -464,4 +464,36 @@ RUN > file2
 
 	})
 
+	It("podman images last used", func() {
+		podmanTest.AddImageToRWStore(ALPINE)
+
+		result := podmanTest.Podman([]string{"images", "--format", "{{.LastUsed}}", ALPINE})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToString()).To(Equal("Never"))
+
+		result = podmanTest.Podman([]string{"images", "-q", "--filter", "until-used=1h", "--filter", "reference=" + ALPINE})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToStringArray()).To(HaveLen(1))
+
+		session := podmanTest.Podman([]string{"create", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		result = podmanTest.Podman([]string{"images", "--format", "{{.LastUsed}}", ALPINE})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToString()).To(HaveSuffix(" ago"))
+
+		result = podmanTest.Podman([]string{"images", "-q", "--filter", "until-used=1h", "--filter", "reference=" + ALPINE})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToStringArray()).To(BeEmpty())
+
+		result = podmanTest.Podman([]string{"images", "--filter", "until-used=1x"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(125))
+	})
+
 })
@@ -516,7 +516,6 @@ var _ = Describe("Podman logs", func() {
 	})
 
 	It("podman pod logs with container names", func() {
-		SkipIfRemote("Remote can only process one container at a time")
 		SkipIfInContainer("journalctl inside a container doesn't work correctly")
 		podName := "testPod"
 		containerName1 := "container1"
@@ -544,7 +543,6 @@ var _ = Describe("Podman logs", func() {
 		Expect(output).To(ContainElement(ContainSubstring(containerName2)))
 	})
 	It("podman pod logs with different colors", func() {
-		SkipIfRemote("Remote can only process one container at a time")
 		SkipIfInContainer("journalctl inside a container doesn't work correctly")
 		podName := "testPod"
 		containerName1 := "container1"
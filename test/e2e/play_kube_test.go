@@ -3,10 +3,18 @@ package integration
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net"
 	"net/http"
 	"net/url"
@@ -16,6 +24,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 	"text/template"
 	"time"
 
@@ -447,6 +456,131 @@ spec:
           periodSeconds: 1
 `
 
+var lifecyclePodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: lifecycle
+spec:
+  containers:
+  - command:
+    - top
+    name: alpine
+    image: quay.io/libpod/alpine:latest
+    lifecycle:
+      postStart:
+        exec:
+          command:
+          - /bin/sh
+          - -c
+          - echo started > /poststart
+      preStop:
+        httpGet:
+          port: 8080
+          path: /drain
+          httpHeaders:
+          - name: X-Reason
+            value: pod stop
+`
+
+var readinessProbePodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: readiness
+spec:
+  containers:
+  - command:
+    - top
+    name: alpine
+    image: quay.io/libpod/alpine:latest
+    readinessProbe:
+      exec:
+        command:
+        - cat
+        - /ready
+      periodSeconds: 1
+      successThreshold: 1
+      failureThreshold: 2
+`
+
+var projectedVolumePodYaml = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: projected-cm
+data:
+  FOO: foobar
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: projected
+  labels:
+    app: web
+    tier: front
+  annotations:
+    note: hello
+spec:
+  containers:
+  - command:
+    - top
+    name: alpine
+    image: quay.io/libpod/alpine:latest
+    volumeMounts:
+    - name: podinfo
+      mountPath: /podinfo
+    - name: all
+      mountPath: /all
+  volumes:
+  - name: podinfo
+    downwardAPI:
+      items:
+      - path: labels
+        fieldRef:
+          fieldPath: metadata.labels
+      - path: note
+        fieldRef:
+          fieldPath: metadata.annotations['note']
+  - name: all
+    projected:
+      sources:
+      - configMap:
+          name: projected-cm
+          items:
+          - key: FOO
+            path: config/foo
+      - downwardAPI:
+          items:
+          - path: name
+            fieldRef:
+              fieldPath: metadata.name
+          - path: uid
+            fieldRef:
+              fieldPath: metadata.uid
+`
+
+var imageVolumePodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: imagevol
+spec:
+  containers:
+  - command:
+    - top
+    name: alpine
+    image: quay.io/libpod/alpine:latest
+    volumeMounts:
+    - name: data
+      mountPath: /data
+  volumes:
+  - name: data
+    image:
+      reference: quay.io/libpod/alpine:latest
+      pullPolicy: IfNotPresent
+`
+
 var selinuxLabelPodYaml = `
 apiVersion: v1
 kind: Pod
@@ -967,6 +1101,148 @@ spec:
     command: ['sh', '-c', 'ls -l /proc/self/ns/ipc']
 `
 
+var networkPolicyPodYaml = `
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: allow-frontend
+spec:
+  podSelector:
+    matchLabels:
+      app: backend
+  ingress:
+  - from:
+    - podSelector:
+        matchLabels:
+          role: frontend
+    ports:
+    - port: 80
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: backend
+  labels:
+    app: backend
+spec:
+  containers:
+  - name: alpine
+    image: quay.io/libpod/alpine:latest
+    command: ['top']
+`
+
+var networkPolicyOnlyYaml = `
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: deny-all
+spec:
+  podSelector: {}
+  policyTypes:
+  - Ingress
+  - Egress
+`
+
+var servicePodYaml = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: shop
+spec:
+  selector:
+    app: web
+  ports:
+  - port: 80
+    targetPort: http
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  containers:
+  - name: alpine
+    image: quay.io/libpod/alpine:latest
+    command: ['top']
+    ports:
+    - name: http
+      containerPort: 8080
+`
+
+var serviceOnlyYaml = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+  ports:
+  - port: 80
+`
+
+var ingressYaml = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+  annotations:
+    io.podman.annotations.ingress.image: %[1]s
+    io.podman.annotations.ingress.http-port: "%[2]d"
+    io.podman.annotations.ingress.https-port: "%[3]d"
+spec:
+  tls:
+  - hosts:
+    - web.example.com
+    secretName: web-tls
+  rules:
+  - host: web.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web
+            port:
+              name: http
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+  ports:
+  - name: http
+    port: 8080
+    targetPort: 80
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: web-tls
+type: kubernetes.io/tls
+data:
+  tls.crt: %[4]s
+  tls.key: %[5]s
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  containers:
+  - name: nginx
+    image: %[1]s
+`
+
 var (
 	defaultCtrName        = "testCtr"
 	defaultCtrCmd         = []string{"top"}
@@ -1680,6 +1956,57 @@ func testPodWithSecret(podmanTest *PodmanTestIntegration, podYamlString, fileNam
 	Expect(podRm).Should(Exit(0))
 }
 
+// generateTLSCertificate returns a PEM encoded self-signed certificate and key
+// for host, and a pool trusting the certificate.
+func generateTLSCertificate(host string) ([]byte, []byte, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: host},
+		DNSNames:              []string{host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), pool
+}
+
+// ingressStatusCode returns the status code of a request for host, retrying
+// until the proxy of the Ingress accepts connections.
+func ingressStatusCode(client *http.Client, address, host string) int {
+	req, err := http.NewRequest(http.MethodGet, address, nil)
+	Expect(err).ToNot(HaveOccurred())
+	req.Host = host
+
+	interval := 250 * time.Millisecond
+	var resp *http.Response
+	for i := 0; i < 6; i++ {
+		resp, err = client.Do(req)
+		if err == nil {
+			break
+		}
+		time.Sleep(interval)
+		interval *= 2
+	}
+	Expect(err).ToNot(HaveOccurred())
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
 func testHTTPServer(port string, shouldErr bool, expectedResponse string) {
 	address := url.URL{
 		Scheme: "http",
@@ -1990,6 +2317,67 @@ var _ = Describe("Podman play kube", func() {
 		Expect(inspect[0].State.Health).To(HaveField("Status", define.HealthCheckHealthy))
 	})
 
+	It("podman play kube support container lifecycle hooks", func() {
+		ctrName := "lifecycle-alpine"
+		err := writeYaml(lifecyclePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		exec := podmanTest.Podman([]string{"exec", ctrName, "cat", "/poststart"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(Exit(0))
+		Expect(exec.OutputToString()).To(Equal("started"))
+
+		inspect := podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].Config.PreStopHook).To(Equal([]string{"curl", "-f", "-H", "X-Reason: pod stop", "http://localhost:8080/drain"}))
+
+		// The pre-stop hook fails, which does not prevent stopping.
+		stop := podmanTest.Podman([]string{"pod", "stop", "lifecycle"})
+		stop.WaitWithDefaultTimeout()
+		Expect(stop).Should(Exit(0))
+	})
+
+	It("podman play kube support readinessProbe", func() {
+		SkipIfRemote("readiness checks are not supported on the remote client")
+		ctrName := "readiness-alpine"
+		err := writeYaml(readinessProbePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		inspect := podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].Config.Readinesscheck).ToNot(BeNil())
+		Expect(inspect[0].Config.Readinesscheck.Test).To(Equal([]string{"CMD", "cat", "/ready"}))
+		Expect(inspect[0].Config.Readinesscheck.Retries).To(Equal(2))
+		Expect(inspect[0].Config.Healthcheck).To(BeNil())
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "--readiness", ctrName})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc).Should(Exit(1))
+		Expect(hc.OutputToString()).To(Equal("not ready"))
+
+		exec := podmanTest.Podman([]string{"exec", ctrName, "touch", "/ready"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(Exit(0))
+
+		wait := podmanTest.Podman([]string{"wait", "--condition", "ready", ctrName})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait).Should(Exit(0))
+
+		inspect = podmanTest.InspectContainer(ctrName)
+		Expect(inspect[0].State.Ready).To(BeTrue())
+
+		podInspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.Ready}}", "readiness"})
+		podInspect.WaitWithDefaultTimeout()
+		Expect(podInspect).Should(Exit(0))
+		Expect(podInspect.OutputToString()).To(Equal("true"))
+	})
+
 	It("podman play kube fail with nonexistent authfile", func() {
 		err := generateKubeYaml("pod", getPod(), kubeYaml)
 		Expect(err).ToNot(HaveOccurred())
@@ -3251,6 +3639,68 @@ VOLUME %s`, ALPINE, hostPathDir+"/")
 		Expect(kube).Should(Exit(0))
 	})
 
+	It("podman play kube with downwardAPI and projected volumes", func() {
+		ctrName := "projected-alpine"
+		err := writeYaml(projectedVolumePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		podID := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.ID}}", "projected"})
+		podID.WaitWithDefaultTimeout()
+		Expect(podID).Should(Exit(0))
+
+		for file, content := range map[string]string{
+			"/podinfo/labels": "app=\"web\"\ntier=\"front\"",
+			"/podinfo/note":   "hello",
+			"/all/config/foo": "foobar",
+			"/all/name":       "projected",
+			"/all/uid":        podID.OutputToString(),
+		} {
+			cat := podmanTest.Podman([]string{"exec", ctrName, "cat", file})
+			cat.WaitWithDefaultTimeout()
+			Expect(cat).Should(Exit(0))
+			Expect(strings.Join(cat.OutputToStringArray(), "\n")).To(Equal(content))
+		}
+
+		// The volumes are read-only.
+		touch := podmanTest.Podman([]string{"exec", ctrName, "touch", "/all/name"})
+		touch.WaitWithDefaultTimeout()
+		Expect(touch).Should(Exit(1))
+
+		down := podmanTest.Podman([]string{"kube", "down", "--force", kubeYaml})
+		down.WaitWithDefaultTimeout()
+		Expect(down).Should(Exit(0))
+		Expect(down.OutputToString()).To(ContainSubstring("projected-podinfo"))
+		Expect(down.OutputToString()).To(ContainSubstring("projected-all"))
+	})
+
+	It("podman play kube with image volume", func() {
+		ctrName := "imagevol-alpine"
+		err := writeYaml(imageVolumePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{range .Mounts}}{{.Type}} {{.Destination}} {{.RW}}{{end}}", ctrName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("image /data false"))
+
+		// The image content is mounted read-only.
+		cat := podmanTest.Podman([]string{"exec", ctrName, "cat", "/data/etc/alpine-release"})
+		cat.WaitWithDefaultTimeout()
+		Expect(cat).Should(Exit(0))
+
+		touch := podmanTest.Podman([]string{"exec", ctrName, "touch", "/data/foo"})
+		touch.WaitWithDefaultTimeout()
+		Expect(touch).Should(Exit(1))
+	})
+
 	It("podman play kube with emptyDir volume", func() {
 		podName := "test-pod"
 		ctrName1 := "vol-test-ctr"
@@ -3388,6 +3838,61 @@ MemoryReservation: {{ .HostConfig.MemoryReservation }}`})
 
 	})
 
+	It("podman play kube sets the QoS class and translates resource requests", func() {
+		SkipIfContainerized("Resource limits require a running systemd")
+		SkipIfRootless("CPU limits and lowering oom_score_adj require root")
+		podmanTest.CgroupManager = "systemd"
+
+		pod := getPod(withCtr(getCtr(
+			withCPURequest("500m"),
+			withCPULimit("500m"),
+			withMemoryRequest("20000000"),
+			withMemoryLimit("20000000"),
+		)))
+		err := generateKubeYaml("pod", pod, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		podInspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.QOSClass}}", pod.Name})
+		podInspect.WaitWithDefaultTimeout()
+		Expect(podInspect).Should(Exit(0))
+		Expect(podInspect.OutputToString()).To(Equal("Guaranteed"))
+
+		inspect := podmanTest.Podman([]string{"inspect", getCtrNameInPod(pod), "--format", "{{.HostConfig.CpuShares}} {{.HostConfig.MemoryReservation}} {{.HostConfig.OomScoreAdj}}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("512 20000000 -997"))
+
+		// kube generate emits the requests back
+		generate := podmanTest.Podman([]string{"kube", "generate", pod.Name})
+		generate.WaitWithDefaultTimeout()
+		Expect(generate).Should(Exit(0))
+
+		Expect(generate.OutputToString()).To(ContainSubstring("requests: cpu: 500m memory: 20M"))
+
+		// a pod without any resources is BestEffort
+		bestEffort := getPod(withPodName("besteffort"), withCtr(getCtr()))
+		err = generateKubeYaml("pod", bestEffort, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube = podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		podInspect = podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.QOSClass}}", bestEffort.Name})
+		podInspect.WaitWithDefaultTimeout()
+		Expect(podInspect).Should(Exit(0))
+		Expect(podInspect.OutputToString()).To(Equal("BestEffort"))
+
+		inspect = podmanTest.Podman([]string{"inspect", getCtrNameInPod(bestEffort), "--format", "{{.HostConfig.OomScoreAdj}}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("1000"))
+	})
+
 	It("podman play kube reports invalid image name", func() {
 		invalidImageName := "./myimage"
 
@@ -3827,6 +4332,85 @@ invalid kube kind
 		Expect(teardown).Should(Exit(125))
 	})
 
+	It("podman play kube --wait", func() {
+		ctr := getCtr(withCmd([]string{"sh", "-c", "echo hello from kube; exit 3"}), withArg(nil))
+		pod := getPod(withPodName("wait"), withRestartPolicy("Never"), withCtr(ctr))
+		err := generateKubeYaml("pod", pod, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", "--wait", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(3))
+		Expect(kube.OutputToString()).To(ContainSubstring("hello from kube"))
+		Expect(kube.OutputToString()).To(ContainSubstring("Pods removed:"))
+
+		exists := podmanTest.Podman([]string{"pod", "exists", "wait"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).Should(Exit(1))
+	})
+
+	It("podman play kube --wait tears down pods on SIGTERM", func() {
+		pod := getPod(withPodName("wait"))
+		err := generateKubeYaml("pod", pod, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", "--wait", kubeYaml})
+		// Wait for the infra and the top container to run.
+		Eventually(podmanTest.NumberOfContainersRunning, 30*time.Second, time.Second).Should(Equal(2))
+
+		kube.Signal(syscall.SIGTERM)
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+		Expect(kube.OutputToString()).To(ContainSubstring("Pods removed:"))
+
+		exists := podmanTest.Podman([]string{"pod", "exists", "wait"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).Should(Exit(1))
+	})
+
+	It("podman play kube --wait does not work with --down", func() {
+		kube := podmanTest.Podman([]string{"kube", "play", "--wait", "--down", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("--wait and --down cannot be used together"))
+	})
+
+	It("podman play kube --kustomize", func() {
+		pod := getPod(withPodName("app"), withLabel("app", "app"))
+		base := filepath.Join(podmanTest.TempDir, "base")
+		overlay := filepath.Join(podmanTest.TempDir, "overlay")
+		Expect(os.MkdirAll(base, 0755)).To(Succeed())
+		Expect(os.MkdirAll(overlay, 0755)).To(Succeed())
+		err := generateKubeYaml("pod", pod, filepath.Join(base, "pod.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		err = writeYaml("resources:\n- pod.yaml\nconfigMapGenerator:\n- name: settings\n  literals:\n  - MODE=base\n", filepath.Join(base, "kustomization.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		err = writeYaml("resources:\n- ../base\nnamePrefix: prod-\ncommonLabels:\n  env: prod\n", filepath.Join(overlay, "kustomization.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", overlay})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("is a directory, use --kustomize"))
+
+		kube = podmanTest.Podman([]string{"kube", "play", "-k", overlay})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.Labels.app}} {{.Labels.env}}", "prod-app"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("app prod"))
+
+		down := podmanTest.Podman([]string{"kube", "down", "-k", overlay})
+		down.WaitWithDefaultTimeout()
+		Expect(down).Should(Exit(0))
+
+		exists := podmanTest.Podman([]string{"pod", "exists", "prod-app"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).Should(Exit(1))
+	})
+
 	It("podman play kube teardown with volume without force delete", func() {
 
 		volName := RandomString(12)
@@ -5032,4 +5616,127 @@ spec:
 		Expect(inspect.OutputToString()).To(ContainSubstring("\"Aliases\": [ \"" + ctrName + "\""))
 	})
 
+	It("podman play kube with NetworkPolicy", func() {
+		SkipIfRootless("rootless pods use slirp4netns by default, network policies require a bridge network")
+		err := writeYaml(networkPolicyPodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.NetworkPolicy.Names}} {{.NetworkPolicy.IsolateIngress}} {{.NetworkPolicy.IsolateEgress}}", "backend"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("[allow-frontend] true false"))
+
+		down := podmanTest.Podman([]string{"kube", "down", kubeYaml})
+		down.WaitWithDefaultTimeout()
+		Expect(down).Should(Exit(0))
+	})
+
+	It("podman play kube with only a NetworkPolicy should fail", func() {
+		err := writeYaml(networkPolicyOnlyYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("NetworkPolicies in podman are not a standalone object"))
+	})
+
+	It("podman play kube with Service", func() {
+		SkipIfRootless("rootless pods use slirp4netns by default, services require a bridge network")
+		err := writeYaml(servicePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{range .ServicePorts}}{{.Service}} {{.Protocol}} {{.Port}} {{.TargetPort}}{{end}}", "web"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("web tcp 80 8080"))
+
+		infra := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.InfraContainerID}}", "web"})
+		infra.WaitWithDefaultTimeout()
+		Expect(infra).Should(Exit(0))
+
+		aliases := podmanTest.Podman([]string{"inspect", "--format", "{{range .NetworkSettings.Networks}}{{.Aliases}}{{end}}", infra.OutputToString()})
+		aliases.WaitWithDefaultTimeout()
+		Expect(aliases).Should(Exit(0))
+		Expect(aliases.OutputToString()).To(ContainSubstring("web web.shop web.shop.svc web.shop.svc.cluster.local"))
+
+		down := podmanTest.Podman([]string{"kube", "down", kubeYaml})
+		down.WaitWithDefaultTimeout()
+		Expect(down).Should(Exit(0))
+	})
+
+	It("podman play kube with only a Service should fail", func() {
+		err := writeYaml(serviceOnlyYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("Services in podman are not a standalone object"))
+	})
+
+	It("podman play kube with Ingress", func() {
+		SkipIfRootless("rootless pods use slirp4netns by default, services require a bridge network")
+		cert, key, certPool := generateTLSCertificate("web.example.com")
+		httpPort, httpsPort := GetPort(), GetPort()
+		err := writeYaml(fmt.Sprintf(ingressYaml, NGINX_IMAGE, httpPort, httpsPort,
+			base64.StdEncoding.EncodeToString(cert), base64.StdEncoding.EncodeToString(key)), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		// The proxy routes requests for the host of the rule to the
+		// Service, other hosts are not found.
+		curl := podmanTest.Podman([]string{"exec", "web-ingress-proxy", "curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", "-H", "Host: web.example.com", "http://localhost/"})
+		curl.WaitWithDefaultTimeout()
+		Expect(curl).Should(Exit(0))
+		Expect(curl.OutputToString()).To(Equal("200"))
+
+		curl = podmanTest.Podman([]string{"exec", "web-ingress-proxy", "curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", "http://localhost/"})
+		curl.WaitWithDefaultTimeout()
+		Expect(curl).Should(Exit(0))
+		Expect(curl.OutputToString()).To(Equal("404"))
+
+		// The same through the published host ports, with TLS
+		// terminated by the proxy using the certificate of the Secret.
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:    certPool,
+			ServerName: "web.example.com",
+			MinVersion: tls.VersionTLS12,
+		}}}
+		httpURL := fmt.Sprintf("http://localhost:%d/", httpPort)
+		httpsURL := fmt.Sprintf("https://localhost:%d/", httpsPort)
+		Expect(ingressStatusCode(client, httpURL, "web.example.com")).To(Equal(http.StatusOK))
+		Expect(ingressStatusCode(client, httpURL, "other.example.com")).To(Equal(http.StatusNotFound))
+		Expect(ingressStatusCode(client, httpsURL, "web.example.com")).To(Equal(http.StatusOK))
+
+		down := podmanTest.Podman([]string{"kube", "down", "--force", kubeYaml})
+		down.WaitWithDefaultTimeout()
+		Expect(down).Should(Exit(0))
+		Expect(down.OutputToString()).To(ContainSubstring("web-ingress-config"))
+
+		exists := podmanTest.Podman([]string{"pod", "exists", "web-ingress"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).Should(Exit(1))
+	})
+
+	It("podman play kube with only an Ingress should fail", func() {
+		err := writeYaml(strings.Split(fmt.Sprintf(ingressYaml, NGINX_IMAGE, GetPort(), GetPort(), "", ""), "---")[0], kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("Ingresses in podman are not a standalone object"))
+	})
 })
@@ -150,4 +150,105 @@ var _ = Describe("Podman port", func() {
 		Expect(result2).Should(Exit(0))
 		Expect(result2.OutputToStringArray()).To(ContainElement(HavePrefix("0.0.0.0:5001")))
 	})
+
+	It("podman port add and rm", func() {
+		lock1 := GetPortLock("5002")
+		defer lock1.Unlock()
+		lock2 := GetPortLock("5003")
+		defer lock2.Unlock()
+
+		setup := podmanTest.Podman([]string{"create", "--name", "test", "--network", "bridge", "-p", "5002:5002", ALPINE, "top"})
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(Exit(0))
+
+		add := podmanTest.Podman([]string{"port", "add", "test", "5003:5003"})
+		add.WaitWithDefaultTimeout()
+		Expect(add).Should(Exit(0))
+		Expect(add.OutputToString()).To(Equal("5003/tcp -> 0.0.0.0:5003"))
+
+		// the host port is already published
+		add = podmanTest.Podman([]string{"port", "add", "test", "5003:80"})
+		add.WaitWithDefaultTimeout()
+		Expect(add).Should(Exit(125))
+		Expect(add.ErrorToString()).To(ContainSubstring("host port 5003/tcp is already published"))
+
+		rm := podmanTest.Podman([]string{"container", "port", "rm", "test", "5002"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm).Should(Exit(0))
+
+		rm = podmanTest.Podman([]string{"port", "rm", "test", "5002"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm).Should(Exit(125))
+		Expect(rm.ErrorToString()).To(ContainSubstring("port 5002/tcp is not published"))
+
+		start := podmanTest.Podman([]string{"start", "test"})
+		start.WaitWithDefaultTimeout()
+		Expect(start).Should(Exit(0))
+
+		result := podmanTest.Podman([]string{"port", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToStringArray()).To(Equal([]string{"5003/tcp -> 0.0.0.0:5003"}))
+
+		ipFormat := "{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}"
+		inspect := podmanTest.Podman([]string{"inspect", "--format", ipFormat, "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		ip := inspect.OutputToString()
+
+		// the ports of a running container are forwarded right away
+		add = podmanTest.Podman([]string{"port", "add", "test", "5002:5002"})
+		add.WaitWithDefaultTimeout()
+		Expect(add).Should(Exit(0))
+
+		result = podmanTest.Podman([]string{"port", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToStringArray()).To(ConsistOf("5002/tcp -> 0.0.0.0:5002", "5003/tcp -> 0.0.0.0:5003"))
+
+		// the container keeps its address
+		inspect = podmanTest.Podman([]string{"inspect", "--format", ipFormat, "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal(ip))
+
+		rm = podmanTest.Podman([]string{"port", "rm", "test", "5002"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm).Should(Exit(0))
+
+		// the ports are kept after a restart
+		restart := podmanTest.Podman([]string{"restart", "test"})
+		restart.WaitWithDefaultTimeout()
+		Expect(restart).Should(Exit(0))
+
+		result = podmanTest.Podman([]string{"port", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToStringArray()).To(Equal([]string{"5003/tcp -> 0.0.0.0:5003"}))
+	})
+
+	It("podman port of a container named like a subcommand", func() {
+		lock := GetPortLock("5004")
+		defer lock.Unlock()
+
+		setup := podmanTest.Podman([]string{"run", "--name", "add", "-dt", "-p", "5004:5004", ALPINE, "top"})
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(Exit(0))
+
+		// the subcommand is never resolved to the container
+		result := podmanTest.Podman([]string{"port", "add"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(125))
+		Expect(result.ErrorToString()).To(ContainSubstring(`requires at least 2 arg(s), only received 0, use "podman port /add" to list the ports of a container named "add"`))
+
+		result = podmanTest.Podman([]string{"port", "/add"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToStringArray()).To(Equal([]string{"5004/tcp -> 0.0.0.0:5004"}))
+
+		result = podmanTest.Podman([]string{"container", "port", "/add", "5004"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(Exit(0))
+		Expect(result.OutputToString()).To(Equal("0.0.0.0:5004"))
+	})
 })
@@ -66,6 +66,19 @@ var _ = Describe("Podman prune", func() {
 		Expect(podmanTest.NumberOfContainers()).To(Equal(1))
 	})
 
+	It("podman container prune --dry-run", func() {
+		session := podmanTest.Podman([]string{"create", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		cid := session.OutputToString()
+
+		prune := podmanTest.Podman([]string{"container", "prune", "--dry-run"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(0))
+		Expect(prune.OutputToStringArray()).To(Equal([]string{cid}))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(1))
+	})
+
 	It("podman container prune after create containers", func() {
 		create := podmanTest.Podman([]string{"create", "--name", "test", BB})
 		create.WaitWithDefaultTimeout()
@@ -213,6 +226,73 @@ var _ = Describe("Podman prune", func() {
 		Expect(images.OutputToStringArray()).To(HaveLen(len(CACHE_IMAGES)))
 	})
 
+	It("podman image prune --until-used and --keep-storage", func() {
+		podmanTest.AddImageToRWStore(ALPINE)
+		podmanTest.AddImageToRWStore(BB)
+
+		session := podmanTest.Podman([]string{"create", BB, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		session = podmanTest.Podman([]string{"rm", "-a"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		// The images use less storage than the target.
+		prune := podmanTest.Podman([]string{"image", "prune", "-f", "--keep-storage", "1000G"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(0))
+		Expect(prune.OutputToString()).To(BeEmpty())
+
+		// Only alpine was not used recently.
+		prune = podmanTest.Podman([]string{"image", "prune", "-f", "--until-used", "1h"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(0))
+		Expect(prune.OutputToStringArray()).To(HaveLen(1))
+
+		images := podmanTest.Podman([]string{"images", "-q", "--no-trunc", "--filter", "readonly=false"})
+		images.WaitWithDefaultTimeout()
+		Expect(images).Should(Exit(0))
+		inspect := podmanTest.Podman([]string{"image", "inspect", "--format", "{{.ID}}", BB})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(images.OutputToStringArray()).To(Equal([]string{"sha256:" + inspect.OutputToString()}))
+
+		// A never used image counts as used when it was tagged.
+		podmanTest.AddImageToRWStore(ALPINE)
+		session = podmanTest.Podman([]string{"tag", ALPINE, "localhost/recent:latest"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		prune = podmanTest.Podman([]string{"image", "prune", "-f", "--until-used", "1h"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(0))
+		Expect(prune.OutputToString()).To(BeEmpty())
+
+		prune = podmanTest.Podman([]string{"image", "prune", "-f", "--keep-storage", "-1"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(125))
+	})
+
+	It("podman image prune --dry-run", func() {
+		podmanTest.AddImageToRWStore(BB)
+		inspect := podmanTest.Podman([]string{"image", "inspect", "--format", "{{.ID}}", BB})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+
+		prune := podmanTest.Podman([]string{"image", "prune", "-a", "--dry-run", "--filter", "reference=" + BB})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(0))
+		Expect(prune.OutputToStringArray()).To(Equal([]string{inspect.OutputToString()}))
+
+		prune = podmanTest.Podman([]string{"image", "prune", "--dry-run", "--keep-storage", "1000G"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(0))
+		Expect(prune.OutputToString()).To(BeEmpty())
+
+		session := podmanTest.Podman([]string{"image", "exists", BB})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+	})
+
 	It("podman system image prune unused images", func() {
 		podmanTest.AddImageToRWStore(ALPINE)
 		podmanTest.BuildImage(pruneImage, "alpine_bash:latest", "true")
@@ -524,6 +604,89 @@ var _ = Describe("Podman prune", func() {
 		podmanTest.Cleanup()
 	})
 
+	It("podman system prune --dry-run", func() {
+		session := podmanTest.Podman([]string{"pod", "create"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		podid := session.OutputToString()
+
+		for _, cmd := range []string{"start", "stop"} {
+			session = podmanTest.Podman([]string{"pod", cmd, podid})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(Exit(0))
+		}
+
+		session = podmanTest.Podman([]string{"create", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		cid := session.OutputToString()
+		numContainers := podmanTest.NumberOfContainers()
+
+		session = podmanTest.Podman([]string{"network", "create", "dryrunnet"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"volume", "create", "dryrunvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		prune := podmanTest.Podman([]string{"system", "prune", "--volumes", "--dry-run"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(0))
+		output := prune.OutputToString()
+		Expect(output).To(ContainSubstring("Would Delete Containers"))
+		Expect(output).To(ContainSubstring(cid))
+		Expect(output).To(ContainSubstring(podid))
+		Expect(output).To(ContainSubstring("dryrunnet"))
+		Expect(output).To(ContainSubstring("dryrunvol"))
+		Expect(output).To(ContainSubstring("Total reclaimable space"))
+		Expect(output).ToNot(ContainSubstring("Deleted"))
+
+		Expect(podmanTest.NumberOfContainers()).To(Equal(numContainers))
+		Expect(podmanTest.NumberOfPods()).To(Equal(1))
+		for _, args := range [][]string{{"network", "exists", "dryrunnet"}, {"volume", "exists", "dryrunvol"}} {
+			session = podmanTest.Podman(args)
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(Exit(0))
+		}
+
+		prune = podmanTest.Podman([]string{"system", "prune", "--external", "--dry-run"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(125))
+	})
+
+	It("podman system prune --all --dry-run reports data held by pruned containers", func() {
+		podmanTest.AddImageToRWStore(BB)
+		inspect := podmanTest.Podman([]string{"image", "inspect", "--format", "{{.ID}}", BB})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		imageID := inspect.OutputToString()
+
+		session := podmanTest.Podman([]string{"volume", "create", "dryrunvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"create", "-v", "dryrunvol:/data", BB, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		cid := session.OutputToString()
+
+		// the image and the volume are only unused once the container is removed
+		prune := podmanTest.Podman([]string{"system", "prune", "--all", "--volumes", "--dry-run"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(Exit(0))
+		output := prune.OutputToString()
+		Expect(output).To(ContainSubstring(cid))
+		Expect(output).To(ContainSubstring(imageID))
+		Expect(output).To(ContainSubstring("dryrunvol"))
+
+		for _, args := range [][]string{{"container", "exists", cid}, {"image", "exists", BB}, {"volume", "exists", "dryrunvol"}} {
+			session = podmanTest.Podman(args)
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(Exit(0))
+		}
+	})
+
 	It("podman system prune --all --external fails", func() {
 		prune := podmanTest.Podman([]string{"system", "prune", "--all", "--enternal"})
 		prune.WaitWithDefaultTimeout()
@@ -499,6 +499,8 @@ var _ = Describe("quadlet system generator", func() {
 		Entry("Kube - ConfigMap", "configmap.kube"),
 		Entry("Kube - Publish IPv4 ports", "ports.kube"),
 		Entry("Kube - Publish IPv6 ports", "ports_ipv6.kube"),
+		Entry("Kube - Wait", "wait.kube"),
+		Entry("Kube - Kustomize", "kustomize.kube"),
 
 		Entry("Network - Basic", "basic.network"),
 		Entry("Network - Label", "label.network"),
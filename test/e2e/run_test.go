@@ -1358,6 +1358,53 @@ USER mail`, BB)
 		Expect(session.ErrorToString()).To(ContainSubstring("healthcheck-start-period must be 0 seconds or greater"))
 	})
 
+	It("podman run with lifecycle hooks", func() {
+		hookDir := filepath.Join(podmanTest.TempDir, "hooks")
+		err := os.MkdirAll(hookDir, 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "hooks", "-v", hookDir + ":/hooks:z",
+			"--hook-post-start", "echo started > /hooks/post-start",
+			"--hook-pre-stop", `["/bin/touch", "/hooks/pre-stop"]`, ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(filepath.Join(hookDir, "post-start")).To(BeARegularFile())
+		Expect(filepath.Join(hookDir, "pre-stop")).ToNot(BeAnExistingFile())
+
+		session = podmanTest.Podman([]string{"stop", "hooks"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(filepath.Join(hookDir, "pre-stop")).To(BeARegularFile())
+
+		// A failing post-start hook stops the container again.
+		session = podmanTest.Podman([]string{"run", "-d", "--name", "hookfail", "--hook-post-start", "exit 3", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.ErrorToString()).To(ContainSubstring("exited with code 3"))
+		inspect := podmanTest.InspectContainer("hookfail")
+		Expect(inspect[0].State.Running).To(BeFalse())
+
+		// Hooks are bounded by the stop timeout.
+		session = podmanTest.Podman([]string{"run", "-d", "--name", "hookslow", "--stop-timeout", "2", "--hook-pre-stop", "sleep 100", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		start := time.Now()
+		session = podmanTest.Podman([]string{"stop", "hookslow"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(time.Since(start)).To(BeNumerically("<", 30*time.Second))
+		inspect = podmanTest.InspectContainer("hookslow")
+		Expect(inspect[0].State.Running).To(BeFalse())
+
+		session = podmanTest.Podman([]string{"run", "-d", "--name", "hookstuck", "--stop-timeout", "2", "--hook-post-start", "sleep 100", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.ErrorToString()).To(ContainSubstring("did not complete within 2s"))
+		inspect = podmanTest.InspectContainer("hookstuck")
+		Expect(inspect[0].State.Running).To(BeFalse())
+	})
+
 	It("podman run with --add-host and --no-hosts fails", func() {
 		session := podmanTest.Podman([]string{"run", "-dt", "--add-host", "test1:127.0.0.1", "--no-hosts", ALPINE, "top"})
 		session.WaitWithDefaultTimeout()
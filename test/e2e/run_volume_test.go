@@ -996,4 +996,132 @@ USER testuser`, fedoraMinimal)
 		Expect(run).Should(Exit(0))
 		Expect(run.OutputToString()).Should(ContainSubstring(strings.TrimLeft("/vol/", f.Name())))
 	})
+
+	It("podman run with volume subpath", func() {
+		volName := "subpathvol"
+		session := podmanTest.Podman([]string{"volume", "create", volName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/data", ALPINE, "sh", "-c", "mkdir -p /data/config/app && echo hello > /data/config/app/file && ln -s / /data/escape"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", "type=volume,src=" + volName + ",dst=/etc/app,subpath=config/app", ALPINE, "cat", "/etc/app/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(Equal("hello"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/etc/app:subpath=config,ro", ALPINE, "cat", "/etc/app/app/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(Equal("hello"))
+
+		// the symlink is resolved inside of the volume, so the subpath
+		// is the (empty) root directory of the volume and not of the host
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/mnt:subpath=escape", ALPINE, "ls", "/mnt"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(ContainSubstring("config"))
+		Expect(session.OutputToString()).ToNot(ContainSubstring("etc"))
+
+		// missing directories are created
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/mnt:subpath=new/dir", ALPINE, "touch", "/mnt/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/data", ALPINE, "ls", "/data/new/dir/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/mnt:subpath=../x", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("must not contain '..'"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", podmanTest.TempDir + ":/mnt:subpath=x", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("subpath is only supported for named volumes"))
+	})
+
+	It("podman run with glob mount", func() {
+		confDir := filepath.Join(tempdir, "conf")
+		err := os.Mkdir(confDir, 0755)
+		Expect(err).ToNot(HaveOccurred())
+		for _, name := range []string{"a.conf", "b.conf", "c.txt"} {
+			err = os.WriteFile(filepath.Join(confDir, name), []byte(name), 0644)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		mount := "type=glob,src=" + confDir + "/*.conf,dst=/conf,ro,relabel=shared"
+		session := podmanTest.Podman([]string{"create", "--name", "globctr", "--mount", mount, ALPINE, "ls", "/conf"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"start", "-a", "globctr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToStringArray()).To(Equal([]string{"a.conf", "b.conf"}))
+
+		inspect := podmanTest.Podman([]string{"container", "inspect", "--format", "{{range .Mounts}}{{.Type}}:{{.Source}}:{{.Destination}}:{{.RW}} {{end}}", "globctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring("bind:" + filepath.Join(confDir, "a.conf") + ":/conf/a.conf:false"))
+		Expect(inspect.OutputToString()).To(ContainSubstring("bind:" + filepath.Join(confDir, "b.conf") + ":/conf/b.conf:false"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", "type=glob,src=" + confDir + "/*.none,dst=/conf", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("does not match any path"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", "type=glob,src=conf/*.conf,dst=/conf", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("must be an absolute path"))
+	})
+
+	It("podman run with overlay mount and upperdir", func() {
+		SkipIfRemote("Overlay volumes only work locally")
+		if os.Getenv("container") != "" {
+			Skip("Overlay mounts not supported when running in a container")
+		}
+		if isRootless() {
+			if _, err := exec.LookPath("fuse-overlayfs"); err != nil {
+				Skip("Fuse-Overlayfs required for rootless overlay mount test")
+			}
+		}
+
+		lowerDir := filepath.Join(tempdir, "lower")
+		err := os.Mkdir(lowerDir, 0755)
+		Expect(err).ToNot(HaveOccurred())
+		// upperdir and workdir are created by podman
+		upperDir := filepath.Join(tempdir, "persist", "upper")
+		workDir := filepath.Join(tempdir, "persist", "work")
+
+		mount := fmt.Sprintf("type=overlay,src=%s,dst=/data,upperdir=%s,workdir=%s", lowerDir, upperDir, workDir)
+		session := podmanTest.Podman([]string{"create", "--name", "overlayctr", "--mount", mount, ALPINE, "sh", "-c", "echo hello >> /data/file; cat /data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"start", "-a", "overlayctr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		session = podmanTest.Podman([]string{"start", "-a", "overlayctr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		// the write of the first run is kept in the upper directory
+		Expect(session.OutputToStringArray()).To(Equal([]string{"hello", "hello"}))
+		Expect(filepath.Join(upperDir, "file")).To(BeARegularFile())
+		Expect(filepath.Join(lowerDir, "file")).ToNot(BeAnExistingFile())
+
+		inspect := podmanTest.Podman([]string{"container", "inspect", "--format", "{{range .Mounts}}{{.Type}}:{{.Source}}:{{.Destination}}:{{.Options}}{{end}}", "overlayctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal(fmt.Sprintf("overlay:%s:/data:[upperdir=%s workdir=%s]", lowerDir, upperDir, workDir)))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", "type=overlay,src=" + lowerDir + ",dst=/data,upperdir=" + upperDir, ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("must set both `upperdir` and `workdir`"))
+	})
 })
@@ -0,0 +1,120 @@
+package integration
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/containers/podman/v4/libpod/define"
+	. "github.com/containers/podman/v4/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Podman system rootless-netns", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+	BeforeEach(func() {
+		if !isRootless() {
+			Skip("The rootless network namespace is only used rootless")
+		}
+		SkipIfRemote("podman-remote system rootless-netns is not supported")
+
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+	})
+
+	It("podman system rootless-netns inspect, exec and cleanup", func() {
+		session := podmanTest.Podman([]string{"system", "rootless-netns", "cleanup"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"system", "rootless-netns", "inspect", "--format", "{{.Exists}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(Equal("false"))
+
+		session = podmanTest.Podman([]string{"system", "rootless-netns", "exec", "ip", "addr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("rootless network namespace does not exist"))
+
+		ctr := podmanTest.Podman([]string{"run", "-d", "--name", "test", "--network", "bridge", "-p", "8080:80", ALPINE, "top"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"system", "rootless-netns", "inspect"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		var info define.RootlessNetNSInfo
+		err := json.Unmarshal(session.Out.Contents(), &info)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Exists).To(BeTrue())
+		Expect(info.Helper).ToNot(BeNil())
+		Expect(info.Helper.Name).To(Equal("slirp4netns"))
+		Expect(info.Helper.Running).To(BeTrue())
+		names := []string{}
+		for _, iface := range info.Interfaces {
+			names = append(names, iface.Name)
+		}
+		Expect(names).To(ContainElement("tap0"))
+		Expect(info.Routes).ToNot(BeEmpty())
+		Expect(info.Containers).To(HaveLen(1))
+		Expect(info.Containers[0].Name).To(Equal("test"))
+		Expect(info.Containers[0].Networks).To(Equal([]string{"podman"}))
+		Expect(info.Containers[0].Ports).To(HaveLen(1))
+		Expect(info.Containers[0].Ports[0].HostPort).To(BeEquivalentTo(8080))
+
+		session = podmanTest.Podman([]string{"system", "rootless-netns", "exec", "ip", "addr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(ContainSubstring("tap0"))
+
+		session = podmanTest.Podman([]string{"system", "rootless-netns", "exec", "false"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(1))
+
+		session = podmanTest.Podman([]string{"system", "rootless-netns", "cleanup"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("rootless network namespace is used by containers test"))
+
+		session = podmanTest.Podman([]string{"rm", "-f", "-t0", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		// keep the namespace around with unshare, it is not cleaned up
+		// when no container uses it
+		session = podmanTest.Podman([]string{"unshare", "--rootless-netns", "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"system", "rootless-netns", "cleanup"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"system", "rootless-netns", "inspect", "--format", "{{.Exists}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(Equal("false"))
+	})
+
+	It("podman system rootless-netns inspect --format", func() {
+		session := podmanTest.Podman([]string{"system", "rootless-netns", "inspect", "--format", "{{.Bogus}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+	})
+})
@@ -238,4 +238,40 @@ var _ = Describe("Podman update", func() {
 		Expect(session).Should(Exit(0))
 		Expect(session.OutputToString()).Should(ContainSubstring("500000"))
 	})
+
+	It("podman update network shaping", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--network-delay", "100ms", "--network-rate-limit", "egress=5mbit", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		ctrID := session.OutputToString()
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.NetworkShaping.Delay}} {{.HostConfig.NetworkShaping.EgressRate}}", ctrID})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("100ms 5000000"))
+
+		session = podmanTest.Podman([]string{"update", "--network-rate-limit", "ingress=10mbit,egress=0", "--network-loss", "1%", ctrID})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		// the settings are kept after a restart
+		session = podmanTest.Podman([]string{"restart", ctrID})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.NetworkShaping.Delay}} {{.HostConfig.NetworkShaping.IngressRate}} {{.HostConfig.NetworkShaping.EgressRate}} {{.HostConfig.NetworkShaping.Loss}}", ctrID})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("100ms 10000000 0 1"))
+
+		session = podmanTest.Podman([]string{"update", "--network-delay", "1h", ctrID})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("invalid network delay"))
+
+		session = podmanTest.Podman([]string{"create", "--network", "host", "--network-delay", "100ms", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("network shaping can only be used with bridge, slirp4netns or pasta networking"))
+	})
 })
@@ -130,4 +130,19 @@ var _ = Describe("Podman wait", func() {
 		Expect(session).Should(Exit(0))
 		Expect(session.OutputToStringArray()).To(Equal([]string{"0", "0", "0"}))
 	})
+	It("podman wait --condition=ready", func() {
+		session := podmanTest.Podman([]string{"run", "-d", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		cid := session.OutputToString()
+
+		// Containers without a readiness check are ready once running.
+		session = podmanTest.Podman([]string{"wait", "--condition", "ready", cid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"wait", "--condition", "bogus", cid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(125))
+	})
 })
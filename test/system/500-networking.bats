@@ -267,7 +267,6 @@ load helpers.network
 }
 
 @test "podman network reload" {
-    skip_if_remote "podman network reload does not have remote support"
 
     random_1=$(random_string 30)
     HOST_PORT=$(random_free_port)
@@ -6,7 +6,6 @@
 load helpers
 
 @test "podman image trust set" {
-      skip_if_remote "trust only works locally"
       policypath=$PODMAN_TMPDIR/policy.json
       run_podman 125 image trust set --policypath=$policypath --type=bogus default
       is "$output" "Error: invalid choice: bogus.*" "error from --type=bogus"